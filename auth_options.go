@@ -84,6 +84,17 @@ type AuthOptions struct {
 
 	// Scope determines the scoping of the authentication request.
 	Scope *AuthScope `json:"-"`
+
+	// Authentication through Application Credentials requires supplying
+	// either ApplicationCredentialID, or ApplicationCredentialName together
+	// with the UserID or the Username and DomainID or DomainName of the user
+	// who owns the credential. ApplicationCredentialSecret is always required.
+	// Tokens issued for an application credential are scoped to the project
+	// the credential belongs to, so no scope is inferred from TenantID or
+	// TenantName.
+	ApplicationCredentialID     string `json:"-"`
+	ApplicationCredentialName   string `json:"-"`
+	ApplicationCredentialSecret string `json:"-"`
 }
 
 // AuthScope allows a created token to be limited to a specific domain or project.
@@ -92,6 +103,11 @@ type AuthScope struct {
 	ProjectName string
 	DomainID    string
 	DomainName  string
+
+	// System requests a system-scoped token, which is needed for
+	// operations on the deployment as a whole. It can't be combined with a
+	// project or domain.
+	System bool
 }
 
 // ToTokenV2CreateMap allows AuthOptions to satisfy the AuthOptionsBuilder
@@ -142,7 +158,7 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 	type userReq struct {
 		ID       *string    `json:"id,omitempty"`
 		Name     *string    `json:"name,omitempty"`
-		Password string     `json:"password"`
+		Password string     `json:"password,omitempty"`
 		Domain   *domainReq `json:"domain,omitempty"`
 	}
 
@@ -154,10 +170,18 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 		ID string `json:"id"`
 	}
 
+	type applicationCredentialReq struct {
+		ID     *string  `json:"id,omitempty"`
+		Name   *string  `json:"name,omitempty"`
+		User   *userReq `json:"user,omitempty"`
+		Secret *string  `json:"secret,omitempty"`
+	}
+
 	type identityReq struct {
-		Methods  []string     `json:"methods"`
-		Password *passwordReq `json:"password,omitempty"`
-		Token    *tokenReq    `json:"token,omitempty"`
+		Methods               []string                  `json:"methods"`
+		Password              *passwordReq              `json:"password,omitempty"`
+		Token                 *tokenReq                 `json:"token,omitempty"`
+		ApplicationCredential *applicationCredentialReq `json:"application_credential,omitempty"`
 	}
 
 	type authReq struct {
@@ -194,6 +218,51 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 			req.Auth.Identity.Token = &tokenReq{
 				ID: opts.TokenID,
 			}
+		} else if opts.ApplicationCredentialID != "" {
+			// Configure the request for ApplicationCredentialID authentication.
+			// The credential ID identifies the user, so no user is sent.
+			if opts.ApplicationCredentialSecret == "" {
+				return nil, ErrAppCredMissingSecret{}
+			}
+			req.Auth.Identity.Methods = []string{"application_credential"}
+			req.Auth.Identity.ApplicationCredential = &applicationCredentialReq{
+				ID:     &opts.ApplicationCredentialID,
+				Secret: &opts.ApplicationCredentialSecret,
+			}
+		} else if opts.ApplicationCredentialName != "" {
+			// Configure the request for ApplicationCredentialName authentication.
+			// Credential names are only unique per user, so the user must be
+			// identified either by ID or by name and domain.
+			if opts.ApplicationCredentialSecret == "" {
+				return nil, ErrAppCredMissingSecret{}
+			}
+
+			var user *userReq
+			switch {
+			case opts.UserID != "":
+				user = &userReq{ID: &opts.UserID}
+			case opts.Username == "":
+				return nil, ErrUsernameOrUserID{}
+			case opts.DomainID != "":
+				user = &userReq{
+					Name:   &opts.Username,
+					Domain: &domainReq{ID: &opts.DomainID},
+				}
+			case opts.DomainName != "":
+				user = &userReq{
+					Name:   &opts.Username,
+					Domain: &domainReq{Name: &opts.DomainName},
+				}
+			default:
+				return nil, ErrDomainIDOrDomainName{}
+			}
+
+			req.Auth.Identity.Methods = []string{"application_credential"}
+			req.Auth.Identity.ApplicationCredential = &applicationCredentialReq{
+				Name:   &opts.ApplicationCredentialName,
+				User:   user,
+				Secret: &opts.ApplicationCredentialSecret,
+			}
 		} else {
 			// If no password or token ID are available, authentication can't continue.
 			return nil, ErrMissingPassword{}
@@ -274,6 +343,12 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 }
 
 func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
+	// An application credential is bound to a project, and Keystone rejects
+	// requests that try to choose a scope for it.
+	if opts.Scope == nil && opts.usesApplicationCredential() {
+		return nil, nil
+	}
+
 	// For backwards compatibility.
 	// If AuthOptions.Scope was not set, try to determine it.
 	// This works well for common scenarios.
@@ -290,6 +365,21 @@ func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
 		}
 	}
 
+	if opts.Scope.System {
+		// System scope provided. ProjectID, ProjectName, DomainID, and
+		// DomainName may not be provided.
+		if opts.Scope.ProjectID != "" || opts.Scope.ProjectName != "" ||
+			opts.Scope.DomainID != "" || opts.Scope.DomainName != "" {
+			return nil, ErrScopeSystemAlone{}
+		}
+
+		return map[string]interface{}{
+			"system": map[string]interface{}{
+				"all": true,
+			},
+		}, nil
+	}
+
 	if opts.Scope.ProjectName != "" {
 		// ProjectName provided: either DomainID or DomainName must also be supplied.
 		// ProjectID may not be supplied.
@@ -358,6 +448,13 @@ func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
 	return nil, nil
 }
 
+// usesApplicationCredential reports whether the options authenticate with an
+// application credential rather than a password or token.
+func (opts *AuthOptions) usesApplicationCredential() bool {
+	return opts.Password == "" && opts.TokenID == "" &&
+		(opts.ApplicationCredentialID != "" || opts.ApplicationCredentialName != "")
+}
+
 func (opts AuthOptions) CanReauth() bool {
 	return opts.AllowReauth
 }
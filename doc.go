@@ -86,6 +86,30 @@ intermediary processing on each page, you can use the AllPages method:
 	allPages, err := servers.List(client, nil).AllPages()
 	allServers, err := servers.ExtractServers(allPages)
 
+To process a large collection without holding it in memory, iterate over its
+items with the Items method, passing the extraction function. Pages are
+requested as the iteration reaches them:
+
+	it := servers.List(client, nil).Items(servers.ExtractServers)
+	it.MaxItems = 1000
+	for it.Next() {
+		server := it.Item().(servers.Server)
+		// Handle the server.
+	}
+	if err := it.Err(); err != nil {
+		panic(err)
+	}
+
+A long listing can save its progress with the opaque cursor of the page after
+the one it has processed, and resume from it later. WithPrefetch requests the
+next page while the current one is processed:
+
+	pager := objects.List(client, "container", nil).WithPrefetch()
+	it := pager.WithCursor(savedCursor).Iterator()
+	for it.Next() {
+		// Handle it.Page(), then save it.Cursor().
+	}
+
 This top-level package contains utility functions and data types that are used
 throughout the provider and service packages. Of particular note for end users
 are the AuthOptions and EndpointOpts structs.
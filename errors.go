@@ -2,6 +2,7 @@ package gophercloud
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -46,6 +47,22 @@ func (e ErrInvalidInput) Error() string {
 	return e.choseErrString()
 }
 
+// ErrInvalidOptions is the error when several fields of the options of a
+// request are missing or invalid. Errors holds an error for each of them.
+type ErrInvalidOptions struct {
+	BaseError
+	Errors []error
+}
+
+func (e ErrInvalidOptions) Error() string {
+	s := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		s[i] = err.Error()
+	}
+	e.DefaultErrString = fmt.Sprintf("Invalid options: %s", strings.Join(s, "; "))
+	return e.choseErrString()
+}
+
 // ErrMissingEnvironmentVariable is the error when environment variable is required
 // in a particular situation but not provided by the user
 type ErrMissingEnvironmentVariable struct {
@@ -73,6 +90,27 @@ func (e ErrMissingAnyoneOfEnvironmentVariables) Error() string {
 	return e.choseErrString()
 }
 
+// ErrMicroversionRequired is returned when an operation or field requires a
+// higher microversion than the one the ServiceClient is configured to use.
+type ErrMicroversionRequired struct {
+	BaseError
+	Feature  string
+	Required string
+	Current  string
+}
+
+func (e ErrMicroversionRequired) Error() string {
+	current := e.Current
+	if current == "" {
+		current = "none"
+	}
+	e.DefaultErrString = fmt.Sprintf(
+		"%s requires microversion %s or later, but the client uses microversion %s",
+		e.Feature, e.Required, current,
+	)
+	return e.choseErrString()
+}
+
 // ErrUnexpectedResponseCode is returned by the Request method when a response code other than
 // those listed in OkCodes is encountered.
 type ErrUnexpectedResponseCode struct {
@@ -82,6 +120,16 @@ type ErrUnexpectedResponseCode struct {
 	Expected []int
 	Actual   int
 	Body     []byte
+
+	// ResponseHeader contains the headers of the failed response.
+	ResponseHeader http.Header
+
+	// RequestID is the ID the service assigned to the failed request, which
+	// can be used to find the request in the service's logs.
+	RequestID string
+
+	// Fault is the service's description of the error, parsed from Body.
+	Fault Fault
 }
 
 func (e ErrUnexpectedResponseCode) Error() string {
@@ -424,6 +472,14 @@ func (e ErrMissingPassword) Error() string {
 	return "You must provide a password to authenticate"
 }
 
+// ErrAppCredMissingSecret indicates that an application credential was
+// provided without its secret.
+type ErrAppCredMissingSecret struct{ BaseError }
+
+func (e ErrAppCredMissingSecret) Error() string {
+	return "You must provide an ApplicationCredentialSecret to authenticate with an application credential"
+}
+
 // ErrScopeDomainIDOrDomainName indicates that a domain ID or Name was required in a Scope, but not present.
 type ErrScopeDomainIDOrDomainName struct{ BaseError }
 
@@ -445,6 +501,14 @@ func (e ErrScopeProjectIDAlone) Error() string {
 	return "ProjectID must be supplied alone in a Scope"
 }
 
+// ErrScopeSystemAlone indicates that a system scope was requested together
+// with a project or domain in a Scope.
+type ErrScopeSystemAlone struct{ BaseError }
+
+func (e ErrScopeSystemAlone) Error() string {
+	return "A system scope can't be combined with a Project or Domain in a Scope"
+}
+
 // ErrScopeEmpty indicates that no credentials were provided in a Scope.
 type ErrScopeEmpty struct{ BaseError }
 
@@ -0,0 +1,177 @@
+package gophercloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Fault describes an error returned by an OpenStack service, parsed from the
+// body of an unsuccessful response. The services wrap their errors in
+// different envelopes; ParseFault recognizes the common ones and maps them
+// onto this single structure.
+type Fault struct {
+	// Type is the service-specific classification of the fault, such as
+	// "itemNotFound" for Nova and Cinder, "PortNotFound" for Neutron or
+	// "StackValidationFailed" for Heat. It may be empty.
+	Type string
+
+	// Message is the human readable description of the fault.
+	Message string
+
+	// Detail holds any additional explanation provided by the service.
+	Detail string
+}
+
+// requestIDHeaders lists the response headers that carry the ID the service
+// assigned to a request, in order of preference.
+var requestIDHeaders = []string{
+	"X-Openstack-Request-Id",
+	"X-Compute-Request-Id",
+	"X-Trans-Id",
+}
+
+// RequestIDFromHeader returns the ID that an OpenStack service assigned to a
+// request, as reported in the X-Openstack-Request-Id, X-Compute-Request-Id or,
+// for Swift, X-Trans-Id response header. It returns "" if none is present.
+func RequestIDFromHeader(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// faultDetails is the union of the fields used by the JSON error envelopes
+// of the various OpenStack services.
+type faultDetails struct {
+	Type        string      `json:"type"`
+	Title       string      `json:"title"`
+	Message     string      `json:"message"`
+	Detail      interface{} `json:"detail"`
+	Details     interface{} `json:"details"`
+	Explanation string      `json:"explanation"`
+	FaultString string      `json:"faultstring"`
+	DebugInfo   interface{} `json:"debuginfo"`
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// ParseFault extracts a Fault from the body of an unsuccessful response. It
+// understands the following formats:
+//
+//	{"badRequest": {"message": "...", "code": 400}}              (Nova, Cinder, Manila)
+//	{"NeutronError": {"type": "...", "message": "...", "detail": "..."}}
+//	{"error": {"type": "...", "message": "...", "title": "..."}}   (Heat, Keystone)
+//	{"errors": [{"title": "...", "detail": "..."}]}                (Placement, Ironic)
+//	{"faultcode": "...", "faultstring": "..."}                     (Octavia)
+//	{"code": 404, "type": "...", "message": "..."}                 (Designate)
+//
+// Any other body, such as Swift's plain text or HTML, is used as the message
+// with markup removed. ParseFault returns a zero Fault for an empty body.
+func ParseFault(body []byte) Fault {
+	trimmed := strings.TrimSpace(string(body))
+	if trimmed == "" {
+		return Fault{}
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Fault{Message: plainTextMessage(trimmed)}
+	}
+
+	// Heat and Keystone nest the fault under "error"; Heat additionally puts
+	// an explanation at the top level.
+	if raw, ok := envelope["error"]; ok {
+		var d faultDetails
+		if json.Unmarshal(raw, &d) == nil && (d.Message != "" || d.Type != "") {
+			f := d.fault(d.Title)
+			if f.Detail == "" {
+				var top faultDetails
+				json.Unmarshal(body, &top)
+				f.Detail = top.Explanation
+			}
+			return f
+		}
+	}
+
+	if raw, ok := envelope["errors"]; ok {
+		var ds []faultDetails
+		if json.Unmarshal(raw, &ds) == nil && len(ds) > 0 {
+			f := ds[0].fault("")
+			if f.Message == "" {
+				f.Message = ds[0].Title
+			} else if f.Type == "" {
+				f.Type = ds[0].Title
+			}
+			return f
+		}
+	}
+
+	var top faultDetails
+	if json.Unmarshal(body, &top) == nil {
+		if top.FaultString != "" {
+			var faultCode string
+			if raw, ok := envelope["faultcode"]; ok {
+				json.Unmarshal(raw, &faultCode)
+			}
+			return Fault{Type: faultCode, Message: top.FaultString, Detail: stringify(top.DebugInfo)}
+		}
+		if top.Message != "" {
+			return top.fault("")
+		}
+	}
+
+	// Nova, Cinder, Manila and Neutron wrap the fault in a single key that
+	// names its type.
+	if len(envelope) == 1 {
+		for k, raw := range envelope {
+			var d faultDetails
+			if json.Unmarshal(raw, &d) == nil && (d.Message != "" || d.Type != "") {
+				return d.fault(k)
+			}
+		}
+	}
+
+	return Fault{Message: trimmed}
+}
+
+func (d faultDetails) fault(defaultType string) Fault {
+	f := Fault{
+		Type:    d.Type,
+		Message: d.Message,
+		Detail:  stringify(d.Detail),
+	}
+	if f.Type == "" {
+		f.Type = defaultType
+	}
+	if f.Detail == "" {
+		f.Detail = stringify(d.Details)
+	}
+	return f
+}
+
+// stringify renders an arbitrary JSON value as a string. Strings are
+// returned as-is and null as "".
+func stringify(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// plainTextMessage strips HTML markup from a non-JSON error body and
+// collapses its whitespace.
+func plainTextMessage(s string) string {
+	s = htmlTag.ReplaceAllString(s, " ")
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,147 @@
+package gophercloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Logger receives a record of every HTTP request sent by a ProviderClient,
+// including the requests issued by pagers and by reauthentication.
+// Credentials are masked before the record is handed to the Logger.
+type Logger interface {
+	LogRequest(entry *RequestLogEntry)
+}
+
+// LoggerFunc is an adapter that allows an ordinary function to be used as a
+// Logger.
+type LoggerFunc func(entry *RequestLogEntry)
+
+// LogRequest calls f(entry).
+func (f LoggerFunc) LogRequest(entry *RequestLogEntry) {
+	f(entry)
+}
+
+// RequestLogEntry describes a single HTTP request and its response.
+type RequestLogEntry struct {
+	// Method and URL identify the request.
+	Method string
+	URL    string
+
+	// RequestHeader holds the request headers, with secrets masked.
+	RequestHeader http.Header
+
+	// RequestBody holds the JSON request body, with secrets masked. It is
+	// only set if ProviderClient.LogBodies is true; raw bodies, such as
+	// object uploads, are never recorded.
+	RequestBody []byte
+
+	// StatusCode and ResponseHeader describe the response. ResponseHeader has
+	// its secrets masked. Both are zero if Err is set.
+	StatusCode     int
+	ResponseHeader http.Header
+
+	// ResponseBody holds the JSON response body, with secrets masked. It is
+	// only set if ProviderClient.LogBodies is true.
+	ResponseBody []byte
+
+	// Duration is the time it took to receive the response.
+	Duration time.Duration
+
+	// Err is the transport error, if the request could not be completed.
+	Err error
+}
+
+// redactedValue replaces the value of any masked header or JSON field.
+const redactedValue = "***"
+
+// sensitiveHeaders lists the canonical names of headers whose values are
+// masked in log records.
+var sensitiveHeaders = map[string]bool{
+	"X-Auth-Token":           true,
+	"X-Subject-Token":        true,
+	"X-Service-Token":        true,
+	"X-Auth-Key":             true,
+	"Authorization":          true,
+	"Openstack-Auth-Receipt": true,
+}
+
+// sensitiveFields lists the lower-cased names of JSON fields whose values are
+// masked in log records.
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+	"admin_pass":    true,
+	"adminpass":     true,
+	"payload":       true,
+	"passcode":      true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+
+	// The blob of an Identity credential is a JSON document in a string,
+	// which holds the secret key of an EC2 credential.
+	"blob": true,
+}
+
+// redactHeader returns a copy of h in which the values of sensitive headers
+// are masked.
+func redactHeader(h http.Header) http.Header {
+	r := make(http.Header, len(h))
+	for k, v := range h {
+		if sensitiveHeaders[http.CanonicalHeaderKey(k)] {
+			r[k] = []string{redactedValue}
+			continue
+		}
+		r[k] = append([]string(nil), v...)
+	}
+	return r
+}
+
+// redactJSON returns a copy of the JSON document b in which the values of
+// sensitive fields are masked. Documents that cannot be parsed are replaced
+// entirely, since they may contain secrets in an unknown form.
+func redactJSON(b []byte) []byte {
+	if len(b) == 0 {
+		return b
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return []byte(redactedValue)
+	}
+	r, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return []byte(redactedValue)
+	}
+	return r
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range t {
+			if !sensitiveFields[strings.ToLower(k)] {
+				t[k] = redactValue(fv)
+				continue
+			}
+			// Objects such as {"token": {"id": "..."}} keep their shape so
+			// that the record stays readable; only their ID is masked.
+			if m, ok := fv.(map[string]interface{}); ok {
+				if _, ok := m["id"]; ok {
+					m["id"] = redactedValue
+				}
+				t[k] = redactValue(m)
+				continue
+			}
+			t[k] = redactedValue
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redactValue(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,97 @@
+package gophercloud
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ParseMicroversion splits a microversion such as "2.53" into its major and
+// minor parts. A leading "v", as used in version document IDs, is ignored.
+func ParseMicroversion(version string) (major, minor int, err error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	parts := strings.Split(v, ".")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid microversion format: %q", version)
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid microversion format: %q", version)
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid microversion format: %q", version)
+	}
+	return major, minor, nil
+}
+
+// CompareMicroversions returns -1, 0 or 1 depending on whether microversion
+// a is lower than, equal to or higher than microversion b.
+func CompareMicroversions(a, b string) (int, error) {
+	aMajor, aMinor, err := ParseMicroversion(a)
+	if err != nil {
+		return 0, err
+	}
+	bMajor, bMinor, err := ParseMicroversion(b)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case aMajor < bMajor || (aMajor == bMajor && aMinor < bMinor):
+		return -1, nil
+	case aMajor == bMajor && aMinor == bMinor:
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// RequireMicroversion returns an ErrMicroversionRequired if the client's
+// Microversion is lower than required. Resource packages call it before
+// issuing a request for an operation that was introduced in a later
+// microversion, so that callers get a clear error instead of a 404 or a
+// silently ignored parameter. feature names the operation in the error.
+func (client *ServiceClient) RequireMicroversion(feature, required string) error {
+	if client.Microversion != "" {
+		c, err := CompareMicroversions(client.Microversion, required)
+		if err != nil {
+			return err
+		}
+		if c >= 0 {
+			return nil
+		}
+	}
+	return ErrMicroversionRequired{
+		Feature:  feature,
+		Required: required,
+		Current:  client.Microversion,
+	}
+}
+
+// RequireMicroversionFields checks the fields of an options struct that
+// carry a `microversion:"x.y"` tag. Each such field that is set to a
+// non-zero value requires the client's Microversion to be at least x.y.
+// Values that aren't structs or pointers to structs are ignored.
+func (client *ServiceClient) RequireMicroversionFields(opts interface{}) error {
+	v := reflect.ValueOf(opts)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f := t.Field(i)
+		required := f.Tag.Get("microversion")
+		if required == "" || isZero(v.Field(i)) {
+			continue
+		}
+		if err := client.RequireMicroversion(t.Name()+"."+f.Name, required); err != nil {
+			return err
+		}
+	}
+	return nil
+}
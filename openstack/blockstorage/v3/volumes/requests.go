@@ -38,6 +38,9 @@ type CreateOpts struct {
 	ImageID string `json:"imageRef,omitempty"`
 	// The associated volume type
 	VolumeType string `json:"volume_type,omitempty"`
+	// The ID of the backup from which you want to create the volume.
+	// Requires microversion 3.47 or later.
+	BackupID string `json:"backup_id,omitempty" microversion:"3.47"`
 }
 
 // ToVolumeCreateMap assembles a request body based on the contents of a
@@ -50,27 +53,33 @@ func (opts CreateOpts) ToVolumeCreateMap() (map[string]interface{}, error) {
 // the Volume object from the response, call the Extract method on the
 // CreateResult.
 func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	if r.Err = client.RequireMicroversionFields(opts); r.Err != nil {
+		return
+	}
 	b, err := opts.ToVolumeCreateMap()
 	if err != nil {
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{202},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Delete will delete the existing Volume with the provided ID.
 func Delete(client *gophercloud.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	resp, err := client.Delete(deleteURL(client, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Get retrieves the Volume with the provided ID. To extract the Volume object
 // from the response, call the Extract method on the GetResult.
 func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	resp, err := client.Get(getURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -103,6 +112,9 @@ type ListOpts struct {
 	// form of <key>[:<direction>].
 	Sort string `q:"sort"`
 
+	// SortKeys sorts by several attributes, in place of Sort.
+	SortKeys []gophercloud.SortKey `q:"sort"`
+
 	// Requests a page size of items.
 	Limit int `q:"limit"`
 
@@ -111,10 +123,21 @@ type ListOpts struct {
 
 	// The ID of the last-seen item.
 	Marker string `q:"marker"`
+
+	// CreatedAt and UpdatedAt filter by the time the volumes were created
+	// and last updated at.
+	CreatedAt *gophercloud.TimeFilter `q:"created_at" microversion:"3.60"`
+	UpdatedAt *gophercloud.TimeFilter `q:"updated_at" microversion:"3.60"`
 }
 
 // ToVolumeListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToVolumeListQuery() (string, error) {
+	if len(opts.SortKeys) > 0 && opts.Sort != "" {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "volumes.ListOpts.SortKeys"
+		err.Info = "SortKeys can't be used together with Sort"
+		return "", err
+	}
 	q, err := gophercloud.BuildQueryString(opts)
 	return q.String(), err
 }
@@ -123,6 +146,9 @@ func (opts ListOpts) ToVolumeListQuery() (string, error) {
 func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
 	url := listURL(client)
 	if opts != nil {
+		if err := client.RequireMicroversionFields(opts); err != nil {
+			return pagination.Pager{Err: err}
+		}
 		query, err := opts.ToVolumeListQuery()
 		if err != nil {
 			return pagination.Pager{Err: err}
@@ -164,9 +190,10 @@ func Update(client *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Put(updateURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Put(updateURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
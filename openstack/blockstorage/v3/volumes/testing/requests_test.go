@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/volumetenants"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"github.com/gophercloud/gophercloud/pagination"
@@ -214,6 +215,52 @@ func TestCreate(t *testing.T) {
 	th.AssertEquals(t, n.ID, "d32019d3-bc6e-4319-9c1d-6722fc136a22")
 }
 
+func TestCreateFromBackupRequiresMicroversion(t *testing.T) {
+	options := &volumes.CreateOpts{Size: 75, BackupID: "20c792f0-bb03-434f-b653-06ef238e337e"}
+	_, err := volumes.Create(client.ServiceClient(), options).Extract()
+	if _, ok := err.(gophercloud.ErrMicroversionRequired); !ok {
+		t.Fatalf("expected ErrMicroversionRequired, got %T: %v", err, err)
+	}
+}
+
+func TestListQuery(t *testing.T) {
+	listOpts := volumes.ListOpts{
+		SortKeys: []gophercloud.SortKey{
+			{Key: "size", Direction: gophercloud.SortDesc},
+			{Key: "name"},
+		},
+		CreatedAt: &gophercloud.TimeFilter{
+			Operator: gophercloud.FilterGT,
+			Time:     time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	query, err := listOpts.ToVolumeListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?created_at=gt%3A2020-01-01T00%3A00%3A00Z&sort=size%3Adesc%2Cname", query)
+
+	// Filtering by time requires microversion 3.60.
+	err = volumes.List(client.ServiceClient(), listOpts).EachPage(func(pagination.Page) (bool, error) {
+		return false, nil
+	})
+	if _, ok := err.(gophercloud.ErrMicroversionRequired); !ok {
+		t.Fatalf("expected ErrMicroversionRequired, got %T: %v", err, err)
+	}
+}
+
+func TestListQueryBothSortStyles(t *testing.T) {
+	listOpts := volumes.ListOpts{
+		Sort: "name:asc",
+		SortKeys: []gophercloud.SortKey{
+			{Key: "size", Direction: gophercloud.SortDesc},
+		},
+	}
+	_, err := listOpts.ToVolumeListQuery()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("expected ErrInvalidInput, got %T: %v", err, err)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
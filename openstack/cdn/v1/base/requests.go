@@ -5,15 +5,17 @@ import "github.com/gophercloud/gophercloud"
 // Get retrieves the home document, allowing the user to discover the
 // entire API.
 func Get(c *gophercloud.ServiceClient) (r GetResult) {
-	_, r.Err = c.Get(getURL(c), &r.Body, nil)
+	resp, err := c.Get(getURL(c), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Ping retrieves a ping to the server.
 func Ping(c *gophercloud.ServiceClient) (r PingResult) {
-	_, r.Err = c.Get(pingURL(c), nil, &gophercloud.RequestOpts{
+	resp, err := c.Get(pingURL(c), nil, &gophercloud.RequestOpts{
 		OkCodes:     []int{204},
 		MoreHeaders: map[string]string{"Accept": ""},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
@@ -3,9 +3,12 @@ package openstack
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/gophercloud/gophercloud"
 	tokens2 "github.com/gophercloud/gophercloud/openstack/identity/v2/tokens"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/oauth1"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
 	tokens3 "github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/gophercloud/gophercloud/openstack/utils"
 )
@@ -92,6 +95,15 @@ func Authenticate(client *gophercloud.ProviderClient, options gophercloud.AuthOp
 		{ID: v3, Priority: 30, Suffix: "/v3/"},
 	}
 
+	// A cached token records which identity version issued it, which saves
+	// querying the identity endpoint for its versions.
+	if cached := cachedToken(client, tokenCacheKey(client, options), v3); cached != nil {
+		return v3auth(client, cached.IdentityEndpoint, &options, gophercloud.EndpointOpts{})
+	}
+	if cached := cachedToken(client, tokenCacheKey(client, options), v2); cached != nil {
+		return v2auth(client, cached.IdentityEndpoint, options, gophercloud.EndpointOpts{})
+	}
+
 	chosen, endpoint, err := utils.ChooseVersion(client, versions)
 	if err != nil {
 		return err
@@ -133,16 +145,22 @@ func v2auth(client *gophercloud.ProviderClient, endpoint string, options gopherc
 		TokenID:          options.TokenID,
 	}
 
-	result := tokens2.Create(v2Client, v2Opts)
+	key := tokenCacheKey(client, options)
+	token, catalog := cachedV2Token(client, key)
+	if token == nil {
+		result := tokens2.Create(v2Client, v2Opts)
 
-	token, err := result.ExtractToken()
-	if err != nil {
-		return err
-	}
+		token, err = result.ExtractToken()
+		if err != nil {
+			return err
+		}
 
-	catalog, err := result.ExtractServiceCatalog()
-	if err != nil {
-		return err
+		catalog, err = result.ExtractServiceCatalog()
+		if err != nil {
+			return err
+		}
+
+		storeToken(client, key, v2, v2Client.Endpoint, token.ID, token.ExpiresAt, catalog)
 	}
 
 	if options.AllowReauth {
@@ -152,6 +170,7 @@ func v2auth(client *gophercloud.ProviderClient, endpoint string, options gopherc
 		tac := *client
 		tac.ReauthFunc = nil
 		tac.TokenID = ""
+		tac.TokenExpiresAt = time.Time{}
 		tao := options
 		tao.AllowReauth = false
 		client.ReauthFunc = func() error {
@@ -160,10 +179,13 @@ func v2auth(client *gophercloud.ProviderClient, endpoint string, options gopherc
 				return err
 			}
 			client.TokenID = tac.TokenID
+			client.TokenExpiresAt = tac.TokenExpiresAt
 			return nil
 		}
 	}
 	client.TokenID = token.ID
+	client.TokenExpiresAt = token.ExpiresAt
+	client.TokenCacheKey = key
 	client.EndpointLocator = func(opts gophercloud.EndpointOpts) (string, error) {
 		return V2EndpointURL(catalog, opts)
 	}
@@ -187,19 +209,27 @@ func v3auth(client *gophercloud.ProviderClient, endpoint string, opts tokens3.Au
 		v3Client.Endpoint = endpoint
 	}
 
-	result := tokens3.Create(v3Client, opts)
+	key := tokenCacheKey(client, opts)
+	token, catalog := cachedV3Token(client, key)
+	if token == nil {
+		result := tokens3.Create(v3Client, opts)
 
-	token, err := result.ExtractToken()
-	if err != nil {
-		return err
-	}
+		token, err = result.ExtractToken()
+		if err != nil {
+			return err
+		}
 
-	catalog, err := result.ExtractServiceCatalog()
-	if err != nil {
-		return err
+		catalog, err = result.ExtractServiceCatalog()
+		if err != nil {
+			return err
+		}
+
+		storeToken(client, key, v3, v3Client.Endpoint, token.ID, token.ExpiresAt, catalog)
 	}
 
 	client.TokenID = token.ID
+	client.TokenExpiresAt = token.ExpiresAt
+	client.TokenCacheKey = key
 
 	if opts.CanReauth() {
 		// here we're creating a throw-away client (tac). it's a copy of the user's provider client, but
@@ -208,25 +238,15 @@ func v3auth(client *gophercloud.ProviderClient, endpoint string, opts tokens3.Au
 		tac := *client
 		tac.ReauthFunc = nil
 		tac.TokenID = ""
-		var tao tokens3.AuthOptionsBuilder
-		switch ot := opts.(type) {
-		case *gophercloud.AuthOptions:
-			o := *ot
-			o.AllowReauth = false
-			tao = &o
-		case *tokens3.AuthOptions:
-			o := *ot
-			o.AllowReauth = false
-			tao = &o
-		default:
-			tao = opts
-		}
+		tac.TokenExpiresAt = time.Time{}
+		tao := disableReauth(opts)
 		client.ReauthFunc = func() error {
 			err := v3auth(&tac, endpoint, tao, eo)
 			if err != nil {
 				return err
 			}
 			client.TokenID = tac.TokenID
+			client.TokenExpiresAt = tac.TokenExpiresAt
 			return nil
 		}
 	}
@@ -237,6 +257,46 @@ func v3auth(client *gophercloud.ProviderClient, endpoint string, opts tokens3.Au
 	return nil
 }
 
+// disableReauth returns a copy of opts that doesn't allow reauthentication,
+// so that the throw-away client of a ReauthFunc authenticates only once.
+// Trust-scoped options are unwrapped and rewrapped with the same trust, so
+// that the new token is scoped to the trust again.
+func disableReauth(opts tokens3.AuthOptionsBuilder) tokens3.AuthOptionsBuilder {
+	switch ot := opts.(type) {
+	case *gophercloud.AuthOptions:
+		o := *ot
+		o.AllowReauth = false
+		return &o
+	case *tokens3.AuthOptions:
+		o := *ot
+		o.AllowReauth = false
+		return &o
+	case *tokens3.MultiMethodAuthOptions:
+		// A receipt is only valid briefly, so reauthentication
+		// has to satisfy all of the methods again.
+		o := *ot
+		o.AllowReauth = false
+		o.Receipt = ""
+		return &o
+	case oauth1.AuthOptions:
+		ot.AllowReauth = false
+		return ot
+	case *oauth1.AuthOptions:
+		o := *ot
+		o.AllowReauth = false
+		return &o
+	case trusts.AuthOptsExt:
+		ot.AuthOptionsBuilder = disableReauth(ot.AuthOptionsBuilder)
+		return ot
+	case *trusts.AuthOptsExt:
+		o := *ot
+		o.AuthOptionsBuilder = disableReauth(o.AuthOptionsBuilder)
+		return o
+	default:
+		return opts
+	}
+}
+
 // NewIdentityV2 creates a ServiceClient that may be used to interact with the
 // v2 identity service.
 func NewIdentityV2(client *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error) {
@@ -0,0 +1,101 @@
+/*
+Package clientconfig loads cloud configuration from the clouds.yaml,
+secure.yaml and clouds-public.yaml files shared by the OpenStack command-line
+tools and SDKs.
+
+The files are searched for in the current directory, in
+$XDG_CONFIG_HOME/openstack (usually ~/.config/openstack) and in
+/etc/openstack, and the first file of each name wins. OS_CLIENT_CONFIG_FILE
+and OS_CLIENT_SECURE_FILE may point at a specific clouds.yaml or secure.yaml.
+
+A cloud is selected by ClientOpts.Cloud or, failing that, the OS_CLOUD
+environment variable. Its settings are built up in the following order,
+later sources overriding earlier ones:
+
+ 1. the profile named by the cloud's "profile" key, from clouds-public.yaml
+ 2. the cloud's entry in clouds.yaml
+ 3. the cloud's entry in secure.yaml
+ 4. OS_* environment variables, such as OS_PASSWORD or OS_REGION_NAME
+
+If no cloud is selected, the settings come from the environment alone.
+
+Example to Create an Authenticated Client
+
+	provider, err := clientconfig.AuthenticatedClient(&clientconfig.ClientOpts{
+		Cloud: "mycloud",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to Build Options From a Cloud
+
+	cloud, err := clientconfig.GetCloud(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	authOptions, err := cloud.AuthOptions()
+	if err != nil {
+		panic(err)
+	}
+
+	tlsConfig, err := cloud.TLSConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	provider, err := openstack.NewClient(authOptions.IdentityEndpoint)
+	provider.HTTPClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	err = openstack.Authenticate(provider, *authOptions)
+
+	computeClient, err := openstack.NewComputeV2(provider, cloud.EndpointOpts())
+
+A Manager creates and caches the clients of several clouds. It authenticates
+to each cloud the first time it's used, and shares the token among the
+service clients of all its regions and interfaces.
+
+	manager := &clientconfig.Manager{
+		Source:             clientconfig.FileSource{},
+		TokenRenewalWindow: 5 * time.Minute,
+	}
+
+	computeClient, err := manager.ServiceClient(clientconfig.Target{
+		Cloud:  "mycloud",
+		Region: "RegionTwo",
+	}, "compute")
+	if err != nil {
+		panic(err)
+	}
+
+	networkClient, err := manager.ServiceClient(clientconfig.Target{
+		Cloud:        "othercloud",
+		Availability: gophercloud.AvailabilityInternal,
+	}, "network")
+	if err != nil {
+		panic(err)
+	}
+
+Example clouds.yaml
+
+	clouds:
+	  mycloud:
+	    profile: rackspace
+	    auth:
+	      auth_url: https://keystone.example.com:5000/v3
+	      username: demo
+	      project_name: demo
+	      user_domain_name: Default
+	      project_domain_name: Default
+	    region_name: RegionOne
+	    interface: public
+	    cacert: /etc/ssl/certs/example-ca.pem
+
+Example secure.yaml
+
+	clouds:
+	  mycloud:
+	    auth:
+	      password: secret
+*/
+package clientconfig
@@ -0,0 +1,51 @@
+package clientconfig
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrCloudNotFound is returned when the selected cloud is not defined in
+// clouds.yaml or secure.yaml.
+type ErrCloudNotFound struct {
+	gophercloud.BaseError
+	Cloud string
+}
+
+func (e ErrCloudNotFound) Error() string {
+	return fmt.Sprintf("Cloud %q was not found in clouds.yaml or secure.yaml", e.Cloud)
+}
+
+// ErrProfileNotFound is returned when a cloud refers to a profile that is not
+// defined in clouds-public.yaml.
+type ErrProfileNotFound struct {
+	gophercloud.BaseError
+	Profile string
+}
+
+func (e ErrProfileNotFound) Error() string {
+	return fmt.Sprintf("Profile %q was not found in clouds-public.yaml", e.Profile)
+}
+
+// ErrUnsupportedAuthType is returned for an auth_type that can't be mapped
+// onto gophercloud.AuthOptions.
+type ErrUnsupportedAuthType struct {
+	gophercloud.BaseError
+	AuthType string
+}
+
+func (e ErrUnsupportedAuthType) Error() string {
+	return fmt.Sprintf("Unsupported auth_type %q", e.AuthType)
+}
+
+// ErrServiceNotRegistered is returned by Manager.ServiceClient for a service
+// type that has no ServiceFunc.
+type ErrServiceNotRegistered struct {
+	gophercloud.BaseError
+	Service string
+}
+
+func (e ErrServiceNotRegistered) Error() string {
+	return fmt.Sprintf("No client is registered for service %q", e.Service)
+}
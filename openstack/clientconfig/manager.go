@@ -0,0 +1,281 @@
+package clientconfig
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+)
+
+// CloudSource provides the configuration of clouds by name.
+type CloudSource interface {
+	GetCloud(name string) (*Cloud, error)
+}
+
+// FileSource is a CloudSource that reads clouds from the configuration files
+// and the environment, as GetCloud does.
+//
+// The environment variables apply to every cloud, so OS_PASSWORD would
+// override the password of all of them. Set EnvPrefix to a prefix that is not
+// in use to ignore the environment.
+type FileSource struct {
+	// EnvPrefix is the prefix of the environment variables to read. It
+	// defaults to "OS_".
+	EnvPrefix string
+
+	// ConfigDirs replaces the directories that are searched for
+	// clouds.yaml, secure.yaml and clouds-public.yaml.
+	ConfigDirs []string
+}
+
+// GetCloud implements CloudSource.
+func (s FileSource) GetCloud(name string) (*Cloud, error) {
+	return GetCloud(&ClientOpts{
+		Cloud:      name,
+		EnvPrefix:  s.EnvPrefix,
+		ConfigDirs: s.ConfigDirs,
+	})
+}
+
+// Clouds is a CloudSource backed by a map of clouds, for configuration that
+// doesn't come from clouds.yaml.
+type Clouds map[string]*Cloud
+
+// GetCloud implements CloudSource.
+func (c Clouds) GetCloud(name string) (*Cloud, error) {
+	cloud, ok := c[name]
+	if !ok {
+		return nil, ErrCloudNotFound{Cloud: name}
+	}
+	return cloud, nil
+}
+
+// ServiceFunc creates the client of a service, such as openstack.NewComputeV2.
+type ServiceFunc func(*gophercloud.ProviderClient, gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error)
+
+// DefaultServices maps the service types of the catalog to the functions
+// that create their clients. It is used by a Manager without Services.
+var DefaultServices = map[string]ServiceFunc{
+	"cdn":           openstack.NewCDNV1,
+	"clustering":    openstack.NewClusteringV1,
+	"compute":       openstack.NewComputeV2,
+	"container":     openstack.NewContainerV1,
+	"database":      openstack.NewDBV1,
+	"dns":           openstack.NewDNSV2,
+	"identity":      openstack.NewIdentityV3,
+	"image":         openstack.NewImageServiceV2,
+	"key-manager":   openstack.NewKeyManagerV1,
+	"load-balancer": openstack.NewLoadBalancerV2,
+	"network":       openstack.NewNetworkV2,
+	"object-store":  openstack.NewObjectStorageV1,
+	"orchestration": openstack.NewOrchestrationV1,
+	"sharev2":       openstack.NewSharedFileSystemV2,
+	"volume":        openstack.NewBlockStorageV1,
+	"volumev2":      openstack.NewBlockStorageV2,
+	"volumev3":      openstack.NewBlockStorageV3,
+}
+
+// Target selects the endpoints of a cloud that a service client talks to.
+type Target struct {
+	// Cloud is the name of the cloud.
+	Cloud string
+
+	// Region is the region of the endpoints. It defaults to the region of
+	// the cloud.
+	Region string
+
+	// Availability is the interface of the endpoints. It defaults to the
+	// interface of the cloud.
+	Availability gophercloud.Availability
+}
+
+// Manager lazily creates and caches authenticated clients for any number of
+// clouds. It creates a single ProviderClient per cloud, which the service
+// clients of all its regions and interfaces share, so that they use the same
+// token. A Manager is safe for concurrent use.
+//
+// A ProviderClient that fails to reauthenticate, for example because its
+// credentials were revoked, is evicted: the clients already handed out keep
+// failing, but the next call to the Manager authenticates again.
+type Manager struct {
+	// Source provides the configuration of the clouds. It defaults to a
+	// FileSource that reads the standard locations.
+	Source CloudSource
+
+	// Services maps service types to the functions that create their
+	// clients. It defaults to DefaultServices.
+	Services map[string]ServiceFunc
+
+	// TokenRenewalWindow is set on every ProviderClient, so that tokens are
+	// renewed before they expire.
+	TokenRenewalWindow time.Duration
+
+	// TokenCache is set on every ProviderClient, so that tokens are shared
+	// with other processes.
+	TokenCache gophercloud.TokenCache
+
+	// ConfigureClient, if set, is called with every new ProviderClient
+	// before it authenticates. It may set a Logger or RetryPolicy, for
+	// example.
+	ConfigureClient func(cloud string, client *gophercloud.ProviderClient) error
+
+	mu        sync.Mutex
+	providers map[string]*managedProvider
+}
+
+// managedProvider is the ProviderClient of a cloud along with the service
+// clients created from it.
+type managedProvider struct {
+	mu       sync.Mutex
+	cloud    *Cloud
+	client   *gophercloud.ProviderClient
+	services map[serviceKey]*gophercloud.ServiceClient
+}
+
+type serviceKey struct {
+	service      string
+	region       string
+	availability gophercloud.Availability
+}
+
+// ProviderClient returns the authenticated ProviderClient of a cloud.
+func (m *Manager) ProviderClient(cloud string) (*gophercloud.ProviderClient, error) {
+	p, err := m.provider(cloud)
+	if err != nil {
+		return nil, err
+	}
+	return p.client, nil
+}
+
+// ServiceClient returns the client of a service, given by its catalog type
+// such as "compute", for the endpoints selected by t.
+func (m *Manager) ServiceClient(t Target, service string) (*gophercloud.ServiceClient, error) {
+	services := m.Services
+	if services == nil {
+		services = DefaultServices
+	}
+	newClient, ok := services[service]
+	if !ok {
+		return nil, ErrServiceNotRegistered{Service: service}
+	}
+
+	p, err := m.provider(t.Cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	eo := p.cloud.EndpointOpts()
+	if t.Region != "" {
+		eo.Region = t.Region
+	}
+	if t.Availability != "" {
+		eo.Availability = t.Availability
+	}
+	key := serviceKey{service: service, region: eo.Region, availability: eo.Availability}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if sc, ok := p.services[key]; ok {
+		return sc, nil
+	}
+	sc, err := newClient(p.client, eo)
+	if err != nil {
+		return nil, err
+	}
+	p.services[key] = sc
+	return sc, nil
+}
+
+// Evict discards the clients of a cloud, so that the next call to the
+// Manager reads its configuration and authenticates again. The clients
+// already handed out keep working.
+func (m *Manager) Evict(cloud string) {
+	m.mu.Lock()
+	delete(m.providers, cloud)
+	m.mu.Unlock()
+}
+
+// provider returns the entry of a cloud, authenticating if it is new. Only
+// the cloud being authenticated is locked, so a slow or unreachable cloud
+// doesn't hold up the others. An entry that failed to authenticate is
+// retried by the next call.
+func (m *Manager) provider(name string) (*managedProvider, error) {
+	m.mu.Lock()
+	if m.providers == nil {
+		m.providers = make(map[string]*managedProvider)
+	}
+	p, ok := m.providers[name]
+	if !ok {
+		p = new(managedProvider)
+		m.providers[name] = p
+	}
+	m.mu.Unlock()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != nil {
+		return p, nil
+	}
+
+	source := m.Source
+	if source == nil {
+		source = FileSource{}
+	}
+	cloud, err := source.GetCloud(name)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := m.authenticate(name, p, cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cloud = cloud
+	p.client = client
+	p.services = make(map[serviceKey]*gophercloud.ServiceClient)
+	return p, nil
+}
+
+// authenticate creates the ProviderClient of cloud. Its ReauthFunc evicts p
+// if reauthentication fails.
+func (m *Manager) authenticate(name string, p *managedProvider, cloud *Cloud) (*gophercloud.ProviderClient, error) {
+	client, ao, err := newClient(cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	client.TokenRenewalWindow = m.TokenRenewalWindow
+	client.TokenCache = m.TokenCache
+	if m.ConfigureClient != nil {
+		if err := m.ConfigureClient(name, client); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := openstack.Authenticate(client, *ao); err != nil {
+		return nil, err
+	}
+
+	if reauth := client.ReauthFunc; reauth != nil {
+		client.ReauthFunc = func() error {
+			err := reauth()
+			if err != nil {
+				m.evict(name, p)
+			}
+			return err
+		}
+	}
+
+	return client, nil
+}
+
+// evict discards the entry of a cloud unless it was already replaced.
+func (m *Manager) evict(name string, p *managedProvider) {
+	m.mu.Lock()
+	if m.providers[name] == p {
+		delete(m.providers, name)
+	}
+	m.mu.Unlock()
+}
@@ -0,0 +1,208 @@
+package clientconfig
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ClientOpts represents options to customize how a cloud is selected and
+// where its configuration is read from.
+type ClientOpts struct {
+	// Cloud is the name of the cloud to use. It takes precedence over the
+	// OS_CLOUD environment variable.
+	Cloud string
+
+	// EnvPrefix is the prefix of the environment variables to read. It
+	// defaults to "OS_".
+	EnvPrefix string
+
+	// ConfigDirs replaces the directories that are searched for
+	// clouds.yaml, secure.yaml and clouds-public.yaml.
+	ConfigDirs []string
+}
+
+func (opts *ClientOpts) envPrefix() string {
+	if opts == nil || opts.EnvPrefix == "" {
+		return "OS_"
+	}
+	return opts.EnvPrefix
+}
+
+// GetCloud returns the configuration of the selected cloud. See the package
+// documentation for how it is assembled. opts may be nil.
+func GetCloud(opts *ClientOpts) (*Cloud, error) {
+	prefix := opts.envPrefix()
+	var dirs []string
+	name := os.Getenv(prefix + "CLOUD")
+	if opts != nil {
+		dirs = opts.ConfigDirs
+		if opts.Cloud != "" {
+			name = opts.Cloud
+		}
+	}
+	if dirs == nil {
+		dirs = configDirs()
+	}
+
+	merged := make(map[interface{}]interface{})
+
+	if name != "" {
+		clouds, err := loadClouds(findFile(os.Getenv("OS_CLIENT_CONFIG_FILE"), dirs, "clouds.yaml", "clouds.yml"), "clouds")
+		if err != nil {
+			return nil, err
+		}
+		secure, err := loadClouds(findFile(os.Getenv("OS_CLIENT_SECURE_FILE"), dirs, "secure.yaml", "secure.yml"), "clouds")
+		if err != nil {
+			return nil, err
+		}
+
+		entry, inClouds := clouds[name].(map[interface{}]interface{})
+		secureEntry, inSecure := secure[name].(map[interface{}]interface{})
+		if !inClouds && !inSecure {
+			return nil, ErrCloudNotFound{Cloud: name}
+		}
+
+		if profile := profileName(entry); profile != "" {
+			public, err := loadClouds(findFile("", dirs, "clouds-public.yaml", "clouds-public.yml"), "public-clouds")
+			if err != nil {
+				return nil, err
+			}
+			base, ok := public[profile].(map[interface{}]interface{})
+			if !ok {
+				return nil, ErrProfileNotFound{Profile: profile}
+			}
+			mergeMaps(merged, base)
+		}
+		mergeMaps(merged, entry)
+		mergeMaps(merged, secureEntry)
+	}
+
+	b, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	cloud := new(Cloud)
+	if err := yaml.Unmarshal(b, cloud); err != nil {
+		return nil, err
+	}
+
+	applyEnv(cloud, prefix)
+
+	return cloud, nil
+}
+
+// profileName returns the profile a clouds.yaml entry refers to.
+func profileName(entry map[interface{}]interface{}) string {
+	for _, key := range []string{"profile", "cloud"} {
+		if v, ok := entry[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// applyEnv overrides the settings of cloud with the environment variables
+// that are set.
+func applyEnv(cloud *Cloud, prefix string) {
+	if cloud.AuthInfo == nil {
+		cloud.AuthInfo = new(AuthInfo)
+	}
+	a := cloud.AuthInfo
+
+	vars := []struct {
+		names []string
+		field *string
+	}{
+		{[]string{"AUTH_URL"}, &a.AuthURL},
+		{[]string{"AUTH_TOKEN", "TOKEN"}, &a.Token},
+		{[]string{"USERNAME"}, &a.Username},
+		{[]string{"USER_ID", "USERID"}, &a.UserID},
+		{[]string{"PASSWORD"}, &a.Password},
+		{[]string{"TENANT_NAME", "PROJECT_NAME"}, &a.ProjectName},
+		{[]string{"TENANT_ID", "PROJECT_ID"}, &a.ProjectID},
+		{[]string{"USER_DOMAIN_NAME"}, &a.UserDomainName},
+		{[]string{"USER_DOMAIN_ID"}, &a.UserDomainID},
+		{[]string{"PROJECT_DOMAIN_NAME"}, &a.ProjectDomainName},
+		{[]string{"PROJECT_DOMAIN_ID"}, &a.ProjectDomainID},
+		{[]string{"DOMAIN_NAME"}, &a.DomainName},
+		{[]string{"DOMAIN_ID"}, &a.DomainID},
+		{[]string{"DEFAULT_DOMAIN"}, &a.DefaultDomain},
+		{[]string{"SYSTEM_SCOPE"}, &a.SystemScope},
+		{[]string{"APPLICATION_CREDENTIAL_ID"}, &a.ApplicationCredentialID},
+		{[]string{"APPLICATION_CREDENTIAL_NAME"}, &a.ApplicationCredentialName},
+		{[]string{"APPLICATION_CREDENTIAL_SECRET"}, &a.ApplicationCredentialSecret},
+		{[]string{"AUTH_TYPE"}, &cloud.AuthType},
+		{[]string{"REGION_NAME"}, &cloud.RegionName},
+		{[]string{"ENDPOINT_TYPE", "INTERFACE"}, &cloud.Interface},
+		{[]string{"IDENTITY_API_VERSION"}, &cloud.IdentityAPIVersion},
+		{[]string{"CACERT"}, &cloud.CACertFile},
+		{[]string{"CERT"}, &cloud.ClientCertFile},
+		{[]string{"KEY"}, &cloud.ClientKeyFile},
+	}
+
+	// Later names take precedence, so that OS_PROJECT_NAME wins over the
+	// older OS_TENANT_NAME, as it does in AuthOptionsFromEnv.
+	for _, v := range vars {
+		for _, name := range v.names {
+			if value := os.Getenv(prefix + name); value != "" {
+				*v.field = value
+			}
+		}
+	}
+
+	if v := os.Getenv(prefix + "INSECURE"); v != "" {
+		if insecure, err := strconv.ParseBool(v); err == nil {
+			verify := !insecure
+			cloud.Verify = &verify
+		}
+	}
+}
+
+// AuthenticatedClient returns a ProviderClient that is authenticated to the
+// selected cloud and configured with its TLS settings. opts may be nil.
+func AuthenticatedClient(opts *ClientOpts) (*gophercloud.ProviderClient, error) {
+	cloud, err := GetCloud(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ao, err := newClient(cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := openstack.Authenticate(client, *ao); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// newClient returns an unauthenticated ProviderClient configured with the
+// TLS settings of cloud, along with the options to authenticate it with.
+func newClient(cloud *Cloud) (*gophercloud.ProviderClient, *gophercloud.AuthOptions, error) {
+	ao, err := cloud.AuthOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig, err := cloud.TLSConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := openstack.NewClient(ao.IdentityEndpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client.HTTPClient.Transport = transport
+
+	return client, ao, nil
+}
@@ -0,0 +1,265 @@
+package clientconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// Cloud represents an entry in clouds.yaml, merged with its profile, its
+// secure.yaml entry and any environment overrides.
+type Cloud struct {
+	// Profile names an entry of clouds-public.yaml that provides defaults
+	// for this cloud. The legacy "cloud" key is accepted as well.
+	Profile string `yaml:"profile,omitempty"`
+	Cloud   string `yaml:"cloud,omitempty"`
+
+	// AuthType is the authentication method, such as "password" or
+	// "token". It defaults to "password".
+	AuthType string `yaml:"auth_type,omitempty"`
+
+	// AuthInfo holds the credentials and scope to authenticate with.
+	AuthInfo *AuthInfo `yaml:"auth,omitempty"`
+
+	// RegionName is the region to use. If it is empty, the first of Regions
+	// is used.
+	RegionName string   `yaml:"region_name,omitempty"`
+	Regions    []Region `yaml:"regions,omitempty"`
+
+	// Interface is the endpoint interface to use: "public", "internal" or
+	// "admin". EndpointType is its legacy name.
+	Interface    string `yaml:"interface,omitempty"`
+	EndpointType string `yaml:"endpoint_type,omitempty"`
+
+	// IdentityAPIVersion is the version of the identity API, "2.0" or "3".
+	IdentityAPIVersion string `yaml:"identity_api_version,omitempty"`
+
+	// Verify controls whether the server certificates are verified. It
+	// defaults to true.
+	Verify *bool `yaml:"verify,omitempty"`
+
+	// CACertFile is a PEM file with the certificate authorities to trust.
+	CACertFile string `yaml:"cacert,omitempty"`
+
+	// ClientCertFile and ClientKeyFile are the PEM encoded certificate and
+	// key used for TLS client authentication. The key may be included in
+	// ClientCertFile instead.
+	ClientCertFile string `yaml:"cert,omitempty"`
+	ClientKeyFile  string `yaml:"key,omitempty"`
+}
+
+// AuthInfo represents the auth section of a cloud.
+type AuthInfo struct {
+	AuthURL           string `yaml:"auth_url,omitempty"`
+	Token             string `yaml:"token,omitempty"`
+	Username          string `yaml:"username,omitempty"`
+	UserID            string `yaml:"user_id,omitempty"`
+	Password          string `yaml:"password,omitempty"`
+	ProjectName       string `yaml:"project_name,omitempty"`
+	ProjectID         string `yaml:"project_id,omitempty"`
+	UserDomainName    string `yaml:"user_domain_name,omitempty"`
+	UserDomainID      string `yaml:"user_domain_id,omitempty"`
+	ProjectDomainName string `yaml:"project_domain_name,omitempty"`
+	ProjectDomainID   string `yaml:"project_domain_id,omitempty"`
+
+	// DomainName and DomainID are used as the user and project domain when
+	// those aren't given. Without a project, they define a domain scope.
+	DomainName string `yaml:"domain_name,omitempty"`
+	DomainID   string `yaml:"domain_id,omitempty"`
+
+	// DefaultDomain is used as the ID of the user and project domain when
+	// no other domain is given.
+	DefaultDomain string `yaml:"default_domain,omitempty"`
+
+	// SystemScope requests a system-scoped token. The only supported value
+	// is "all".
+	SystemScope string `yaml:"system_scope,omitempty"`
+
+	// ApplicationCredentialID, ApplicationCredentialName and
+	// ApplicationCredentialSecret are used with the
+	// "v3applicationcredential" auth_type.
+	ApplicationCredentialID     string `yaml:"application_credential_id,omitempty"`
+	ApplicationCredentialName   string `yaml:"application_credential_name,omitempty"`
+	ApplicationCredentialSecret string `yaml:"application_credential_secret,omitempty"`
+}
+
+// Region is an entry of a cloud's regions list. It may be given as a plain
+// name or as a map with a "name" key.
+type Region struct {
+	Name string `yaml:"name"`
+}
+
+// UnmarshalYAML accepts both forms of a region.
+func (r *Region) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	if err := unmarshal(&name); err == nil {
+		r.Name = name
+		return nil
+	}
+
+	type region Region
+	var v region
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	*r = Region(v)
+	return nil
+}
+
+// isV2 reports whether the cloud uses version 2 of the identity API.
+func (c *Cloud) isV2() bool {
+	v := strings.TrimPrefix(c.IdentityAPIVersion, "v")
+	return v == "2" || v == "2.0"
+}
+
+// AuthOptions returns the options to authenticate to the cloud with.
+// AllowReauth is enabled.
+func (c *Cloud) AuthOptions() (*gophercloud.AuthOptions, error) {
+	a := c.AuthInfo
+	if a == nil || a.AuthURL == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "auth_url"}
+	}
+
+	ao := &gophercloud.AuthOptions{
+		IdentityEndpoint: a.AuthURL,
+		AllowReauth:      true,
+	}
+
+	switch strings.ToLower(c.AuthType) {
+	case "", "password", "v2password", "v3password":
+		ao.Username = a.Username
+		ao.UserID = a.UserID
+		ao.Password = a.Password
+	case "token", "v2token", "v3token":
+		ao.TokenID = a.Token
+	case "v3applicationcredential", "application_credential":
+		ao.ApplicationCredentialID = a.ApplicationCredentialID
+		ao.ApplicationCredentialName = a.ApplicationCredentialName
+		ao.ApplicationCredentialSecret = a.ApplicationCredentialSecret
+		// A credential looked up by name belongs to the given user.
+		if ao.ApplicationCredentialID == "" {
+			ao.Username = a.Username
+			ao.UserID = a.UserID
+		}
+	default:
+		return nil, ErrUnsupportedAuthType{AuthType: c.AuthType}
+	}
+
+	if c.isV2() {
+		ao.TenantID = a.ProjectID
+		ao.TenantName = a.ProjectName
+		return ao, nil
+	}
+
+	// The user's domain is only needed to look up a user by name.
+	if ao.Username != "" && ao.UserID == "" {
+		ao.DomainID, ao.DomainName = pickDomain(
+			a.UserDomainID, a.UserDomainName, a.DomainID, a.DomainName, a.DefaultDomain)
+	}
+
+	// Application credentials are bound to a project and can't be scoped.
+	if ao.ApplicationCredentialID != "" || ao.ApplicationCredentialName != "" {
+		return ao, nil
+	}
+
+	switch {
+	case a.SystemScope == "all":
+		ao.Scope = &gophercloud.AuthScope{System: true}
+	case a.ProjectID != "":
+		ao.Scope = &gophercloud.AuthScope{ProjectID: a.ProjectID}
+	case a.ProjectName != "":
+		ao.Scope = &gophercloud.AuthScope{ProjectName: a.ProjectName}
+		ao.Scope.DomainID, ao.Scope.DomainName = pickDomain(
+			a.ProjectDomainID, a.ProjectDomainName, a.DomainID, a.DomainName, a.DefaultDomain)
+	case a.DomainID != "":
+		ao.Scope = &gophercloud.AuthScope{DomainID: a.DomainID}
+	case a.DomainName != "":
+		ao.Scope = &gophercloud.AuthScope{DomainName: a.DomainName}
+	}
+
+	return ao, nil
+}
+
+// pickDomain returns the first domain that is set, preferring the specific
+// domain over the generic one and the default domain.
+func pickDomain(id, name, genericID, genericName, defaultID string) (string, string) {
+	switch {
+	case id != "":
+		return id, ""
+	case name != "":
+		return "", name
+	case genericID != "":
+		return genericID, ""
+	case genericName != "":
+		return "", genericName
+	}
+	return defaultID, ""
+}
+
+// EndpointOpts returns the region and interface with which to look up
+// service endpoints in the catalog.
+func (c *Cloud) EndpointOpts() gophercloud.EndpointOpts {
+	eo := gophercloud.EndpointOpts{
+		Region: c.RegionName,
+	}
+	if eo.Region == "" && len(c.Regions) > 0 {
+		eo.Region = c.Regions[0].Name
+	}
+
+	iface := c.Interface
+	if iface == "" {
+		iface = c.EndpointType
+	}
+	// Accept the "publicURL" style used by the v2 catalog.
+	iface = strings.TrimSuffix(strings.ToLower(iface), "url")
+	switch iface {
+	case "internal":
+		eo.Availability = gophercloud.AvailabilityInternal
+	case "admin":
+		eo.Availability = gophercloud.AvailabilityAdmin
+	case "public":
+		eo.Availability = gophercloud.AvailabilityPublic
+	}
+
+	return eo
+}
+
+// TLSConfig returns the TLS settings of the cloud: the trusted certificate
+// authorities, the client certificate and whether to verify the server.
+func (c *Cloud) TLSConfig() (*tls.Config, error) {
+	config := &tls.Config{}
+
+	if c.Verify != nil && !*c.Verify {
+		config.InsecureSkipVerify = true
+	}
+
+	if c.CACertFile != "" {
+		pem, err := ioutil.ReadFile(c.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", c.CACertFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if c.ClientCertFile != "" {
+		keyFile := c.ClientKeyFile
+		if keyFile == "" {
+			keyFile = c.ClientCertFile
+		}
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
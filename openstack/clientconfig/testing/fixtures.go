@@ -0,0 +1,210 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/clientconfig"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+// CloudsYAML is a clouds.yaml with a password and a token cloud.
+const CloudsYAML = `
+clouds:
+  mycloud:
+    profile: example
+    auth:
+      auth_url: https://keystone.example.com:5000/v3
+      username: demo
+      project_name: demo-project
+      user_domain_name: Users
+      project_domain_id: default
+    region_name: RegionTwo
+    interface: internalURL
+  tokencloud:
+    auth_type: token
+    identity_api_version: 3
+    auth:
+      auth_url: https://keystone.example.com:5000/v3
+      token: abcdef
+      project_id: 1234
+    regions:
+      - name: RegionThree
+      - RegionFour
+    verify: false
+  legacy:
+    identity_api_version: "2.0"
+    auth:
+      auth_url: https://keystone.example.com:5000/v2.0
+      username: demo
+      password: secret
+      project_name: demo-project
+`
+
+// SecureYAML holds the password of mycloud.
+const SecureYAML = `
+clouds:
+  mycloud:
+    auth:
+      password: secret
+`
+
+// PublicCloudsYAML defines the profile used by mycloud.
+const PublicCloudsYAML = `
+public-clouds:
+  example:
+    auth:
+      auth_url: https://public.example.com:5000/v3
+    region_name: RegionOne
+    identity_api_version: 3
+`
+
+// WriteConfig writes the configuration files to a new temporary directory
+// and returns it.
+func WriteConfig(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "clientconfig")
+	th.AssertNoErr(t, err)
+
+	files := map[string]string{
+		"clouds.yaml":        CloudsYAML,
+		"secure.yaml":        SecureYAML,
+		"clouds-public.yaml": PublicCloudsYAML,
+	}
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+		th.AssertNoErr(t, err)
+	}
+	return dir
+}
+
+// ManagerTokenResponse is a token whose catalog has compute endpoints in
+// RegionOne and RegionTwo. The %s verbs are replaced by the test endpoint.
+const ManagerTokenResponse = `
+{
+  "token": {
+    "methods": ["password"],
+    "expires_at": "2099-01-01T00:00:00.000000Z",
+    "user": {
+      "id": "ce9e07",
+      "name": "demo"
+    },
+    "catalog": [
+      {
+        "type": "compute",
+        "name": "nova",
+        "endpoints": [
+          {
+            "id": "1",
+            "interface": "public",
+            "region": "RegionOne",
+            "url": "%[1]scompute/one/"
+          },
+          {
+            "id": "2",
+            "interface": "internal",
+            "region": "RegionOne",
+            "url": "%[1]scompute/one-internal/"
+          },
+          {
+            "id": "3",
+            "interface": "public",
+            "region": "RegionTwo",
+            "url": "%[1]scompute/two/"
+          }
+        ]
+      }
+    ]
+  }
+}
+`
+
+// ManagerClouds returns two clouds that authenticate against the test
+// endpoint.
+func ManagerClouds() clientconfig.Clouds {
+	cloud := func(username string) *clientconfig.Cloud {
+		return &clientconfig.Cloud{
+			AuthInfo: &clientconfig.AuthInfo{
+				AuthURL:      th.Endpoint() + "v3/",
+				Username:     username,
+				Password:     "secret",
+				UserDomainID: "default",
+			},
+			RegionName: "RegionOne",
+			Interface:  "public",
+		}
+	}
+	return clientconfig.Clouds{
+		"alpha": cloud("alpha"),
+		"beta":  cloud("beta"),
+	}
+}
+
+// ManagerKeystone is the fake identity service used by the Manager tests.
+type ManagerKeystone struct {
+	mu       sync.Mutex
+	counts   map[string]int
+	rejected map[string]bool
+}
+
+// Authentications returns the number of authentication requests of a user.
+func (k *ManagerKeystone) Authentications(user string) int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.counts[user]
+}
+
+// Reject makes the authentication requests of a user fail, or succeed again.
+func (k *ManagerKeystone) Reject(user string, reject bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.rejected[user] = reject
+}
+
+// HandleManagerAuthentication creates an HTTP handler at `/v3/auth/tokens`
+// on the test handler mux that issues a token to every user that isn't
+// rejected.
+func HandleManagerAuthentication(t *testing.T) *ManagerKeystone {
+	k := &ManagerKeystone{
+		counts:   make(map[string]int),
+		rejected: make(map[string]bool),
+	}
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+
+		var body struct {
+			Auth struct {
+				Identity struct {
+					Password struct {
+						User struct {
+							Name string `json:"name"`
+						} `json:"user"`
+					} `json:"password"`
+				} `json:"identity"`
+			} `json:"auth"`
+		}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+		user := body.Auth.Identity.Password.User.Name
+
+		k.mu.Lock()
+		k.counts[user]++
+		reject := k.rejected[user]
+		k.mu.Unlock()
+
+		if reject {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-Subject-Token", user+"-token")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, ManagerTokenResponse, th.Endpoint())
+	})
+
+	return k
+}
@@ -0,0 +1,169 @@
+package testing
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/clientconfig"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestManagerServiceClients(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	keystone := HandleManagerAuthentication(t)
+
+	m := &clientconfig.Manager{Source: ManagerClouds()}
+
+	one, err := m.ServiceClient(clientconfig.Target{Cloud: "alpha"}, "compute")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, th.Endpoint()+"compute/one/", one.Endpoint)
+	th.AssertEquals(t, "alpha-token", one.Token())
+
+	two, err := m.ServiceClient(clientconfig.Target{Cloud: "alpha", Region: "RegionTwo"}, "compute")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, th.Endpoint()+"compute/two/", two.Endpoint)
+
+	internal, err := m.ServiceClient(clientconfig.Target{
+		Cloud:        "alpha",
+		Availability: gophercloud.AvailabilityInternal,
+	}, "compute")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, th.Endpoint()+"compute/one-internal/", internal.Endpoint)
+
+	again, err := m.ServiceClient(clientconfig.Target{Cloud: "alpha", Region: "RegionOne"}, "compute")
+	th.AssertNoErr(t, err)
+	if again != one {
+		t.Errorf("Expected the cached service client to be returned")
+	}
+
+	// The service clients of a cloud share its ProviderClient.
+	provider, err := m.ProviderClient("alpha")
+	th.AssertNoErr(t, err)
+	if one.ProviderClient != provider || two.ProviderClient != provider {
+		t.Errorf("Expected the service clients to share the ProviderClient")
+	}
+	th.AssertEquals(t, 1, keystone.Authentications("alpha"))
+
+	beta, err := m.ServiceClient(clientconfig.Target{Cloud: "beta"}, "compute")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "beta-token", beta.Token())
+	th.AssertEquals(t, 1, keystone.Authentications("beta"))
+}
+
+func TestManagerConcurrentAuthentication(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	keystone := HandleManagerAuthentication(t)
+
+	m := &clientconfig.Manager{Source: ManagerClouds()}
+
+	var wg sync.WaitGroup
+	clients := make([]*gophercloud.ServiceClient, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sc, err := m.ServiceClient(clientconfig.Target{Cloud: "alpha"}, "compute")
+			th.AssertNoErr(t, err)
+			clients[i] = sc
+		}(i)
+	}
+	wg.Wait()
+
+	th.AssertEquals(t, 1, keystone.Authentications("alpha"))
+	for _, sc := range clients {
+		if sc != clients[0] {
+			t.Fatalf("Expected every goroutine to get the same service client")
+		}
+	}
+}
+
+func TestManagerErrors(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	keystone := HandleManagerAuthentication(t)
+
+	m := &clientconfig.Manager{Source: ManagerClouds()}
+
+	_, err := m.ProviderClient("gamma")
+	if _, ok := err.(clientconfig.ErrCloudNotFound); !ok {
+		t.Fatalf("Expected ErrCloudNotFound, got %v", err)
+	}
+
+	_, err = m.ServiceClient(clientconfig.Target{Cloud: "alpha"}, "telemetry")
+	if _, ok := err.(clientconfig.ErrServiceNotRegistered); !ok {
+		t.Fatalf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+
+	// A failed authentication isn't cached.
+	keystone.Reject("alpha", true)
+	_, err = m.ProviderClient("alpha")
+	if err == nil {
+		t.Fatal("Expected the authentication to fail")
+	}
+	keystone.Reject("alpha", false)
+	_, err = m.ProviderClient("alpha")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, keystone.Authentications("alpha"))
+}
+
+func TestManagerEvictsOnFailedReauthentication(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	keystone := HandleManagerAuthentication(t)
+
+	th.Mux.HandleFunc("/compute/one/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	m := &clientconfig.Manager{Source: ManagerClouds()}
+
+	sc, err := m.ServiceClient(clientconfig.Target{Cloud: "alpha"}, "compute")
+	th.AssertNoErr(t, err)
+
+	// The token is rejected and so are the credentials.
+	keystone.Reject("alpha", true)
+	_, err = sc.Get(sc.ServiceURL("servers"), nil, nil)
+	if err == nil {
+		t.Fatal("Expected the request to fail")
+	}
+	th.AssertEquals(t, 2, keystone.Authentications("alpha"))
+
+	keystone.Reject("alpha", false)
+	fresh, err := m.ServiceClient(clientconfig.Target{Cloud: "alpha"}, "compute")
+	th.AssertNoErr(t, err)
+	if fresh == sc {
+		t.Errorf("Expected the broken client to be evicted")
+	}
+	th.AssertEquals(t, 3, keystone.Authentications("alpha"))
+}
+
+func TestManagerEvict(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	keystone := HandleManagerAuthentication(t)
+
+	var configured []string
+	m := &clientconfig.Manager{
+		Source: ManagerClouds(),
+		ConfigureClient: func(cloud string, client *gophercloud.ProviderClient) error {
+			configured = append(configured, cloud)
+			return nil
+		},
+	}
+
+	first, err := m.ProviderClient("alpha")
+	th.AssertNoErr(t, err)
+
+	m.Evict("alpha")
+	second, err := m.ProviderClient("alpha")
+	th.AssertNoErr(t, err)
+	if first == second {
+		t.Errorf("Expected a new ProviderClient after Evict")
+	}
+	th.AssertEquals(t, 2, keystone.Authentications("alpha"))
+	th.AssertDeepEquals(t, []string{"alpha", "alpha"}, configured)
+}
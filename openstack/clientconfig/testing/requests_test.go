@@ -0,0 +1,233 @@
+package testing
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/clientconfig"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+// envPrefix keeps the tests independent of any OS_* variables set by the
+// environment running them.
+const envPrefix = "GOPHERCLOUD_TEST_"
+
+func TestGetCloudMergesFiles(t *testing.T) {
+	dir := WriteConfig(t)
+	defer os.RemoveAll(dir)
+
+	cloud, err := clientconfig.GetCloud(&clientconfig.ClientOpts{
+		Cloud:      "mycloud",
+		EnvPrefix:  envPrefix,
+		ConfigDirs: []string{dir},
+	})
+	th.AssertNoErr(t, err)
+
+	// clouds.yaml overrides the profile, and secure.yaml adds the password.
+	th.AssertEquals(t, "https://keystone.example.com:5000/v3", cloud.AuthInfo.AuthURL)
+	th.AssertEquals(t, "secret", cloud.AuthInfo.Password)
+	th.AssertEquals(t, "RegionTwo", cloud.RegionName)
+	th.AssertEquals(t, "3", cloud.IdentityAPIVersion)
+
+	ao, err := cloud.AuthOptions()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, &gophercloud.AuthOptions{
+		IdentityEndpoint: "https://keystone.example.com:5000/v3",
+		Username:         "demo",
+		Password:         "secret",
+		DomainName:       "Users",
+		AllowReauth:      true,
+		Scope: &gophercloud.AuthScope{
+			ProjectName: "demo-project",
+			DomainID:    "default",
+		},
+	}, ao)
+
+	th.AssertDeepEquals(t, gophercloud.EndpointOpts{
+		Region:       "RegionTwo",
+		Availability: gophercloud.AvailabilityInternal,
+	}, cloud.EndpointOpts())
+}
+
+func TestGetCloudToken(t *testing.T) {
+	dir := WriteConfig(t)
+	defer os.RemoveAll(dir)
+
+	cloud, err := clientconfig.GetCloud(&clientconfig.ClientOpts{
+		Cloud:      "tokencloud",
+		EnvPrefix:  envPrefix,
+		ConfigDirs: []string{dir},
+	})
+	th.AssertNoErr(t, err)
+
+	ao, err := cloud.AuthOptions()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, &gophercloud.AuthOptions{
+		IdentityEndpoint: "https://keystone.example.com:5000/v3",
+		TokenID:          "abcdef",
+		AllowReauth:      true,
+		Scope:            &gophercloud.AuthScope{ProjectID: "1234"},
+	}, ao)
+
+	th.AssertEquals(t, "RegionThree", cloud.EndpointOpts().Region)
+
+	tlsConfig, err := cloud.TLSConfig()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, tlsConfig.InsecureSkipVerify)
+}
+
+func TestGetCloudV2(t *testing.T) {
+	dir := WriteConfig(t)
+	defer os.RemoveAll(dir)
+
+	cloud, err := clientconfig.GetCloud(&clientconfig.ClientOpts{
+		Cloud:      "legacy",
+		EnvPrefix:  envPrefix,
+		ConfigDirs: []string{dir},
+	})
+	th.AssertNoErr(t, err)
+
+	ao, err := cloud.AuthOptions()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, &gophercloud.AuthOptions{
+		IdentityEndpoint: "https://keystone.example.com:5000/v2.0",
+		Username:         "demo",
+		Password:         "secret",
+		TenantName:       "demo-project",
+		AllowReauth:      true,
+	}, ao)
+}
+
+func TestGetCloudEnvOverrides(t *testing.T) {
+	dir := WriteConfig(t)
+	defer os.RemoveAll(dir)
+
+	env := map[string]string{
+		"CLOUD":       "mycloud",
+		"PASSWORD":    "from-env",
+		"PROJECT_ID":  "5678",
+		"REGION_NAME": "RegionFive",
+		"INTERFACE":   "admin",
+		"INSECURE":    "true",
+	}
+	for k, v := range env {
+		os.Setenv(envPrefix+k, v)
+		defer os.Unsetenv(envPrefix + k)
+	}
+
+	cloud, err := clientconfig.GetCloud(&clientconfig.ClientOpts{
+		EnvPrefix:  envPrefix,
+		ConfigDirs: []string{dir},
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, "from-env", cloud.AuthInfo.Password)
+	th.AssertEquals(t, "RegionFive", cloud.RegionName)
+	th.AssertEquals(t, gophercloud.AvailabilityAdmin, cloud.EndpointOpts().Availability)
+	th.AssertEquals(t, false, *cloud.Verify)
+
+	ao, err := cloud.AuthOptions()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, &gophercloud.AuthScope{ProjectID: "5678"}, ao.Scope)
+}
+
+func TestGetCloudFromEnvOnly(t *testing.T) {
+	env := map[string]string{
+		"AUTH_URL":       "https://keystone.example.com:5000/v3",
+		"USERNAME":       "demo",
+		"PASSWORD":       "secret",
+		"PROJECT_NAME":   "demo-project",
+		"DEFAULT_DOMAIN": "default",
+	}
+	for k, v := range env {
+		os.Setenv(envPrefix+k, v)
+		defer os.Unsetenv(envPrefix + k)
+	}
+
+	cloud, err := clientconfig.GetCloud(&clientconfig.ClientOpts{
+		EnvPrefix:  envPrefix,
+		ConfigDirs: []string{},
+	})
+	th.AssertNoErr(t, err)
+
+	ao, err := cloud.AuthOptions()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "default", ao.DomainID)
+	th.AssertDeepEquals(t, &gophercloud.AuthScope{ProjectName: "demo-project", DomainID: "default"}, ao.Scope)
+}
+
+func TestGetCloudNotFound(t *testing.T) {
+	dir := WriteConfig(t)
+	defer os.RemoveAll(dir)
+
+	_, err := clientconfig.GetCloud(&clientconfig.ClientOpts{
+		Cloud:      "missing",
+		EnvPrefix:  envPrefix,
+		ConfigDirs: []string{dir},
+	})
+	if _, ok := err.(clientconfig.ErrCloudNotFound); !ok {
+		t.Fatalf("expected ErrCloudNotFound, got %T: %v", err, err)
+	}
+}
+
+func TestGetCloudApplicationCredential(t *testing.T) {
+	env := map[string]string{
+		"AUTH_URL":                      "https://keystone.example.com:5000/v3",
+		"AUTH_TYPE":                     "v3applicationcredential",
+		"APPLICATION_CREDENTIAL_ID":     "appcred-id",
+		"APPLICATION_CREDENTIAL_SECRET": "appcred-secret",
+		"PROJECT_NAME":                  "ignored",
+	}
+	for k, v := range env {
+		os.Setenv(envPrefix+k, v)
+		defer os.Unsetenv(envPrefix + k)
+	}
+
+	cloud, err := clientconfig.GetCloud(&clientconfig.ClientOpts{
+		EnvPrefix:  envPrefix,
+		ConfigDirs: []string{},
+	})
+	th.AssertNoErr(t, err)
+
+	ao, err := cloud.AuthOptions()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, &gophercloud.AuthOptions{
+		IdentityEndpoint:            "https://keystone.example.com:5000/v3",
+		ApplicationCredentialID:     "appcred-id",
+		ApplicationCredentialSecret: "appcred-secret",
+		AllowReauth:                 true,
+	}, ao)
+}
+
+func TestGetCloudSystemScope(t *testing.T) {
+	env := map[string]string{
+		"AUTH_URL":            "https://keystone.example.com:5000/v3",
+		"USERNAME":            "admin",
+		"PASSWORD":            "secret",
+		"USER_DOMAIN_ID":      "default",
+		"SYSTEM_SCOPE":        "all",
+		"PROJECT_DOMAIN_NAME": "ignored",
+	}
+	for k, v := range env {
+		os.Setenv(envPrefix+k, v)
+		defer os.Unsetenv(envPrefix + k)
+	}
+
+	cloud, err := clientconfig.GetCloud(&clientconfig.ClientOpts{
+		EnvPrefix:  envPrefix,
+		ConfigDirs: []string{},
+	})
+	th.AssertNoErr(t, err)
+
+	ao, err := cloud.AuthOptions()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, &gophercloud.AuthOptions{
+		IdentityEndpoint: "https://keystone.example.com:5000/v3",
+		Username:         "admin",
+		Password:         "secret",
+		DomainID:         "default",
+		AllowReauth:      true,
+		Scope:            &gophercloud.AuthScope{System: true},
+	}, ao)
+}
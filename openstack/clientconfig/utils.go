@@ -0,0 +1,76 @@
+package clientconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// configDirs returns the standard directories to search for configuration
+// files, in order of precedence.
+func configDirs() []string {
+	dirs := []string{"."}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		dirs = append(dirs, filepath.Join(dir, "openstack"))
+	} else if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".config", "openstack"))
+	}
+	return append(dirs, "/etc/openstack")
+}
+
+// findFile returns override if it is set, or else the first of names that
+// exists in one of dirs. It returns "" if no file is found.
+func findFile(override string, dirs []string, names ...string) string {
+	if override != "" {
+		return override
+	}
+	for _, dir := range dirs {
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// loadClouds reads the map of clouds found under key in the YAML file at
+// path. A missing path yields an empty map.
+func loadClouds(path, key string) (map[interface{}]interface{}, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]map[interface{}]interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return doc[key], nil
+}
+
+// mergeMaps recursively copies the values of src into dst. Nested maps are
+// merged; any other value in src replaces the one in dst.
+func mergeMaps(dst, src map[interface{}]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[interface{}]interface{})
+		dstMap, dstIsMap := dst[k].(map[interface{}]interface{})
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		if srcIsMap {
+			copied := make(map[interface{}]interface{}, len(srcMap))
+			mergeMaps(copied, srcMap)
+			v = copied
+		}
+		dst[k] = v
+	}
+}
@@ -1,16 +1,14 @@
 package webhooks
 
 import (
-	"net/url"
-
 	"github.com/gophercloud/gophercloud"
 	"golang.org/x/crypto/openpgp/errors"
 )
 
 // TriggerOpts represents options used for triggering an action
 type TriggerOpts struct {
-	V      string `q:"V" required:"true"`
-	Params map[string]string
+	V      string            `q:"V" required:"true"`
+	Params map[string]string `q:",inline"`
 }
 
 // TriggerOptsBuilder Query string builder interface for webhooks
@@ -21,13 +19,6 @@ type TriggerOptsBuilder interface {
 // Query string builder for webhooks
 func (opts TriggerOpts) ToWebhookTriggerQuery() (string, error) {
 	q, err := gophercloud.BuildQueryString(opts)
-	params := q.Query()
-
-	for k, v := range opts.Params {
-		params.Add(k, v)
-	}
-
-	q = &url.URL{RawQuery: params.Encode()}
 	return q.String(), err
 }
 
@@ -46,8 +37,9 @@ func Trigger(client *gophercloud.ServiceClient, id string, opts TriggerOptsBuild
 		return
 	}
 
-	_, r.Err = client.Post(url, nil, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Post(url, nil, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200, 201, 202},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
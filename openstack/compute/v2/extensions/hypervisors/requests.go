@@ -16,26 +16,29 @@ func List(client *gophercloud.ServiceClient) pagination.Pager {
 
 // Statistics makes a request against the API to get hypervisors statistics.
 func GetStatistics(client *gophercloud.ServiceClient) (r StatisticsResult) {
-	_, r.Err = client.Get(hypervisorsStatisticsURL(client), &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Get(hypervisorsStatisticsURL(client), &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Get makes a request against the API to get details for specific hypervisor.
 func Get(client *gophercloud.ServiceClient, hypervisorID int) (r HypervisorResult) {
 	v := strconv.Itoa(hypervisorID)
-	_, r.Err = client.Get(hypervisorsGetURL(client, v), &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Get(hypervisorsGetURL(client, v), &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // GetUptime makes a request against the API to get uptime for specific hypervisor.
 func GetUptime(client *gophercloud.ServiceClient, hypervisorID int) (r UptimeResult) {
 	v := strconv.Itoa(hypervisorID)
-	_, r.Err = client.Get(hypervisorsUptimeURL(client, v), &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Get(hypervisorsUptimeURL(client, v), &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
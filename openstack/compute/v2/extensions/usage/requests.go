@@ -1,7 +1,6 @@
 package usage
 
 import (
-	"net/url"
 	"time"
 
 	"github.com/gophercloud/gophercloud"
@@ -26,10 +25,10 @@ func SingleTenant(client *gophercloud.ServiceClient, tenantID string, opts Singl
 // SingleTenantOpts are options for fetching usage of a single tenant.
 type SingleTenantOpts struct {
 	// The ending time to calculate usage statistics on compute and storage resources.
-	End *time.Time `q:"end"`
+	End *time.Time `q:"end" format:"2006-01-02T15:04:05.999999"`
 
 	// The beginning time to calculate usage statistics on compute and storage resources.
-	Start *time.Time `q:"start"`
+	Start *time.Time `q:"start" format:"2006-01-02T15:04:05.999999"`
 }
 
 // SingleTenantOptsBuilder allows extensions to add additional parameters to the
@@ -40,15 +39,6 @@ type SingleTenantOptsBuilder interface {
 
 // ToUsageSingleTenantQuery formats a SingleTenantOpts into a query string.
 func (opts SingleTenantOpts) ToUsageSingleTenantQuery() (string, error) {
-	params := make(url.Values)
-	if opts.Start != nil {
-		params.Add("start", opts.Start.Format(gophercloud.RFC3339MilliNoZ))
-	}
-
-	if opts.End != nil {
-		params.Add("end", opts.End.Format(gophercloud.RFC3339MilliNoZ))
-	}
-
-	q := &url.URL{RawQuery: params.Encode()}
-	return q.String(), nil
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
 }
@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
@@ -106,6 +108,22 @@ func TestListFlavors(t *testing.T) {
 	}
 }
 
+func TestListFlavorsChangesQuery(t *testing.T) {
+	listOpts := flavors.ListOpts{
+		ChangesSinceTime: time.Date(2020, time.January, 2, 3, 4, 5, 123000, time.UTC),
+	}
+
+	query, err := listOpts.ToFlavorListQuery()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "?changes-since=2020-01-02T03%3A04%3A05.000123Z", query)
+
+	listOpts.ChangesSince = "2020-01-02T03:04:05Z"
+	_, err = listOpts.ToFlavorListQuery()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("expected ErrInvalidInput, got %T: %v", err, err)
+	}
+}
+
 func TestGetFlavor(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
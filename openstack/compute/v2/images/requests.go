@@ -1,6 +1,8 @@
 package images
 
 import (
+	"time"
+
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
 )
@@ -15,8 +17,14 @@ type ListOptsBuilder interface {
 type ListOpts struct {
 	// ChangesSince filters Images based on the last changed status (in date-time
 	// format).
+	//
+	// Deprecated: use ChangesSinceTime instead.
 	ChangesSince string `q:"changes-since"`
 
+	// ChangesSinceTime filters Images to those that changed since the given
+	// time. It can't be used together with ChangesSince.
+	ChangesSinceTime time.Time `q:"changes-since" format:"2006-01-02T15:04:05.999999Z07:00"`
+
 	// Limit limits the number of Images to return.
 	Limit int `q:"limit"`
 
@@ -38,6 +46,12 @@ type ListOpts struct {
 
 // ToImageListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToImageListQuery() (string, error) {
+	if opts.ChangesSince != "" && !opts.ChangesSinceTime.IsZero() {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "images.ListOpts.ChangesSince/images.ListOpts.ChangesSinceTime"
+		err.Info = "Only one of ChangesSince and ChangesSinceTime can be set"
+		return "", err
+	}
 	q, err := gophercloud.BuildQueryString(opts)
 	return q.String(), err
 }
@@ -59,13 +73,15 @@ func ListDetail(client *gophercloud.ServiceClient, opts ListOptsBuilder) paginat
 
 // Get returns data about a specific image by its ID.
 func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	resp, err := client.Get(getURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Delete deletes the specified image ID.
 func Delete(client *gophercloud.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	resp, err := client.Delete(deleteURL(client, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -801,6 +801,7 @@ func HandleServerGetSuccessfully(t *testing.T) {
 		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
 		th.TestHeader(t, r, "Accept", "application/json")
 
+		w.Header().Add("X-Openstack-Request-Id", "req-cf8c9d2a-7a5e-4d8d-a1e9-9f1c0b2c3d4e")
 		fmt.Fprintf(w, SingleServerBody)
 	})
 }
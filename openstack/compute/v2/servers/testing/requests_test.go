@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/availabilityzones"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/diskconfig"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/extendedstatus"
@@ -85,6 +87,45 @@ func TestListAllServersWithExtensions(t *testing.T) {
 	th.AssertEquals(t, diskconfig.Manual, actual[0].DiskConfig)
 }
 
+func TestListServersQuery(t *testing.T) {
+	listOpts := servers.ListOpts{
+		Status: "ACTIVE",
+		SortKeys: []gophercloud.SortKey{
+			{Key: "display_name", Direction: gophercloud.SortAsc},
+			{Key: "created_at", Direction: gophercloud.SortDesc},
+		},
+	}
+
+	query, err := listOpts.ToServerListQuery()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "?sort_dir=asc&sort_dir=desc&sort_key=display_name&sort_key=created_at&status=ACTIVE", query)
+}
+
+func TestListServersChangesQuery(t *testing.T) {
+	listOpts := servers.ListOpts{
+		ChangesSinceTime: time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC),
+		ChangesBefore:    time.Date(2020, time.January, 3, 3, 4, 5, 123000, time.UTC),
+	}
+
+	query, err := listOpts.ToServerListQuery()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "?changes-before=2020-01-03T03%3A04%3A05.000123Z&changes-since=2020-01-02T03%3A04%3A05Z", query)
+
+	// Filtering by changes-before requires microversion 2.66.
+	err = servers.List(client.ServiceClient(), listOpts).EachPage(func(pagination.Page) (bool, error) {
+		return false, nil
+	})
+	if _, ok := err.(gophercloud.ErrMicroversionRequired); !ok {
+		t.Fatalf("expected ErrMicroversionRequired, got %T: %v", err, err)
+	}
+
+	listOpts.ChangesSince = "2020-01-02T03:04:05Z"
+	_, err = listOpts.ToServerListQuery()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("expected ErrInvalidInput, got %T: %v", err, err)
+	}
+}
+
 func TestCreateServer(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -218,6 +259,16 @@ func TestGetServer(t *testing.T) {
 	th.CheckDeepEquals(t, ServerDerp, *actual)
 }
 
+func TestGetServerHeader(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerGetSuccessfully(t)
+
+	r := servers.Get(client.ServiceClient(), "1234asdf")
+	th.AssertNoErr(t, r.Err)
+	th.AssertEquals(t, "req-cf8c9d2a-7a5e-4d8d-a1e9-9f1c0b2c3d4e", gophercloud.RequestIDFromHeader(r.Header))
+}
+
 func TestGetFaultyServer(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
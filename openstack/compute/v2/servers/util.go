@@ -6,7 +6,7 @@ import "github.com/gophercloud/gophercloud"
 // transitions to a specified status. It will do this for at most the number
 // of seconds specified.
 func WaitForStatus(c *gophercloud.ServiceClient, id, status string, secs int) error {
-	return gophercloud.WaitFor(secs, func() (bool, error) {
+	return gophercloud.WaitForContext(c.RequestContext(), secs, func() (bool, error) {
 		current, err := Get(c, id).Extract()
 		if err != nil {
 			return false, err
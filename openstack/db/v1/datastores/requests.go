@@ -14,7 +14,8 @@ func List(client *gophercloud.ServiceClient) pagination.Pager {
 
 // Get will retrieve the details of a specified datastore type.
 func Get(client *gophercloud.ServiceClient, datastoreID string) (r GetResult) {
-	_, r.Err = client.Get(resourceURL(client, datastoreID), &r.Body, nil)
+	resp, err := client.Get(resourceURL(client, datastoreID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -28,6 +29,7 @@ func ListVersions(client *gophercloud.ServiceClient, datastoreID string) paginat
 
 // GetVersion will retrieve the details of a specified datastore version.
 func GetVersion(client *gophercloud.ServiceClient, datastoreID, versionID string) (r GetVersionResult) {
-	_, r.Err = client.Get(versionURL(client, datastoreID, versionID), &r.Body, nil)
+	resp, err := client.Get(versionURL(client, datastoreID, versionID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
@@ -0,0 +1,103 @@
+/*
+Package applicationcredentials provides information and interaction with the
+application credentials API resource for the OpenStack Identity service.
+
+Application credentials let a user delegate a subset of their role
+assignments on a project to an application, which authenticates with the
+credential's ID (or name) and secret instead of the user's password.
+
+For more information, see:
+https://docs.openstack.org/api-ref/identity/v3/#application-credentials
+
+Example to List Application Credentials
+
+	listOpts := applicationcredentials.ListOpts{
+		Name: "my_app",
+	}
+
+	allPages, err := applicationcredentials.List(identityClient, userID, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allApplicationCredentials, err := applicationcredentials.ExtractApplicationCredentials(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, applicationCredential := range allApplicationCredentials {
+		fmt.Printf("%+v\n", applicationCredential)
+	}
+
+Example to Get an Application Credential
+
+	applicationCredential, err := applicationcredentials.Get(identityClient, userID, applicationID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create an Application Credential
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	createOpts := applicationcredentials.CreateOpts{
+		Name:        "test",
+		Description: "A test application credential",
+		Roles: []applicationcredentials.Role{
+			{Name: "member"},
+		},
+		AccessRules: []applicationcredentials.AccessRule{
+			{
+				Path:    "/v2.1/servers",
+				Method:  "GET",
+				Service: "compute",
+			},
+		},
+		ExpiresAt: &expiresAt,
+	}
+
+	applicationCredential, err := applicationcredentials.Create(identityClient, userID, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	// The secret is only returned when the credential is created.
+	fmt.Println(applicationCredential.Secret)
+
+Example to Delete an Application Credential
+
+	err := applicationcredentials.Delete(identityClient, userID, applicationID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List Access Rules
+
+	allPages, err := applicationcredentials.ListAccessRules(identityClient, userID).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allAccessRules, err := applicationcredentials.ExtractAccessRules(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete an Access Rule
+
+	err := applicationcredentials.DeleteAccessRule(identityClient, userID, accessRuleID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authenticate with an Application Credential
+
+	authOptions := gophercloud.AuthOptions{
+		IdentityEndpoint:            "https://keystone.example.com:5000/v3",
+		ApplicationCredentialID:     applicationCredential.ID,
+		ApplicationCredentialSecret: applicationCredential.Secret,
+	}
+
+	provider, err := openstack.AuthenticatedClient(authOptions)
+*/
+package applicationcredentials
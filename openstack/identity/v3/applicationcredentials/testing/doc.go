@@ -0,0 +1,2 @@
+// applicationcredentials unit tests
+package testing
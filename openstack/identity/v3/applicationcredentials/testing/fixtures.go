@@ -0,0 +1,339 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+const userID = "2844b2a08be147a08ef58317d6471f1f"
+const applicationCredentialID = "f741662395b249c9b8acdebf1722c5ae"
+const accessRuleID = "07d719df00f349ef8de77d542edf010c"
+
+// ListOutput provides a single page of ApplicationCredential results.
+const ListOutput = `
+{
+  "links": {
+    "self": "http://localhost/identity/v3/users/2844b2a08be147a08ef58317d6471f1f/application_credentials",
+    "previous": null,
+    "next": null
+  },
+  "application_credentials": [
+    {
+      "links": {
+        "self": "http://localhost/identity/v3/users/2844b2a08be147a08ef58317d6471f1f/application_credentials/c4859fb437df4b87a51a8f5adcfb0bc7"
+      },
+      "description": null,
+      "roles": [
+        {
+          "domain_id": null,
+          "id": "31f87923ae4a4d119aa0b85dcdbeed13",
+          "name": "compute_viewer"
+        }
+      ],
+      "expires_at": null,
+      "unrestricted": false,
+      "project_id": "53c2b94f63fb4f43a21b92d119ce549f",
+      "id": "c4859fb437df4b87a51a8f5adcfb0bc7",
+      "name": "test1"
+    },
+    {
+      "links": {
+        "self": "http://localhost/identity/v3/users/2844b2a08be147a08ef58317d6471f1f/application_credentials/6b8cc7647da64166a4a3cc0c88ebbabb"
+      },
+      "description": "A test application credential",
+      "roles": [
+        {
+          "domain_id": null,
+          "id": "31f87923ae4a4d119aa0b85dcdbeed13",
+          "name": "compute_viewer"
+        }
+      ],
+      "expires_at": "2019-03-12T12:12:12.123456",
+      "unrestricted": true,
+      "project_id": "53c2b94f63fb4f43a21b92d119ce549f",
+      "id": "6b8cc7647da64166a4a3cc0c88ebbabb",
+      "name": "test2"
+    }
+  ]
+}
+`
+
+// GetOutput provides a Get result.
+const GetOutput = `
+{
+  "application_credential": {
+    "links": {
+      "self": "http://localhost/identity/v3/users/2844b2a08be147a08ef58317d6471f1f/application_credentials/f741662395b249c9b8acdebf1722c5ae"
+    },
+    "description": null,
+    "roles": [
+      {
+        "domain_id": null,
+        "id": "31f87923ae4a4d119aa0b85dcdbeed13",
+        "name": "compute_viewer"
+      }
+    ],
+    "access_rules": [
+      {
+        "id": "07d719df00f349ef8de77d542edf010c",
+        "path": "/v2.1/servers",
+        "method": "GET",
+        "service": "compute"
+      }
+    ],
+    "expires_at": null,
+    "unrestricted": false,
+    "project_id": "53c2b94f63fb4f43a21b92d119ce549f",
+    "id": "f741662395b249c9b8acdebf1722c5ae",
+    "name": "test"
+  }
+}
+`
+
+// CreateRequest provides the input to a Create request.
+const CreateRequest = `
+{
+  "application_credential": {
+    "name": "test",
+    "description": "A test application credential",
+    "secret": "mysecret",
+    "unrestricted": false,
+    "expires_at": "2030-01-01T00:00:00",
+    "roles": [
+      {
+        "id": "31f87923ae4a4d119aa0b85dcdbeed13"
+      }
+    ],
+    "access_rules": [
+      {
+        "path": "/v2.1/servers",
+        "method": "GET",
+        "service": "compute"
+      }
+    ]
+  }
+}
+`
+
+// CreateResponse provides the output of a Create request.
+const CreateResponse = `
+{
+  "application_credential": {
+    "links": {
+      "self": "http://localhost/identity/v3/users/2844b2a08be147a08ef58317d6471f1f/application_credentials/f741662395b249c9b8acdebf1722c5ae"
+    },
+    "description": "A test application credential",
+    "roles": [
+      {
+        "domain_id": null,
+        "id": "31f87923ae4a4d119aa0b85dcdbeed13",
+        "name": "compute_viewer"
+      }
+    ],
+    "access_rules": [
+      {
+        "id": "07d719df00f349ef8de77d542edf010c",
+        "path": "/v2.1/servers",
+        "method": "GET",
+        "service": "compute"
+      }
+    ],
+    "expires_at": "2030-01-01T00:00:00.000000",
+    "secret": "mysecret",
+    "unrestricted": false,
+    "project_id": "53c2b94f63fb4f43a21b92d119ce549f",
+    "id": "f741662395b249c9b8acdebf1722c5ae",
+    "name": "test"
+  }
+}
+`
+
+// ListAccessRulesOutput provides a single page of AccessRule results.
+const ListAccessRulesOutput = `
+{
+  "links": {
+    "self": "https://example.com/identity/v3/users/2844b2a08be147a08ef58317d6471f1f/access_rules",
+    "previous": null,
+    "next": null
+  },
+  "access_rules": [
+    {
+      "id": "07d719df00f349ef8de77d542edf010c",
+      "path": "/v2.1/servers",
+      "method": "GET",
+      "service": "compute"
+    }
+  ]
+}
+`
+
+// GetAccessRuleOutput provides a GetAccessRule result.
+const GetAccessRuleOutput = `
+{
+  "access_rule": {
+    "id": "07d719df00f349ef8de77d542edf010c",
+    "path": "/v2.1/servers",
+    "method": "GET",
+    "service": "compute"
+  }
+}
+`
+
+var computeViewer = applicationcredentials.Role{
+	ID:   "31f87923ae4a4d119aa0b85dcdbeed13",
+	Name: "compute_viewer",
+}
+
+// ListServersRule is the access rule used in the fixtures.
+var ListServersRule = applicationcredentials.AccessRule{
+	ID:      "07d719df00f349ef8de77d542edf010c",
+	Path:    "/v2.1/servers",
+	Method:  "GET",
+	Service: "compute",
+}
+
+// FirstApplicationCredential is the first application credential in the List request.
+var FirstApplicationCredential = applicationcredentials.ApplicationCredential{
+	ID:           "c4859fb437df4b87a51a8f5adcfb0bc7",
+	Name:         "test1",
+	Roles:        []applicationcredentials.Role{computeViewer},
+	Unrestricted: false,
+	ProjectID:    "53c2b94f63fb4f43a21b92d119ce549f",
+	Links: map[string]interface{}{
+		"self": "http://localhost/identity/v3/users/2844b2a08be147a08ef58317d6471f1f/application_credentials/c4859fb437df4b87a51a8f5adcfb0bc7",
+	},
+}
+
+// SecondApplicationCredential is the second application credential in the List request.
+var SecondApplicationCredential = applicationcredentials.ApplicationCredential{
+	ID:           "6b8cc7647da64166a4a3cc0c88ebbabb",
+	Name:         "test2",
+	Description:  "A test application credential",
+	Roles:        []applicationcredentials.Role{computeViewer},
+	ExpiresAt:    time.Date(2019, 3, 12, 12, 12, 12, 123456000, time.UTC),
+	Unrestricted: true,
+	ProjectID:    "53c2b94f63fb4f43a21b92d119ce549f",
+	Links: map[string]interface{}{
+		"self": "http://localhost/identity/v3/users/2844b2a08be147a08ef58317d6471f1f/application_credentials/6b8cc7647da64166a4a3cc0c88ebbabb",
+	},
+}
+
+// ApplicationCredential is the application credential in the Get request.
+var ApplicationCredential = applicationcredentials.ApplicationCredential{
+	ID:           "f741662395b249c9b8acdebf1722c5ae",
+	Name:         "test",
+	Roles:        []applicationcredentials.Role{computeViewer},
+	AccessRules:  []applicationcredentials.AccessRule{ListServersRule},
+	Unrestricted: false,
+	ProjectID:    "53c2b94f63fb4f43a21b92d119ce549f",
+	Links: map[string]interface{}{
+		"self": "http://localhost/identity/v3/users/2844b2a08be147a08ef58317d6471f1f/application_credentials/f741662395b249c9b8acdebf1722c5ae",
+	},
+}
+
+// ExpectedApplicationCredentialsSlice is the slice of application credentials expected to be returned from ListOutput.
+var ExpectedApplicationCredentialsSlice = []applicationcredentials.ApplicationCredential{FirstApplicationCredential, SecondApplicationCredential}
+
+// HandleListApplicationCredentialsSuccessfully creates an HTTP handler at
+// `/users/{user_id}/application_credentials` on the test handler mux that
+// responds with a list of two application credentials.
+func HandleListApplicationCredentialsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/users/"+userID+"/application_credentials", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListOutput)
+	})
+}
+
+// HandleGetApplicationCredentialSuccessfully creates an HTTP handler at
+// `/users/{user_id}/application_credentials/{id}` on the test handler mux
+// that responds with a single application credential.
+func HandleGetApplicationCredentialSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/users/"+userID+"/application_credentials/"+applicationCredentialID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, GetOutput)
+	})
+}
+
+// HandleCreateApplicationCredentialSuccessfully creates an HTTP handler at
+// `/users/{user_id}/application_credentials` on the test handler mux that
+// tests application credential creation.
+func HandleCreateApplicationCredentialSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/users/"+userID+"/application_credentials", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, CreateRequest)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, CreateResponse)
+	})
+}
+
+// HandleDeleteApplicationCredentialSuccessfully creates an HTTP handler at
+// `/users/{user_id}/application_credentials/{id}` on the test handler mux
+// that tests application credential deletion.
+func HandleDeleteApplicationCredentialSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/users/"+userID+"/application_credentials/"+applicationCredentialID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleListAccessRulesSuccessfully creates an HTTP handler at
+// `/users/{user_id}/access_rules` on the test handler mux that responds with
+// a list of access rules.
+func HandleListAccessRulesSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/users/"+userID+"/access_rules", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListAccessRulesOutput)
+	})
+}
+
+// HandleGetAccessRuleSuccessfully creates an HTTP handler at
+// `/users/{user_id}/access_rules/{id}` on the test handler mux that responds
+// with a single access rule.
+func HandleGetAccessRuleSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/users/"+userID+"/access_rules/"+accessRuleID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, GetAccessRuleOutput)
+	})
+}
+
+// HandleDeleteAccessRuleSuccessfully creates an HTTP handler at
+// `/users/{user_id}/access_rules/{id}` on the test handler mux that tests
+// access rule deletion.
+func HandleDeleteAccessRuleSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/users/"+userID+"/access_rules/"+accessRuleID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,134 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestListApplicationCredentials(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListApplicationCredentialsSuccessfully(t)
+
+	count := 0
+	err := applicationcredentials.List(client.ServiceClient(), userID, nil).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := applicationcredentials.ExtractApplicationCredentials(page)
+		th.AssertNoErr(t, err)
+
+		th.CheckDeepEquals(t, ExpectedApplicationCredentialsSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestListApplicationCredentialsAllPages(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListApplicationCredentialsSuccessfully(t)
+
+	allPages, err := applicationcredentials.List(client.ServiceClient(), userID, nil).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := applicationcredentials.ExtractApplicationCredentials(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedApplicationCredentialsSlice, actual)
+	th.AssertDeepEquals(t, ExpectedApplicationCredentialsSlice[0].Roles[0].Name, "compute_viewer")
+}
+
+func TestGetApplicationCredential(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetApplicationCredentialSuccessfully(t)
+
+	actual, err := applicationcredentials.Get(client.ServiceClient(), userID, applicationCredentialID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ApplicationCredential, *actual)
+}
+
+func TestCreateApplicationCredential(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateApplicationCredentialSuccessfully(t)
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	createOpts := applicationcredentials.CreateOpts{
+		Name:        "test",
+		Description: "A test application credential",
+		Secret:      "mysecret",
+		Roles: []applicationcredentials.Role{
+			{ID: "31f87923ae4a4d119aa0b85dcdbeed13"},
+		},
+		AccessRules: []applicationcredentials.AccessRule{
+			{
+				Path:    "/v2.1/servers",
+				Method:  "GET",
+				Service: "compute",
+			},
+		},
+		ExpiresAt: &expiresAt,
+	}
+
+	expected := ApplicationCredential
+	expected.Description = "A test application credential"
+	expected.Secret = "mysecret"
+	expected.ExpiresAt = expiresAt
+
+	actual, err := applicationcredentials.Create(client.ServiceClient(), userID, createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, expected, *actual)
+}
+
+func TestCreateApplicationCredentialRequiresName(t *testing.T) {
+	_, err := applicationcredentials.CreateOpts{}.ToApplicationCredentialCreateMap()
+	if err == nil {
+		t.Fatal("expected an error for a missing name")
+	}
+}
+
+func TestDeleteApplicationCredential(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteApplicationCredentialSuccessfully(t)
+
+	res := applicationcredentials.Delete(client.ServiceClient(), userID, applicationCredentialID)
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestListAccessRules(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListAccessRulesSuccessfully(t)
+
+	allPages, err := applicationcredentials.ListAccessRules(client.ServiceClient(), userID).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := applicationcredentials.ExtractAccessRules(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []applicationcredentials.AccessRule{ListServersRule}, actual)
+}
+
+func TestGetAccessRule(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetAccessRuleSuccessfully(t)
+
+	actual, err := applicationcredentials.GetAccessRule(client.ServiceClient(), userID, accessRuleID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ListServersRule, *actual)
+}
+
+func TestDeleteAccessRule(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteAccessRuleSuccessfully(t)
+
+	res := applicationcredentials.DeleteAccessRule(client.ServiceClient(), userID, accessRuleID)
+	th.AssertNoErr(t, res.Err)
+}
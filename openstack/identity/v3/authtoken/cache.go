@@ -0,0 +1,75 @@
+package authtoken
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// cacheEntry is a validated token. A nil identity marks an invalid token.
+type cacheEntry struct {
+	identity *Identity
+	expires  time.Time
+}
+
+// tokenCache keeps the results of token validations in memory. Tokens are
+// stored by their hash, so that a dump of the cache doesn't reveal them.
+type tokenCache struct {
+	mu         sync.Mutex
+	entries    map[string]cacheEntry
+	maxEntries int
+}
+
+func newTokenCache(maxEntries int) *tokenCache {
+	return &tokenCache{
+		entries:    make(map[string]cacheEntry),
+		maxEntries: maxEntries,
+	}
+}
+
+func cacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *tokenCache) get(key string, now time.Time) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	if !now.Before(e.expires) {
+		delete(c.entries, key)
+		return cacheEntry{}, false
+	}
+	return e, true
+}
+
+func (c *tokenCache) set(key string, e cacheEntry, now time.Time) {
+	if !now.Before(e.expires) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.maxEntries {
+		for k, old := range c.entries {
+			if !now.Before(old.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	// Make room by dropping arbitrary entries; they are simply validated
+	// again when they are next used.
+	for k := range c.entries {
+		if len(c.entries) < c.maxEntries {
+			break
+		}
+		delete(c.entries, k)
+	}
+	c.entries[key] = e
+}
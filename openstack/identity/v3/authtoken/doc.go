@@ -0,0 +1,57 @@
+/*
+Package authtoken provides an HTTP middleware that validates the tokens of
+incoming requests with the OpenStack Identity service, for Go services that
+are deployed behind OpenStack. It follows the auth_token middleware of
+keystonemiddleware.
+
+The middleware validates X-Auth-Token and X-Service-Token with the identity
+v3 client it is given, which must be authenticated as a service user. Valid
+tokens are cached until they expire, or for at most Opts.CacheTTL, and
+invalid tokens for Opts.NegativeCacheTTL.
+
+Example to Protect an HTTP Handler
+
+	provider, err := openstack.AuthenticatedClient(gophercloud.AuthOptions{
+		IdentityEndpoint: "https://keystone.example.com:5000/v3",
+		Username:         "myservice",
+		Password:         "password",
+		DomainID:         "default",
+		TenantName:       "service",
+		AllowReauth:      true,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	identityClient, err := openstack.NewIdentityV3(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+	m := authtoken.New(identityClient, authtoken.Opts{})
+	http.Handle("/", m.Handler(apiHandler))
+
+Example to Read the Identity of a Request
+
+	func apiHandler(w http.ResponseWriter, r *http.Request) {
+		id, ok := authtoken.FromContext(r.Context())
+		if !ok || !id.HasRole("admin") {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		fmt.Fprintf(w, "Hello, %s of project %s\n", id.User.Name, r.Header.Get("X-Project-Id"))
+	}
+
+Example to Delay the Auth Decision
+
+	m := authtoken.New(identityClient, authtoken.Opts{
+		DelayAuthDecision: true,
+	})
+
+	http.Handle("/", m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Identity-Status") != authtoken.StatusConfirmed {
+			// Serve anonymous requests.
+		}
+	})))
+*/
+package authtoken
@@ -0,0 +1,11 @@
+package authtoken
+
+import "github.com/gophercloud/gophercloud"
+
+// ErrInvalidToken is returned by Validate when the Identity service doesn't
+// accept a token, e.g. because it expired or was revoked.
+type ErrInvalidToken struct{ gophercloud.BaseError }
+
+func (e ErrInvalidToken) Error() string {
+	return "The token is invalid or expired"
+}
@@ -0,0 +1,294 @@
+package authtoken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+)
+
+const (
+	// DefaultCacheTTL is the default of Opts.CacheTTL.
+	DefaultCacheTTL = 5 * time.Minute
+
+	// DefaultNegativeCacheTTL is the default of Opts.NegativeCacheTTL.
+	DefaultNegativeCacheTTL = time.Minute
+
+	// DefaultMaxCacheEntries is the default of Opts.MaxCacheEntries.
+	DefaultMaxCacheEntries = 10000
+)
+
+// Identity status values of the X-Identity-Status and
+// X-Service-Identity-Status headers.
+const (
+	StatusConfirmed = "Confirmed"
+	StatusInvalid   = "Invalid"
+)
+
+// Opts configures a Middleware.
+type Opts struct {
+	// DelayAuthDecision passes requests with a missing or invalid token on to
+	// the next handler instead of rejecting them. The next handler decides
+	// what to do based on the X-Identity-Status header or FromContext.
+	DelayAuthDecision bool
+
+	// CacheTTL is the longest time a validated token is cached for. A token
+	// is never cached beyond its expiry. It defaults to DefaultCacheTTL.
+	CacheTTL time.Duration
+
+	// NegativeCacheTTL is how long an invalid token is remembered as such.
+	// It defaults to DefaultNegativeCacheTTL. A negative value disables
+	// negative caching.
+	NegativeCacheTTL time.Duration
+
+	// MaxCacheEntries bounds the number of cached tokens. It defaults to
+	// DefaultMaxCacheEntries.
+	MaxCacheEntries int
+
+	// OmitServiceCatalog leaves out the service catalog from the
+	// X-Service-Catalog header and the Identity.
+	OmitServiceCatalog bool
+
+	// WWWAuthenticateURI is the Identity service endpoint that clients are
+	// referred to by the WWW-Authenticate header of a rejected request. It
+	// defaults to the identity endpoint of the Middleware's provider client.
+	WWWAuthenticateURI string
+}
+
+// Middleware validates the tokens of incoming requests with the Identity
+// service, like the auth_token middleware of keystonemiddleware.
+type Middleware struct {
+	identity *gophercloud.ServiceClient
+	opts     Opts
+	cache    *tokenCache
+}
+
+// New returns a Middleware that validates tokens with the given identity v3
+// client. The client must be authenticated with a service user that is
+// allowed to validate tokens; enabling AllowReauth keeps it authenticated.
+func New(identity *gophercloud.ServiceClient, opts Opts) *Middleware {
+	if opts.CacheTTL == 0 {
+		opts.CacheTTL = DefaultCacheTTL
+	}
+	if opts.NegativeCacheTTL == 0 {
+		opts.NegativeCacheTTL = DefaultNegativeCacheTTL
+	}
+	if opts.MaxCacheEntries <= 0 {
+		opts.MaxCacheEntries = DefaultMaxCacheEntries
+	}
+	if opts.WWWAuthenticateURI == "" && identity.ProviderClient != nil {
+		opts.WWWAuthenticateURI = identity.IdentityEndpoint
+	}
+
+	return &Middleware{
+		identity: identity,
+		opts:     opts,
+		cache:    newTokenCache(opts.MaxCacheEntries),
+	}
+}
+
+// Validate returns the Identity of a token. It returns an ErrInvalidToken if
+// the Identity service doesn't accept the token, and any other error if the
+// token couldn't be validated. Results are cached.
+func (m *Middleware) Validate(token string) (*Identity, error) {
+	now := time.Now()
+	key := cacheKey(token)
+	if e, ok := m.cache.get(key, now); ok {
+		if e.identity == nil {
+			return nil, ErrInvalidToken{}
+		}
+		return e.identity, nil
+	}
+
+	id, err := m.fetch(token)
+	if err != nil {
+		if _, ok := err.(ErrInvalidToken); ok && m.opts.NegativeCacheTTL > 0 {
+			m.cache.set(key, cacheEntry{expires: now.Add(m.opts.NegativeCacheTTL)}, now)
+		}
+		return nil, err
+	}
+	if !now.Before(id.ExpiresAt) {
+		return nil, ErrInvalidToken{}
+	}
+
+	expires := now.Add(m.opts.CacheTTL)
+	if id.ExpiresAt.Before(expires) {
+		expires = id.ExpiresAt
+	}
+	m.cache.set(key, cacheEntry{identity: id, expires: expires}, now)
+	return id, nil
+}
+
+// fetch validates a token with the Identity service.
+func (m *Middleware) fetch(token string) (*Identity, error) {
+	r := tokens.Get(m.identity, token)
+	if r.Err != nil {
+		if _, ok := r.Err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrInvalidToken{}
+		}
+		return nil, r.Err
+	}
+
+	t, err := r.ExtractToken()
+	if err != nil {
+		return nil, err
+	}
+	id := &Identity{ExpiresAt: t.ExpiresAt}
+
+	user, err := r.ExtractUser()
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		id.User = *user
+	}
+	if id.Project, err = r.ExtractProject(); err != nil {
+		return nil, err
+	}
+	if id.Domain, err = r.ExtractDomain(); err != nil {
+		return nil, err
+	}
+	if id.System, err = r.ExtractSystem(); err != nil {
+		return nil, err
+	}
+	if id.Roles, err = r.ExtractRoles(); err != nil {
+		return nil, err
+	}
+	if !m.opts.OmitServiceCatalog {
+		if id.Catalog, err = r.ExtractServiceCatalog(); err != nil {
+			return nil, err
+		}
+	}
+
+	return id, nil
+}
+
+// Handler wraps next with token validation.
+//
+// A request with a valid X-Auth-Token reaches next with the identity headers
+// of keystonemiddleware, such as X-User-Id, X-Project-Id, X-Roles and
+// X-Service-Catalog, and with its Identity in the request context. A valid
+// X-Service-Token is described by the same headers with an X-Service-
+// prefix. Identity headers sent by the client are always removed.
+//
+// A request with a missing or invalid token is rejected with 401 Unauthorized
+// unless DelayAuthDecision is set. If a token can't be validated at all, the
+// request is rejected with 503 Service Unavailable.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		removeIdentityHeaders(r.Header)
+		ctx := r.Context()
+
+		id, err := m.validateHeader(r.Header.Get("X-Auth-Token"))
+		switch err.(type) {
+		case nil:
+			r.Header.Set("X-Identity-Status", StatusConfirmed)
+			setIdentityHeaders(r.Header, "X-", id)
+			ctx = context.WithValue(ctx, identityKey, id)
+		case ErrInvalidToken:
+			if !m.opts.DelayAuthDecision {
+				m.unauthorized(w)
+				return
+			}
+			r.Header.Set("X-Identity-Status", StatusInvalid)
+		default:
+			m.unavailable(w)
+			return
+		}
+
+		if serviceToken := r.Header.Get("X-Service-Token"); serviceToken != "" {
+			sid, err := m.Validate(serviceToken)
+			switch err.(type) {
+			case nil:
+				r.Header.Set("X-Service-Identity-Status", StatusConfirmed)
+				setIdentityHeaders(r.Header, "X-Service-", sid)
+				ctx = context.WithValue(ctx, serviceIdentityKey, sid)
+			case ErrInvalidToken:
+				if !m.opts.DelayAuthDecision {
+					m.unauthorized(w)
+					return
+				}
+				r.Header.Set("X-Service-Identity-Status", StatusInvalid)
+			default:
+				m.unavailable(w)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (m *Middleware) validateHeader(token string) (*Identity, error) {
+	if token == "" {
+		return nil, ErrInvalidToken{}
+	}
+	return m.Validate(token)
+}
+
+func (m *Middleware) unauthorized(w http.ResponseWriter) {
+	if m.opts.WWWAuthenticateURI != "" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf("Keystone uri=%q", m.opts.WWWAuthenticateURI))
+	}
+	http.Error(w, "Authentication required", http.StatusUnauthorized)
+}
+
+func (m *Middleware) unavailable(w http.ResponseWriter) {
+	http.Error(w, "The Keystone service is temporarily unavailable", http.StatusServiceUnavailable)
+}
+
+// identityHeaders are the headers that describe a token, without their
+// "X-" or "X-Service-" prefix.
+var identityHeaders = []string{
+	"User-Id", "User-Name", "User-Domain-Id", "User-Domain-Name",
+	"Project-Id", "Project-Name", "Project-Domain-Id", "Project-Domain-Name",
+	"Domain-Id", "Domain-Name", "System-Scope", "Roles", "Service-Catalog",
+}
+
+func removeIdentityHeaders(h http.Header) {
+	h.Del("X-Identity-Status")
+	h.Del("X-Service-Identity-Status")
+	for _, name := range identityHeaders {
+		h.Del("X-" + name)
+		h.Del("X-Service-" + name)
+	}
+}
+
+func setIdentityHeaders(h http.Header, prefix string, id *Identity) {
+	set := func(name, value string) {
+		if value != "" {
+			h.Set(prefix+name, value)
+		}
+	}
+
+	set("User-Id", id.User.ID)
+	set("User-Name", id.User.Name)
+	set("User-Domain-Id", id.User.Domain.ID)
+	set("User-Domain-Name", id.User.Domain.Name)
+	if id.Project != nil {
+		set("Project-Id", id.Project.ID)
+		set("Project-Name", id.Project.Name)
+		set("Project-Domain-Id", id.Project.Domain.ID)
+		set("Project-Domain-Name", id.Project.Domain.Name)
+	}
+	if id.Domain != nil {
+		set("Domain-Id", id.Domain.ID)
+		set("Domain-Name", id.Domain.Name)
+	}
+	if id.System != nil && id.System.All {
+		set("System-Scope", "all")
+	}
+	set("Roles", strings.Join(id.RoleNames(), ","))
+
+	// Only the user's catalog is passed on, as keystonemiddleware does.
+	if prefix == "X-" && id.Catalog != nil {
+		if b, err := json.Marshal(id.Catalog.Entries); err == nil {
+			set("Service-Catalog", string(b))
+		}
+	}
+}
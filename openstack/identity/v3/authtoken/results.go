@@ -0,0 +1,75 @@
+package authtoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+)
+
+// Identity describes the user and scope of a validated token.
+type Identity struct {
+	// ExpiresAt is the time at which the token expires.
+	ExpiresAt time.Time
+
+	// User is the user the token was issued to.
+	User tokens.User
+
+	// Project is the project the token is scoped to, if any.
+	Project *tokens.Project
+
+	// Domain is the domain the token is scoped to, if any.
+	Domain *tokens.Domain
+
+	// System is the system the token is scoped to, if any.
+	System *tokens.System
+
+	// Roles are the roles the user has in the token's scope.
+	Roles []tokens.Role
+
+	// Catalog is the service catalog of the token. It is nil if the
+	// middleware omits the service catalog.
+	Catalog *tokens.ServiceCatalog
+}
+
+// RoleNames returns the names of the roles of the token.
+func (id *Identity) RoleNames() []string {
+	names := make([]string, len(id.Roles))
+	for i, role := range id.Roles {
+		names[i] = role.Name
+	}
+	return names
+}
+
+// HasRole reports whether the token has the role with the given name.
+func (id *Identity) HasRole(name string) bool {
+	for _, role := range id.Roles {
+		if role.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey int
+
+const (
+	identityKey contextKey = iota
+	serviceIdentityKey
+)
+
+// FromContext returns the Identity of the X-Auth-Token of a request that
+// passed through the middleware. It returns false if the request had no
+// valid token.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey).(*Identity)
+	return id, ok
+}
+
+// ServiceFromContext returns the Identity of the X-Service-Token of a
+// request that passed through the middleware. It returns false if the
+// request had no valid service token.
+func ServiceFromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(serviceIdentityKey).(*Identity)
+	return id, ok
+}
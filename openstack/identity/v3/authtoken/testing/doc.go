@@ -0,0 +1,2 @@
+// authtoken unit tests
+package testing
@@ -0,0 +1,146 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+// ValidTokenBody is the body of a project-scoped token, with a placeholder
+// for its expiry.
+const ValidTokenBody = `
+{
+    "token": {
+        "expires_at": "%s",
+        "methods": ["password"],
+        "user": {
+            "domain": {
+                "id": "default",
+                "name": "Default"
+            },
+            "id": "0ca8f6",
+            "name": "joe"
+        },
+        "project": {
+            "domain": {
+                "id": "default",
+                "name": "Default"
+            },
+            "id": "263fd9",
+            "name": "demo"
+        },
+        "roles": [
+            { "id": "51cc68", "name": "member" },
+            { "id": "9fe2ff", "name": "reader" }
+        ],
+        "catalog": [
+            {
+                "endpoints": [
+                    {
+                        "id": "39dc32",
+                        "interface": "public",
+                        "region": "RegionOne",
+                        "region_id": "RegionOne",
+                        "url": "https://compute.example.com/v2.1"
+                    }
+                ],
+                "id": "4363ae",
+                "name": "nova",
+                "type": "compute"
+            }
+        ]
+    }
+}
+`
+
+// ServiceTokenBody is the body of a token of a service user, with a
+// placeholder for its expiry.
+const ServiceTokenBody = `
+{
+    "token": {
+        "expires_at": "%s",
+        "methods": ["password"],
+        "user": {
+            "domain": { "id": "default", "name": "Default" },
+            "id": "f3e8a1",
+            "name": "nova"
+        },
+        "project": {
+            "domain": { "id": "default", "name": "Default" },
+            "id": "b1d3a2",
+            "name": "service"
+        },
+        "roles": [
+            { "id": "2c1e8f", "name": "service" }
+        ]
+    }
+}
+`
+
+// SystemTokenBody is the body of a system-scoped token, with a placeholder
+// for its expiry.
+const SystemTokenBody = `
+{
+    "token": {
+        "expires_at": "%s",
+        "methods": ["password"],
+        "user": {
+            "domain": { "id": "default", "name": "Default" },
+            "id": "a0b1c2",
+            "name": "admin"
+        },
+        "system": { "all": true },
+        "roles": [
+            { "id": "8b2a1c", "name": "admin" }
+        ]
+    }
+}
+`
+
+// FakeKeystone counts the validations of each token by a fake Identity
+// service.
+type FakeKeystone struct {
+	Validations map[string]int
+}
+
+// HandleValidateTokens creates an HTTP handler at `/auth/tokens` on the test
+// handler mux that validates the tokens "valid-token", "service-token" and
+// "system-token", expiring at the given time. It fails to validate
+// "broken-token" and rejects all other tokens.
+func HandleValidateTokens(t *testing.T, expiresAt time.Time) *FakeKeystone {
+	k := &FakeKeystone{Validations: make(map[string]int)}
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		token := r.Header.Get("X-Subject-Token")
+		k.Validations[token]++
+
+		var body string
+		switch token {
+		case "valid-token":
+			body = ValidTokenBody
+		case "service-token":
+			body = ServiceTokenBody
+		case "system-token":
+			body = SystemTokenBody
+		case "broken-token":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"error": {"code": 404, "message": "Could not find token.", "title": "Not Found"}}`)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", token)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, body, expiresAt.UTC().Format("2006-01-02T15:04:05.000000Z"))
+	})
+	return k
+}
@@ -0,0 +1,257 @@
+package testing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/authtoken"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+// recorder is a handler that remembers the last request it served.
+type recorder struct {
+	request *http.Request
+}
+
+func (h *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.request = r
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func serve(m *authtoken.Middleware, next http.Handler, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", "/servers", nil)
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	m.Handler(next).ServeHTTP(w, r)
+	return w
+}
+
+func TestValidToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	k := HandleValidateTokens(t, time.Now().Add(time.Hour))
+
+	m := authtoken.New(client.ServiceClient(), authtoken.Opts{})
+	next := &recorder{}
+
+	for i := 0; i < 2; i++ {
+		w := serve(m, next, map[string]string{
+			"X-Auth-Token": "valid-token",
+			// A client can't claim roles of its own.
+			"X-Roles": "admin",
+		})
+		th.AssertEquals(t, http.StatusNoContent, w.Code)
+	}
+	th.AssertEquals(t, 1, k.Validations["valid-token"])
+
+	h := next.request.Header
+	th.AssertEquals(t, authtoken.StatusConfirmed, h.Get("X-Identity-Status"))
+	th.AssertEquals(t, "0ca8f6", h.Get("X-User-Id"))
+	th.AssertEquals(t, "joe", h.Get("X-User-Name"))
+	th.AssertEquals(t, "default", h.Get("X-User-Domain-Id"))
+	th.AssertEquals(t, "263fd9", h.Get("X-Project-Id"))
+	th.AssertEquals(t, "demo", h.Get("X-Project-Name"))
+	th.AssertEquals(t, "member,reader", h.Get("X-Roles"))
+	th.AssertEquals(t, "", h.Get("X-System-Scope"))
+	th.AssertEquals(t, `[{"id":"4363ae","name":"nova","type":"compute","endpoints":[{"id":"39dc32","region":"RegionOne","region_id":"RegionOne","interface":"public","url":"https://compute.example.com/v2.1"}]}]`, h.Get("X-Service-Catalog"))
+
+	id, ok := authtoken.FromContext(next.request.Context())
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "0ca8f6", id.User.ID)
+	th.AssertEquals(t, "263fd9", id.Project.ID)
+	th.AssertEquals(t, true, id.HasRole("member"))
+	th.AssertEquals(t, false, id.HasRole("admin"))
+	th.AssertEquals(t, "compute", id.Catalog.Entries[0].Type)
+
+	_, ok = authtoken.ServiceFromContext(next.request.Context())
+	th.AssertEquals(t, false, ok)
+}
+
+func TestSystemToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleValidateTokens(t, time.Now().Add(time.Hour))
+
+	m := authtoken.New(client.ServiceClient(), authtoken.Opts{OmitServiceCatalog: true})
+	next := &recorder{}
+
+	w := serve(m, next, map[string]string{"X-Auth-Token": "system-token"})
+	th.AssertEquals(t, http.StatusNoContent, w.Code)
+
+	h := next.request.Header
+	th.AssertEquals(t, "all", h.Get("X-System-Scope"))
+	th.AssertEquals(t, "admin", h.Get("X-Roles"))
+	th.AssertEquals(t, "", h.Get("X-Project-Id"))
+	th.AssertEquals(t, "", h.Get("X-Service-Catalog"))
+}
+
+func TestInvalidToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	k := HandleValidateTokens(t, time.Now().Add(time.Hour))
+
+	m := authtoken.New(client.ServiceClient(), authtoken.Opts{
+		WWWAuthenticateURI: "https://keystone.example.com:5000",
+	})
+	next := &recorder{}
+
+	for i := 0; i < 2; i++ {
+		w := serve(m, next, map[string]string{"X-Auth-Token": "revoked-token"})
+		th.AssertEquals(t, http.StatusUnauthorized, w.Code)
+		th.AssertEquals(t, `Keystone uri="https://keystone.example.com:5000"`, w.Header().Get("WWW-Authenticate"))
+	}
+	th.AssertEquals(t, 1, k.Validations["revoked-token"])
+
+	w := serve(m, next, nil)
+	th.AssertEquals(t, http.StatusUnauthorized, w.Code)
+
+	if next.request != nil {
+		t.Fatal("A request with an invalid token reached the handler")
+	}
+}
+
+func TestNegativeCacheDisabled(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	k := HandleValidateTokens(t, time.Now().Add(time.Hour))
+
+	m := authtoken.New(client.ServiceClient(), authtoken.Opts{NegativeCacheTTL: -1})
+	for i := 0; i < 2; i++ {
+		_, err := m.Validate("revoked-token")
+		if _, ok := err.(authtoken.ErrInvalidToken); !ok {
+			t.Fatalf("Expected ErrInvalidToken, got %v", err)
+		}
+	}
+	th.AssertEquals(t, 2, k.Validations["revoked-token"])
+}
+
+func TestDelayAuthDecision(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleValidateTokens(t, time.Now().Add(time.Hour))
+
+	m := authtoken.New(client.ServiceClient(), authtoken.Opts{DelayAuthDecision: true})
+	next := &recorder{}
+
+	w := serve(m, next, map[string]string{
+		"X-Auth-Token":    "revoked-token",
+		"X-Service-Token": "revoked-token",
+		"X-User-Id":       "spoofed",
+	})
+	th.AssertEquals(t, http.StatusNoContent, w.Code)
+
+	h := next.request.Header
+	th.AssertEquals(t, authtoken.StatusInvalid, h.Get("X-Identity-Status"))
+	th.AssertEquals(t, authtoken.StatusInvalid, h.Get("X-Service-Identity-Status"))
+	th.AssertEquals(t, "", h.Get("X-User-Id"))
+
+	_, ok := authtoken.FromContext(next.request.Context())
+	th.AssertEquals(t, false, ok)
+}
+
+func TestServiceToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleValidateTokens(t, time.Now().Add(time.Hour))
+
+	m := authtoken.New(client.ServiceClient(), authtoken.Opts{})
+	next := &recorder{}
+
+	w := serve(m, next, map[string]string{
+		"X-Auth-Token":    "valid-token",
+		"X-Service-Token": "service-token",
+	})
+	th.AssertEquals(t, http.StatusNoContent, w.Code)
+
+	h := next.request.Header
+	th.AssertEquals(t, authtoken.StatusConfirmed, h.Get("X-Service-Identity-Status"))
+	th.AssertEquals(t, "f3e8a1", h.Get("X-Service-User-Id"))
+	th.AssertEquals(t, "b1d3a2", h.Get("X-Service-Project-Id"))
+	th.AssertEquals(t, "service", h.Get("X-Service-Roles"))
+	th.AssertEquals(t, "0ca8f6", h.Get("X-User-Id"))
+
+	sid, ok := authtoken.ServiceFromContext(next.request.Context())
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "nova", sid.User.Name)
+
+	// An invalid service token is rejected even with a valid user token.
+	next.request = nil
+	w = serve(m, next, map[string]string{
+		"X-Auth-Token":    "valid-token",
+		"X-Service-Token": "revoked-token",
+	})
+	th.AssertEquals(t, http.StatusUnauthorized, w.Code)
+	if next.request != nil {
+		t.Fatal("A request with an invalid service token reached the handler")
+	}
+}
+
+func TestKeystoneUnavailable(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	k := HandleValidateTokens(t, time.Now().Add(time.Hour))
+
+	m := authtoken.New(client.ServiceClient(), authtoken.Opts{DelayAuthDecision: true})
+	for i := 0; i < 2; i++ {
+		w := serve(m, &recorder{}, map[string]string{"X-Auth-Token": "broken-token"})
+		th.AssertEquals(t, http.StatusServiceUnavailable, w.Code)
+	}
+	// Failures aren't cached.
+	th.AssertEquals(t, 2, k.Validations["broken-token"])
+}
+
+func TestCacheExpiry(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	// The token expires before the cache TTL runs out.
+	k := HandleValidateTokens(t, time.Now().Add(150*time.Millisecond))
+	m := authtoken.New(client.ServiceClient(), authtoken.Opts{})
+
+	_, err := m.Validate("valid-token")
+	th.AssertNoErr(t, err)
+	_, err = m.Validate("valid-token")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, k.Validations["valid-token"])
+
+	time.Sleep(200 * time.Millisecond)
+
+	// The fake Identity service returns the expired token as is, but it is
+	// rejected nonetheless.
+	_, err = m.Validate("valid-token")
+	if _, ok := err.(authtoken.ErrInvalidToken); !ok {
+		t.Fatalf("Expected ErrInvalidToken, got %v", err)
+	}
+	th.AssertEquals(t, 2, k.Validations["valid-token"])
+}
+
+func TestCacheTTL(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	k := HandleValidateTokens(t, time.Now().Add(time.Hour))
+
+	m := authtoken.New(client.ServiceClient(), authtoken.Opts{
+		CacheTTL:        50 * time.Millisecond,
+		MaxCacheEntries: 1,
+	})
+
+	_, err := m.Validate("valid-token")
+	th.AssertNoErr(t, err)
+
+	// The cache holds a single token, so the service token evicts it.
+	_, err = m.Validate("service-token")
+	th.AssertNoErr(t, err)
+	_, err = m.Validate("valid-token")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, k.Validations["valid-token"])
+
+	time.Sleep(100 * time.Millisecond)
+	_, err = m.Validate("valid-token")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, k.Validations["valid-token"])
+}
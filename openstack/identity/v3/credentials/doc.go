@@ -0,0 +1,81 @@
+/*
+Package credentials manages and retrieves Credentials in the OpenStack
+Identity Service. A credential is a secret of a user, such as a pair of EC2
+keys or a TOTP seed.
+
+Example to List Credentials
+
+	listOpts := credentials.ListOpts{
+		UserID: "bb5476fd12884539b41d5a88f838d773",
+		Type:   "ec2",
+	}
+
+	allPages, err := credentials.List(identityClient, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allCredentials, err := credentials.ExtractCredentials(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, credential := range allCredentials {
+		fmt.Printf("%+v\n", credential)
+	}
+
+Example to Create an EC2 Credential
+
+	blob := credentials.EC2Blob{
+		Access: "181920",
+		Secret: "secretKey",
+	}
+
+	createOpts := credentials.CreateOpts{
+		Blob:      blob.String(),
+		ProjectID: "731fc6f265cd486d900f16e84c5cb594",
+		Type:      "ec2",
+		UserID:    "bb5476fd12884539b41d5a88f838d773",
+	}
+
+	credential, err := credentials.Create(identityClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Get the Keys of an EC2 Credential
+
+	credentialID := "3d3367228f9c7665266604462ec60029bcd83ad89614021a80b2eb879c572510"
+
+	credential, err := credentials.Get(identityClient, credentialID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	keys, err := credential.ExtractEC2Blob()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Update a Credential
+
+	credentialID := "3d3367228f9c7665266604462ec60029bcd83ad89614021a80b2eb879c572510"
+
+	updateOpts := credentials.UpdateOpts{
+		ProjectID: "731fc6f265cd486d900f16e84c5cb594",
+	}
+
+	credential, err := credentials.Update(identityClient, credentialID, updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Credential
+
+	credentialID := "3d3367228f9c7665266604462ec60029bcd83ad89614021a80b2eb879c572510"
+	err := credentials.Delete(identityClient, credentialID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package credentials
@@ -0,0 +1,161 @@
+package credentials
+
+import (
+	"encoding/json"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to
+// the List request
+type ListOptsBuilder interface {
+	ToCredentialListQuery() (string, error)
+}
+
+// ListOpts provides options to filter the List results.
+type ListOpts struct {
+	// UserID filters the response by a user ID.
+	UserID string `q:"user_id"`
+
+	// Type filters the response by a credential type, such as "ec2" or
+	// "totp".
+	Type string `q:"type"`
+}
+
+// ToCredentialListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToCredentialListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List enumerates the Credentials to which the current token has access.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(client)
+	if opts != nil {
+		query, err := opts.ToCredentialListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return CredentialPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves details on a single credential, by ID.
+func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
+	resp, err := client.Get(getURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to
+// the Create request.
+type CreateOptsBuilder interface {
+	ToCredentialCreateMap() (map[string]interface{}, error)
+}
+
+// CreateOpts provides options used to create a credential.
+type CreateOpts struct {
+	// Blob is the serialized credential. For the "ec2" type, it is a JSON
+	// object with the access and secret keys; see EC2Blob.
+	Blob string `json:"blob" required:"true"`
+
+	// ProjectID is the ID of the project the credential belongs to. It is
+	// required for the "ec2" type.
+	ProjectID string `json:"project_id,omitempty"`
+
+	// Type is the type of the credential, such as "ec2", "cert" or "totp".
+	Type string `json:"type" required:"true"`
+
+	// UserID is the ID of the user who owns the credential.
+	UserID string `json:"user_id" required:"true"`
+}
+
+// ToCredentialCreateMap formats a CreateOpts into a create request.
+func (opts CreateOpts) ToCredentialCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "credential")
+}
+
+// Create creates a new Credential.
+func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToCredentialCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Post(createURL(client), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to
+// the Update request.
+type UpdateOptsBuilder interface {
+	ToCredentialUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateOpts represents parameters to update a credential.
+type UpdateOpts struct {
+	// Blob is the serialized credential.
+	Blob string `json:"blob,omitempty"`
+
+	// ProjectID is the ID of the project the credential belongs to.
+	ProjectID string `json:"project_id,omitempty"`
+
+	// Type is the type of the credential.
+	Type string `json:"type,omitempty"`
+
+	// UserID is the ID of the user who owns the credential.
+	UserID string `json:"user_id,omitempty"`
+}
+
+// ToCredentialUpdateMap formats an UpdateOpts into an update request.
+func (opts UpdateOpts) ToCredentialUpdateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "credential")
+}
+
+// Update modifies the attributes of a credential.
+func Update(client *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToCredentialUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(updateURL(client, id), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete deletes a credential.
+func Delete(client *gophercloud.ServiceClient, id string) (r DeleteResult) {
+	resp, err := client.Delete(deleteURL(client, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// EC2Blob is the content of the blob of an "ec2" credential.
+type EC2Blob struct {
+	// Access is the EC2 access key.
+	Access string `json:"access"`
+
+	// Secret is the EC2 secret key.
+	Secret string `json:"secret"`
+
+	// TrustID is the ID of the trust the credential was created with, if
+	// any.
+	TrustID string `json:"trust_id,omitempty"`
+}
+
+// String serializes the blob for use as the Blob of CreateOpts or
+// UpdateOpts.
+func (b EC2Blob) String() string {
+	s, _ := json.Marshal(b)
+	return string(s)
+}
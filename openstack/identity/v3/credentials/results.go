@@ -0,0 +1,110 @@
+package credentials
+
+import (
+	"encoding/json"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Credential represents a secret of a user, such as a pair of EC2 keys or a
+// TOTP seed, that is stored by the OpenStack Identity Service.
+type Credential struct {
+	// ID is the unique ID of the credential.
+	ID string `json:"id"`
+
+	// Blob is the serialized credential.
+	Blob string `json:"blob"`
+
+	// ProjectID is the ID of the project the credential belongs to.
+	ProjectID string `json:"project_id"`
+
+	// Type is the type of the credential.
+	Type string `json:"type"`
+
+	// UserID is the ID of the user who owns the credential.
+	UserID string `json:"user_id"`
+
+	// Links contains referencing links to the credential.
+	Links map[string]interface{} `json:"links"`
+}
+
+// ExtractEC2Blob interprets the Blob of an "ec2" credential.
+func (c Credential) ExtractEC2Blob() (*EC2Blob, error) {
+	var b EC2Blob
+	err := json.Unmarshal([]byte(c.Blob), &b)
+	return &b, err
+}
+
+type credentialResult struct {
+	gophercloud.Result
+}
+
+// GetResult is the response from a Get operation. Call its Extract method
+// to interpret it as a Credential.
+type GetResult struct {
+	credentialResult
+}
+
+// CreateResult is the response from a Create operation. Call its Extract
+// method to interpret it as a Credential.
+type CreateResult struct {
+	credentialResult
+}
+
+// UpdateResult is the response from an Update operation. Call its Extract
+// method to interpret it as a Credential.
+type UpdateResult struct {
+	credentialResult
+}
+
+// DeleteResult is the response from a Delete operation. Call its ExtractErr
+// to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// CredentialPage is a single page of Credential results.
+type CredentialPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a CredentialPage contains any results.
+func (r CredentialPage) IsEmpty() (bool, error) {
+	credentials, err := ExtractCredentials(r)
+	return len(credentials) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r CredentialPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractCredentials returns a slice of Credentials contained in a single
+// page of results.
+func ExtractCredentials(r pagination.Page) ([]Credential, error) {
+	var s struct {
+		Credentials []Credential `json:"credentials"`
+	}
+	err := (r.(CredentialPage)).ExtractInto(&s)
+	return s.Credentials, err
+}
+
+// Extract interprets any credentialResult as a Credential.
+func (r credentialResult) Extract() (*Credential, error) {
+	var s struct {
+		Credential *Credential `json:"credential"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Credential, err
+}
@@ -0,0 +1,2 @@
+// credentials unit tests
+package testing
@@ -0,0 +1,105 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/credentials"
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestListCredentials(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListCredentialsSuccessfully(t)
+
+	listOpts := credentials.ListOpts{
+		UserID: "bb5476fd12884539b41d5a88f838d773",
+	}
+
+	count := 0
+	err := credentials.List(client.ServiceClient(), listOpts).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := credentials.ExtractCredentials(page)
+		th.AssertNoErr(t, err)
+
+		th.CheckDeepEquals(t, ExpectedCredentialsSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestGetCredential(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetCredentialSuccessfully(t)
+
+	actual, err := credentials.Get(client.ServiceClient(), credentialID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, EC2Credential, *actual)
+
+	blob, err := actual.ExtractEC2Blob()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, credentials.EC2Blob{Access: "181920", Secret: "secretKey"}, *blob)
+}
+
+func TestCreateCredential(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateCredentialSuccessfully(t)
+
+	blob := credentials.EC2Blob{
+		Access: "181920",
+		Secret: "secretKey",
+	}
+
+	createOpts := credentials.CreateOpts{
+		Blob:      blob.String(),
+		ProjectID: "731fc6f265cd486d900f16e84c5cb594",
+		Type:      "ec2",
+		UserID:    "bb5476fd12884539b41d5a88f838d773",
+	}
+
+	actual, err := credentials.Create(client.ServiceClient(), createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, EC2Credential, *actual)
+}
+
+func TestCreateCredentialMissingFields(t *testing.T) {
+	createOpts := credentials.CreateOpts{
+		Blob: "GJRDMRRVGQYTONBTGI4DOMRXHAZDMNJRHA3TENBQ",
+		Type: "totp",
+	}
+
+	_, err := createOpts.ToCredentialCreateMap()
+	if err == nil {
+		t.Fatal("Expected an error for the missing UserID")
+	}
+}
+
+func TestUpdateCredential(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUpdateCredentialSuccessfully(t)
+
+	updateOpts := credentials.UpdateOpts{
+		ProjectID: "1a32f3f1b5f04cc8a2aaf2bc7d3ff9b8",
+	}
+
+	actual, err := credentials.Update(client.ServiceClient(), credentialID, updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, UpdatedEC2Credential, *actual)
+}
+
+func TestDeleteCredential(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteCredentialSuccessfully(t)
+
+	res := credentials.Delete(client.ServiceClient(), credentialID)
+	th.AssertNoErr(t, res.Err)
+}
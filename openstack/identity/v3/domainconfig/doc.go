@@ -0,0 +1,60 @@
+/*
+Package domainconfig manages the domain-specific configuration of the
+identity drivers of the OpenStack Identity service, such as the LDAP server
+the users of a domain are stored in.
+
+A configuration consists of groups of options. Only the "identity" and
+"ldap" groups may be set for a domain.
+
+Example to Configure a Domain to Use LDAP
+
+	domainID := "5a75994a383c449184053ff7270c4e91"
+
+	createOpts := domainconfig.CreateOpts{
+		Config: domainconfig.Config{
+			"identity": {
+				"driver": "ldap",
+			},
+			"ldap": {
+				"url":          "ldap://myldap.com:389/",
+				"user_tree_dn":  "ou=Users,dc=my_new_root,dc=org",
+			},
+		},
+	}
+
+	config, err := domainconfig.Create(identityClient, domainID, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Get the Configuration of a Domain
+
+	config, err := domainconfig.Get(identityClient, domainID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(config["ldap"]["url"])
+
+Example to Change an Option of the Configuration of a Domain
+
+	config, err := domainconfig.UpdateOption(identityClient, domainID, "ldap", "url", "ldap://other.com:389/").Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Get the Default Value of an Option
+
+	value, err := domainconfig.GetDefaultOption(identityClient, "ldap", "user_tree_dn").Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete the Configuration of a Domain
+
+	err := domainconfig.Delete(identityClient, domainID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package domainconfig
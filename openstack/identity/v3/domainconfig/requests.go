@@ -0,0 +1,166 @@
+package domainconfig
+
+import (
+	"github.com/gophercloud/gophercloud"
+)
+
+// CreateOptsBuilder allows extensions to add additional parameters to
+// the Create request.
+type CreateOptsBuilder interface {
+	ToDomainConfigCreateMap() (map[string]interface{}, error)
+}
+
+// CreateOpts provides the configuration of a domain.
+type CreateOpts struct {
+	// Config is the configuration of the domain. Only the "identity" and
+	// "ldap" groups may be set.
+	Config Config `json:"config" required:"true"`
+}
+
+// ToDomainConfigCreateMap formats a CreateOpts into a create request.
+func (opts CreateOpts) ToDomainConfigCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "")
+}
+
+// Create sets the configuration of a domain, which must not have a
+// configuration yet.
+func Create(client *gophercloud.ServiceClient, domainID string, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToDomainConfigCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(configURL(client, domainID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Get retrieves the configuration of a domain.
+func Get(client *gophercloud.ServiceClient, domainID string) (r GetResult) {
+	resp, err := client.Get(configURL(client, domainID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetGroup retrieves a single group of the configuration of a domain.
+func GetGroup(client *gophercloud.ServiceClient, domainID, group string) (r GetResult) {
+	resp, err := client.Get(groupURL(client, domainID, group), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetOption retrieves a single option of the configuration of a domain.
+func GetOption(client *gophercloud.ServiceClient, domainID, group, option string) (r GetOptionResult) {
+	resp, err := client.Get(optionURL(client, domainID, group, option), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to
+// the Update request.
+type UpdateOptsBuilder interface {
+	ToDomainConfigUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateOpts provides the options to change in the configuration of a
+// domain. Options that aren't set are left unchanged.
+type UpdateOpts struct {
+	// Config contains the options to change, by group.
+	Config Config `json:"config" required:"true"`
+}
+
+// ToDomainConfigUpdateMap formats an UpdateOpts into an update request.
+func (opts UpdateOpts) ToDomainConfigUpdateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "")
+}
+
+// Update changes options of the configuration of a domain.
+func Update(client *gophercloud.ServiceClient, domainID string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToDomainConfigUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(configURL(client, domainID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateGroup changes options of a single group of the configuration of a
+// domain. The Config of opts may only contain that group.
+func UpdateGroup(client *gophercloud.ServiceClient, domainID, group string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToDomainConfigUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(groupURL(client, domainID, group), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOption changes a single option of the configuration of a domain.
+func UpdateOption(client *gophercloud.ServiceClient, domainID, group, option string, value interface{}) (r UpdateResult) {
+	b := map[string]interface{}{
+		"config": map[string]interface{}{
+			option: value,
+		},
+	}
+	resp, err := client.Patch(optionURL(client, domainID, group, option), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete removes the configuration of a domain, which then uses the
+// configuration of the Identity service.
+func Delete(client *gophercloud.ServiceClient, domainID string) (r DeleteResult) {
+	resp, err := client.Delete(configURL(client, domainID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteGroup removes a single group from the configuration of a domain.
+func DeleteGroup(client *gophercloud.ServiceClient, domainID, group string) (r DeleteResult) {
+	resp, err := client.Delete(groupURL(client, domainID, group), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteOption removes a single option from the configuration of a domain.
+func DeleteOption(client *gophercloud.ServiceClient, domainID, group, option string) (r DeleteResult) {
+	resp, err := client.Delete(optionURL(client, domainID, group, option), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetDefault retrieves the default configuration of the domains, that is,
+// the options the configuration of a domain may set and their defaults.
+func GetDefault(client *gophercloud.ServiceClient) (r GetResult) {
+	resp, err := client.Get(defaultURL(client), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetDefaultGroup retrieves a single group of the default configuration of
+// the domains.
+func GetDefaultGroup(client *gophercloud.ServiceClient, group string) (r GetResult) {
+	resp, err := client.Get(defaultGroupURL(client, group), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetDefaultOption retrieves a single option of the default configuration
+// of the domains.
+func GetDefaultOption(client *gophercloud.ServiceClient, group, option string) (r GetOptionResult) {
+	resp, err := client.Get(defaultOptionURL(client, group, option), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
@@ -0,0 +1,68 @@
+package domainconfig
+
+import (
+	"github.com/gophercloud/gophercloud"
+)
+
+// Config is the identity driver configuration of a domain. It maps the
+// names of the configuration groups, such as "identity" and "ldap", to their
+// options.
+type Config map[string]map[string]interface{}
+
+type configResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any configResult as a Config.
+func (r configResult) Extract() (Config, error) {
+	var s struct {
+		Config Config `json:"config"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Config, err
+}
+
+// CreateResult is the response from a Create operation. Call its Extract
+// method to interpret it as a Config.
+type CreateResult struct {
+	configResult
+}
+
+// GetResult is the response from a Get, GetGroup, GetDefault or
+// GetDefaultGroup operation. Call its Extract method to interpret it as a
+// Config.
+type GetResult struct {
+	configResult
+}
+
+// UpdateResult is the response from an Update, UpdateGroup or UpdateOption
+// operation. Call its Extract method to interpret it as a Config.
+type UpdateResult struct {
+	configResult
+}
+
+// DeleteResult is the response from a Delete, DeleteGroup or DeleteOption
+// operation. Call its ExtractErr to determine if the request succeeded or
+// failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// GetOptionResult is the response from a GetOption or GetDefaultOption
+// operation. Call its Extract method to interpret it as the value of the
+// option.
+type GetOptionResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a GetOptionResult as the value of the option.
+func (r GetOptionResult) Extract() (interface{}, error) {
+	var s struct {
+		Config map[string]interface{} `json:"config"`
+	}
+	err := r.ExtractInto(&s)
+	for _, v := range s.Config {
+		return v, err
+	}
+	return nil, err
+}
@@ -0,0 +1,2 @@
+// domainconfig unit tests
+package testing
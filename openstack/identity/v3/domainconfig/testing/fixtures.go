@@ -0,0 +1,233 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/domainconfig"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+const domainID = "5a75994a383c449184053ff7270c4e91"
+
+// ConfigOutput provides the configuration of a domain.
+const ConfigOutput = `
+{
+  "config": {
+    "identity": {
+      "driver": "ldap"
+    },
+    "ldap": {
+      "url": "ldap://myldap.com:389/",
+      "user_tree_dn": "ou=Users,dc=my_new_root,dc=org",
+      "page_size": 100
+    }
+  }
+}
+`
+
+// CreateRequest provides the input to a Create request.
+const CreateRequest = `
+{
+  "config": {
+    "identity": {
+      "driver": "ldap"
+    },
+    "ldap": {
+      "url": "ldap://myldap.com:389/",
+      "user_tree_dn": "ou=Users,dc=my_new_root,dc=org",
+      "page_size": 100
+    }
+  }
+}
+`
+
+// GroupOutput provides a single group of the configuration of a domain.
+const GroupOutput = `
+{
+  "config": {
+    "ldap": {
+      "url": "ldap://myldap.com:389/",
+      "user_tree_dn": "ou=Users,dc=my_new_root,dc=org",
+      "page_size": 100
+    }
+  }
+}
+`
+
+// UpdateGroupRequest provides the input to an UpdateGroup request.
+const UpdateGroupRequest = `
+{
+  "config": {
+    "ldap": {
+      "url": "ldap://other.com:389/"
+    }
+  }
+}
+`
+
+// UpdateOptionRequest provides the input to an UpdateOption request.
+const UpdateOptionRequest = `
+{
+  "config": {
+    "url": "ldap://other.com:389/"
+  }
+}
+`
+
+// UpdatedConfigOutput provides the configuration of a domain after its LDAP
+// URL was changed.
+const UpdatedConfigOutput = `
+{
+  "config": {
+    "identity": {
+      "driver": "ldap"
+    },
+    "ldap": {
+      "url": "ldap://other.com:389/",
+      "user_tree_dn": "ou=Users,dc=my_new_root,dc=org",
+      "page_size": 100
+    }
+  }
+}
+`
+
+// OptionOutput provides a single option of a configuration.
+const OptionOutput = `
+{
+  "config": {
+    "user_tree_dn": "ou=Users,dc=my_new_root,dc=org"
+  }
+}
+`
+
+// DomainConfig is the Config expected from ConfigOutput.
+var DomainConfig = domainconfig.Config{
+	"identity": {
+		"driver": "ldap",
+	},
+	"ldap": {
+		"url":          "ldap://myldap.com:389/",
+		"user_tree_dn": "ou=Users,dc=my_new_root,dc=org",
+		"page_size":    float64(100),
+	},
+}
+
+// UpdatedDomainConfig is the Config expected from UpdatedConfigOutput.
+var UpdatedDomainConfig = domainconfig.Config{
+	"identity": {
+		"driver": "ldap",
+	},
+	"ldap": {
+		"url":          "ldap://other.com:389/",
+		"user_tree_dn": "ou=Users,dc=my_new_root,dc=org",
+		"page_size":    float64(100),
+	},
+}
+
+// HandleConfigSuccessfully creates an HTTP handler at
+// `/domains/{domain_id}/config` on the test handler mux that creates, gets
+// and deletes the configuration of a domain.
+func HandleConfigSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/domains/"+domainID+"/config", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "PUT":
+			th.TestJSONRequest(t, r, CreateRequest)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, ConfigOutput)
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, ConfigOutput)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
+
+// HandleGroupSuccessfully creates an HTTP handler at
+// `/domains/{domain_id}/config/ldap` on the test handler mux that gets,
+// updates and deletes the ldap group of the configuration of a domain.
+func HandleGroupSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/domains/"+domainID+"/config/ldap", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, GroupOutput)
+		case "PATCH":
+			th.TestJSONRequest(t, r, UpdateGroupRequest)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, UpdatedConfigOutput)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
+
+// HandleOptionSuccessfully creates HTTP handlers on the test handler mux
+// that get, update and delete options of the ldap group of the configuration
+// of a domain, and that get the default of an option.
+func HandleOptionSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/domains/"+domainID+"/config/ldap/url", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "PATCH":
+			th.TestJSONRequest(t, r, UpdateOptionRequest)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, UpdatedConfigOutput)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	option := func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, OptionOutput)
+	}
+	th.Mux.HandleFunc("/domains/"+domainID+"/config/ldap/user_tree_dn", option)
+	th.Mux.HandleFunc("/domains/config/ldap/user_tree_dn/default", option)
+}
+
+// HandleGetDefaultSuccessfully creates HTTP handlers at
+// `/domains/config/default` and `/domains/config/ldap/default` on the test
+// handler mux that respond with the default configuration.
+func HandleGetDefaultSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/domains/config/default", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ConfigOutput)
+	})
+
+	th.Mux.HandleFunc("/domains/config/ldap/default", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, GroupOutput)
+	})
+}
@@ -0,0 +1,122 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/domainconfig"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestCreateConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleConfigSuccessfully(t)
+
+	createOpts := domainconfig.CreateOpts{
+		Config: domainconfig.Config{
+			"identity": {
+				"driver": "ldap",
+			},
+			"ldap": {
+				"url":          "ldap://myldap.com:389/",
+				"user_tree_dn": "ou=Users,dc=my_new_root,dc=org",
+				"page_size":    100,
+			},
+		},
+	}
+
+	actual, err := domainconfig.Create(client.ServiceClient(), domainID, createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, DomainConfig, actual)
+}
+
+func TestCreateConfigRequiresConfig(t *testing.T) {
+	_, err := domainconfig.CreateOpts{}.ToDomainConfigCreateMap()
+	if err == nil {
+		t.Fatal("Expected an error for the missing Config")
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleConfigSuccessfully(t)
+	HandleGroupSuccessfully(t)
+
+	actual, err := domainconfig.Get(client.ServiceClient(), domainID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, DomainConfig, actual)
+
+	actual, err = domainconfig.GetGroup(client.ServiceClient(), domainID, "ldap").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, domainconfig.Config{"ldap": DomainConfig["ldap"]}, actual)
+}
+
+func TestGetOption(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleOptionSuccessfully(t)
+
+	actual, err := domainconfig.GetOption(client.ServiceClient(), domainID, "ldap", "user_tree_dn").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "ou=Users,dc=my_new_root,dc=org", actual)
+
+	actual, err = domainconfig.GetDefaultOption(client.ServiceClient(), "ldap", "user_tree_dn").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "ou=Users,dc=my_new_root,dc=org", actual)
+}
+
+func TestUpdateConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGroupSuccessfully(t)
+	HandleOptionSuccessfully(t)
+
+	updateOpts := domainconfig.UpdateOpts{
+		Config: domainconfig.Config{
+			"ldap": {
+				"url": "ldap://other.com:389/",
+			},
+		},
+	}
+
+	actual, err := domainconfig.UpdateGroup(client.ServiceClient(), domainID, "ldap", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, UpdatedDomainConfig, actual)
+
+	actual, err = domainconfig.UpdateOption(client.ServiceClient(), domainID, "ldap", "url", "ldap://other.com:389/").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, UpdatedDomainConfig, actual)
+}
+
+func TestDeleteConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleConfigSuccessfully(t)
+	HandleGroupSuccessfully(t)
+	HandleOptionSuccessfully(t)
+
+	err := domainconfig.DeleteOption(client.ServiceClient(), domainID, "ldap", "url").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = domainconfig.DeleteGroup(client.ServiceClient(), domainID, "ldap").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = domainconfig.Delete(client.ServiceClient(), domainID).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestGetDefaultConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetDefaultSuccessfully(t)
+
+	actual, err := domainconfig.GetDefault(client.ServiceClient()).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, DomainConfig, actual)
+
+	actual, err = domainconfig.GetDefaultGroup(client.ServiceClient(), "ldap").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, domainconfig.Config{"ldap": DomainConfig["ldap"]}, actual)
+}
@@ -0,0 +1,27 @@
+package domainconfig
+
+import "github.com/gophercloud/gophercloud"
+
+func configURL(client *gophercloud.ServiceClient, domainID string) string {
+	return client.ServiceURL("domains", domainID, "config")
+}
+
+func groupURL(client *gophercloud.ServiceClient, domainID, group string) string {
+	return client.ServiceURL("domains", domainID, "config", group)
+}
+
+func optionURL(client *gophercloud.ServiceClient, domainID, group, option string) string {
+	return client.ServiceURL("domains", domainID, "config", group, option)
+}
+
+func defaultURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL("domains", "config", "default")
+}
+
+func defaultGroupURL(client *gophercloud.ServiceClient, group string) string {
+	return client.ServiceURL("domains", "config", group, "default")
+}
+
+func defaultOptionURL(client *gophercloud.ServiceClient, group, option string) string {
+	return client.ServiceURL("domains", "config", group, option, "default")
+}
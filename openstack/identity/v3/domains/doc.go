@@ -22,6 +22,22 @@ Example to List Domains
 		fmt.Printf("%+v\n", domain)
 	}
 
+Example to List the Domains Available to the Current Token
+
+	allPages, err := domains.ListAvailable(identityClient).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allDomains, err := domains.ExtractDomains(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, domain := range allDomains {
+		fmt.Printf("%+v\n", domain)
+	}
+
 Example to Create a Domain
 
 	createOpts := domains.CreateOpts{
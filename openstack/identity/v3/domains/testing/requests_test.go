@@ -87,3 +87,23 @@ func TestUpdateDomain(t *testing.T) {
 	th.AssertNoErr(t, err)
 	th.CheckDeepEquals(t, SecondDomainUpdated, *actual)
 }
+
+func TestListAvailableDomains(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListAvailableDomainsSuccessfully(t)
+
+	count := 0
+	err := domains.ListAvailable(client.ServiceClient()).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := domains.ExtractDomains(page)
+		th.AssertNoErr(t, err)
+
+		th.CheckDeepEquals(t, ExpectedDomainsSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
@@ -44,6 +44,14 @@ Example to Create an Endpoint
 		panic(err)
 	}
 
+Example to Get an Endpoint
+
+	endpointID := "ad59deeec5154d1fa0dcff518596f499"
+
+	endpoint, err := endpoints.Get(identityClient, endpointID).Extract()
+	if err != nil {
+		panic(err)
+	}
 
 Example to Update an Endpoint
 
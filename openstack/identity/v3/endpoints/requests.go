@@ -42,7 +42,8 @@ func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r Create
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(listURL(client), &b, &r.Body, nil)
+	resp, err := client.Post(listURL(client), &b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -87,6 +88,13 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 	})
 }
 
+// Get retrieves details on a single endpoint, by ID.
+func Get(client *gophercloud.ServiceClient, endpointID string) (r GetResult) {
+	resp, err := client.Get(endpointURL(client, endpointID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
 // UpdateOptsBuilder allows extensions to add parameters to the Update request.
 type UpdateOptsBuilder interface {
 	ToEndpointUpdateMap() (map[string]interface{}, error)
@@ -125,12 +133,14 @@ func Update(client *gophercloud.ServiceClient, endpointID string, opts UpdateOpt
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Patch(endpointURL(client, endpointID), &b, &r.Body, nil)
+	resp, err := client.Patch(endpointURL(client, endpointID), &b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Delete removes an endpoint from the service catalog.
 func Delete(client *gophercloud.ServiceClient, endpointID string) (r DeleteResult) {
-	_, r.Err = client.Delete(endpointURL(client, endpointID), nil)
+	resp, err := client.Delete(endpointURL(client, endpointID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
@@ -25,6 +25,12 @@ type CreateResult struct {
 	commonResult
 }
 
+// GetResult is the response from a Get operation. Call its Extract method
+// to interpret it as an Endpoint.
+type GetResult struct {
+	commonResult
+}
+
 // UpdateResult is the response from an Update operation. Call its Extract
 // method to interpret it as an Endpoint.
 type UpdateResult struct {
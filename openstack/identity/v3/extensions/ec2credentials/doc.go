@@ -0,0 +1,51 @@
+/*
+Package ec2credentials provides information and interaction with the EC2
+credentials API resource for the OpenStack Identity service (OS-EC2).
+
+EC2 credentials are the access and secret keys a user authenticates to
+EC2-compatible APIs with, such as the S3 API of the Object Storage service.
+They are also stored as credentials of the "ec2" type, which the credentials
+package manages.
+
+Example to List EC2 Credentials
+
+	userID := "2844b2a08be147a08ef58317d6471f1f"
+
+	allPages, err := ec2credentials.List(identityClient, userID).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allCredentials, err := ec2credentials.ExtractCredentials(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, credential := range allCredentials {
+		fmt.Printf("%+v\n", credential)
+	}
+
+Example to Create an EC2 Credential
+
+	userID := "2844b2a08be147a08ef58317d6471f1f"
+
+	createOpts := ec2credentials.CreateOpts{
+		TenantID: "6238dee2fec940a6bf31e49e9faf995a",
+	}
+
+	credential, err := ec2credentials.Create(identityClient, userID, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete an EC2 Credential
+
+	userID := "2844b2a08be147a08ef58317d6471f1f"
+	access := "f741662395b249c9b8acdebf1722c5ae"
+
+	err := ec2credentials.Delete(identityClient, userID, access).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package ec2credentials
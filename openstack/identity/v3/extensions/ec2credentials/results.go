@@ -0,0 +1,96 @@
+package ec2credentials
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Credential represents the EC2 credential of a user, which is a pair of
+// access and secret keys for a project.
+type Credential struct {
+	// UserID is the ID of the user who owns the EC2 credential.
+	UserID string `json:"user_id"`
+
+	// TenantID is the ID of the project the EC2 credential was created for.
+	TenantID string `json:"tenant_id"`
+
+	// Access is the EC2 access key. It also identifies the credential.
+	Access string `json:"access"`
+
+	// Secret is the EC2 secret key.
+	Secret string `json:"secret"`
+
+	// TrustID is the ID of the trust the EC2 credential was created with,
+	// if any.
+	TrustID string `json:"trust_id"`
+
+	// Links contains referencing links to the EC2 credential.
+	Links map[string]interface{} `json:"links"`
+}
+
+type credentialResult struct {
+	gophercloud.Result
+}
+
+// GetResult is the response from a Get operation. Call its Extract method
+// to interpret it as a Credential.
+type GetResult struct {
+	credentialResult
+}
+
+// CreateResult is the response from a Create operation. Call its Extract
+// method to interpret it as a Credential.
+type CreateResult struct {
+	credentialResult
+}
+
+// DeleteResult is the response from a Delete operation. Call its ExtractErr
+// to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// CredentialPage is a single page of Credential results.
+type CredentialPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a CredentialPage contains any results.
+func (r CredentialPage) IsEmpty() (bool, error) {
+	credentials, err := ExtractCredentials(r)
+	return len(credentials) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r CredentialPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractCredentials returns a slice of Credentials contained in a single
+// page of results.
+func ExtractCredentials(r pagination.Page) ([]Credential, error) {
+	var s struct {
+		Credentials []Credential `json:"credentials"`
+	}
+	err := (r.(CredentialPage)).ExtractInto(&s)
+	return s.Credentials, err
+}
+
+// Extract interprets any credentialResult as a Credential.
+func (r credentialResult) Extract() (*Credential, error) {
+	var s struct {
+		Credential *Credential `json:"credential"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Credential, err
+}
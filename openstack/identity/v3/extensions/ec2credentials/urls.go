@@ -0,0 +1,21 @@
+package ec2credentials
+
+import "github.com/gophercloud/gophercloud"
+
+const ec2Path = "OS-EC2"
+
+func listURL(client *gophercloud.ServiceClient, userID string) string {
+	return client.ServiceURL("users", userID, "credentials", ec2Path)
+}
+
+func getURL(client *gophercloud.ServiceClient, userID, access string) string {
+	return client.ServiceURL("users", userID, "credentials", ec2Path, access)
+}
+
+func createURL(client *gophercloud.ServiceClient, userID string) string {
+	return client.ServiceURL("users", userID, "credentials", ec2Path)
+}
+
+func deleteURL(client *gophercloud.ServiceClient, userID, access string) string {
+	return client.ServiceURL("users", userID, "credentials", ec2Path, access)
+}
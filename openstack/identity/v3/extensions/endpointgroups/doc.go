@@ -0,0 +1,71 @@
+/*
+Package endpointgroups manages the endpoint groups of the OpenStack Identity
+service (OS-EP-FILTER).
+
+An endpoint group selects endpoints by their interface, service and region.
+Associating it with a project adds the selected endpoints to the catalog of
+the tokens scoped to the project. Individual endpoints are associated with
+projects by the projectendpoints package.
+
+Example to List Endpoint Groups
+
+	allPages, err := endpointgroups.List(identityClient, nil).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allEndpointGroups, err := endpointgroups.ExtractEndpointGroups(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, endpointGroup := range allEndpointGroups {
+		fmt.Printf("%+v\n", endpointGroup)
+	}
+
+Example to Create an Endpoint Group
+
+	createOpts := endpointgroups.CreateOpts{
+		Name:        "public compute",
+		Description: "The public endpoints of the compute service",
+		Filters: endpointgroups.Filters{
+			Availability: gophercloud.AvailabilityPublic,
+			ServiceID:    "1b501a",
+		},
+	}
+
+	endpointGroup, err := endpointgroups.Create(identityClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Associate an Endpoint Group with a Project
+
+	endpointGroupID := "ac4861"
+	projectID := "263fd9"
+
+	err := endpointgroups.AddProject(identityClient, endpointGroupID, projectID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the Endpoints of an Endpoint Group
+
+	allPages, err := endpointgroups.ListEndpoints(identityClient, "ac4861").AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allEndpoints, err := endpoints.ExtractEndpoints(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete an Endpoint Group
+
+	err := endpointgroups.Delete(identityClient, "ac4861").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package endpointgroups
@@ -0,0 +1,184 @@
+package endpointgroups
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/endpoints"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to
+// the List request
+type ListOptsBuilder interface {
+	ToEndpointGroupListQuery() (string, error)
+}
+
+// ListOpts provides options to filter the List results.
+type ListOpts struct {
+	// Name filters the response by an endpoint group name.
+	Name string `q:"name"`
+}
+
+// ToEndpointGroupListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToEndpointGroupListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List enumerates the endpoint groups.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(client)
+	if opts != nil {
+		query, err := opts.ToEndpointGroupListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return EndpointGroupPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves details on a single endpoint group, by ID.
+func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
+	resp, err := client.Get(resourceURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to
+// the Create request.
+type CreateOptsBuilder interface {
+	ToEndpointGroupCreateMap() (map[string]interface{}, error)
+}
+
+// CreateOpts provides options used to create an endpoint group.
+type CreateOpts struct {
+	// Name is the name of the endpoint group.
+	Name string `json:"name" required:"true"`
+
+	// Description is the description of the endpoint group.
+	Description string `json:"description,omitempty"`
+
+	// Filters selects the endpoints of the endpoint group.
+	Filters Filters `json:"filters" required:"true"`
+}
+
+// ToEndpointGroupCreateMap formats a CreateOpts into a create request.
+func (opts CreateOpts) ToEndpointGroupCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "endpoint_group")
+}
+
+// Create creates a new endpoint group.
+func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToEndpointGroupCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Post(rootURL(client), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to
+// the Update request.
+type UpdateOptsBuilder interface {
+	ToEndpointGroupUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateOpts provides options used to update an endpoint group.
+type UpdateOpts struct {
+	// Name is the name of the endpoint group.
+	Name string `json:"name,omitempty"`
+
+	// Description is the description of the endpoint group.
+	Description *string `json:"description,omitempty"`
+
+	// Filters replaces the filters of the endpoint group.
+	Filters *Filters `json:"filters,omitempty"`
+}
+
+// ToEndpointGroupUpdateMap formats an UpdateOpts into an update request.
+func (opts UpdateOpts) ToEndpointGroupUpdateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "endpoint_group")
+}
+
+// Update modifies the attributes of an endpoint group.
+func Update(client *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToEndpointGroupUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(resourceURL(client, id), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete deletes an endpoint group.
+func Delete(client *gophercloud.ServiceClient, id string) (r DeleteResult) {
+	resp, err := client.Delete(resourceURL(client, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListEndpoints enumerates the endpoints that match the filters of an
+// endpoint group. Use endpoints.ExtractEndpoints to interpret the pages.
+func ListEndpoints(client *gophercloud.ServiceClient, id string) pagination.Pager {
+	url := listEndpointsURL(client, id)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return endpoints.EndpointPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// ListProjects enumerates the projects an endpoint group is associated
+// with. Use projects.ExtractProjects to interpret the pages.
+func ListProjects(client *gophercloud.ServiceClient, id string) pagination.Pager {
+	url := listProjectsURL(client, id)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return projects.ProjectPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// AddProject associates an endpoint group with a project, which adds the
+// endpoints of the group to the catalog of the tokens scoped to the project.
+func AddProject(client *gophercloud.ServiceClient, id, projectID string) (r AddProjectResult) {
+	resp, err := client.Put(projectURL(client, id, projectID), nil, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CheckProject checks whether an endpoint group is associated with a
+// project. The result's ExtractErr returns a gophercloud.ErrDefault404 if it
+// isn't.
+func CheckProject(client *gophercloud.ServiceClient, id, projectID string) (r CheckProjectResult) {
+	resp, err := client.Head(projectURL(client, id, projectID), &gophercloud.RequestOpts{
+		OkCodes: []int{200, 204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// RemoveProject removes the association of an endpoint group with a project.
+func RemoveProject(client *gophercloud.ServiceClient, id, projectID string) (r RemoveProjectResult) {
+	resp, err := client.Delete(projectURL(client, id, projectID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListForProject enumerates the endpoint groups that are associated with a
+// project.
+func ListForProject(client *gophercloud.ServiceClient, projectID string) pagination.Pager {
+	url := listForProjectURL(client, projectID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return EndpointGroupPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
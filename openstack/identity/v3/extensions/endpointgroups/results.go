@@ -0,0 +1,132 @@
+package endpointgroups
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Filters selects the endpoints of an endpoint group. An endpoint belongs to
+// the group if it matches all of the filters that are set.
+type Filters struct {
+	// Availability is the interface type of the endpoints (admin, internal,
+	// or public).
+	Availability gophercloud.Availability `json:"interface,omitempty"`
+
+	// ServiceID is the ID of the service of the endpoints.
+	ServiceID string `json:"service_id,omitempty"`
+
+	// RegionID is the ID of the region of the endpoints.
+	RegionID string `json:"region_id,omitempty"`
+}
+
+// EndpointGroup is a set of endpoints, selected by filters, which can be
+// added to the catalog of the projects it is associated with.
+type EndpointGroup struct {
+	// ID is the unique ID of the endpoint group.
+	ID string `json:"id"`
+
+	// Name is the name of the endpoint group.
+	Name string `json:"name"`
+
+	// Description is the description of the endpoint group.
+	Description string `json:"description"`
+
+	// Filters selects the endpoints of the endpoint group.
+	Filters Filters `json:"filters"`
+
+	// Links contains referencing links to the endpoint group.
+	Links map[string]interface{} `json:"links"`
+}
+
+type endpointGroupResult struct {
+	gophercloud.Result
+}
+
+// GetResult is the response from a Get operation. Call its Extract method
+// to interpret it as an EndpointGroup.
+type GetResult struct {
+	endpointGroupResult
+}
+
+// CreateResult is the response from a Create operation. Call its Extract
+// method to interpret it as an EndpointGroup.
+type CreateResult struct {
+	endpointGroupResult
+}
+
+// UpdateResult is the response from an Update operation. Call its Extract
+// method to interpret it as an EndpointGroup.
+type UpdateResult struct {
+	endpointGroupResult
+}
+
+// DeleteResult is the response from a Delete operation. Call its ExtractErr
+// to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// AddProjectResult is the response from an AddProject operation. Call its
+// ExtractErr to determine if the request succeeded or failed.
+type AddProjectResult struct {
+	gophercloud.ErrResult
+}
+
+// CheckProjectResult is the response from a CheckProject operation. Call its
+// ExtractErr to determine if the endpoint group is associated with the
+// project.
+type CheckProjectResult struct {
+	gophercloud.ErrResult
+}
+
+// RemoveProjectResult is the response from a RemoveProject operation. Call
+// its ExtractErr to determine if the request succeeded or failed.
+type RemoveProjectResult struct {
+	gophercloud.ErrResult
+}
+
+// EndpointGroupPage is a single page of EndpointGroup results.
+type EndpointGroupPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not an EndpointGroupPage contains any
+// results.
+func (r EndpointGroupPage) IsEmpty() (bool, error) {
+	endpointGroups, err := ExtractEndpointGroups(r)
+	return len(endpointGroups) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r EndpointGroupPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractEndpointGroups returns a slice of EndpointGroups contained in a
+// single page of results.
+func ExtractEndpointGroups(r pagination.Page) ([]EndpointGroup, error) {
+	var s struct {
+		EndpointGroups []EndpointGroup `json:"endpoint_groups"`
+	}
+	err := (r.(EndpointGroupPage)).ExtractInto(&s)
+	return s.EndpointGroups, err
+}
+
+// Extract interprets any endpointGroupResult as an EndpointGroup.
+func (r endpointGroupResult) Extract() (*EndpointGroup, error) {
+	var s struct {
+		EndpointGroup *EndpointGroup `json:"endpoint_group"`
+	}
+	err := r.ExtractInto(&s)
+	return s.EndpointGroup, err
+}
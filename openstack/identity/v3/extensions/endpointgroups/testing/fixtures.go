@@ -0,0 +1,319 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/endpointgroups"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+// ListOutput provides a single page of EndpointGroup results.
+const ListOutput = `
+{
+  "endpoint_groups": [
+    {
+      "description": "The public endpoints of the compute service",
+      "filters": {
+        "interface": "public",
+        "service_id": "1b501a"
+      },
+      "id": "ac4861",
+      "links": {
+        "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861"
+      },
+      "name": "public compute"
+    },
+    {
+      "description": "",
+      "filters": {
+        "region_id": "RegionTwo"
+      },
+      "id": "3de68c",
+      "links": {
+        "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/3de68c"
+      },
+      "name": "region two"
+    }
+  ],
+  "links": {
+    "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups",
+    "previous": null,
+    "next": null
+  }
+}
+`
+
+// GetOutput provides a Get result.
+const GetOutput = `
+{
+  "endpoint_group": {
+    "description": "The public endpoints of the compute service",
+    "filters": {
+      "interface": "public",
+      "service_id": "1b501a"
+    },
+    "id": "ac4861",
+    "links": {
+      "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861"
+    },
+    "name": "public compute"
+  }
+}
+`
+
+// CreateRequest provides the input to a Create request.
+const CreateRequest = `
+{
+  "endpoint_group": {
+    "description": "The public endpoints of the compute service",
+    "filters": {
+      "interface": "public",
+      "service_id": "1b501a"
+    },
+    "name": "public compute"
+  }
+}
+`
+
+// UpdateRequest provides the input to an Update request.
+const UpdateRequest = `
+{
+  "endpoint_group": {
+    "filters": {
+      "interface": "internal",
+      "service_id": "1b501a"
+    }
+  }
+}
+`
+
+// UpdateOutput provides an Update result.
+const UpdateOutput = `
+{
+  "endpoint_group": {
+    "description": "The public endpoints of the compute service",
+    "filters": {
+      "interface": "internal",
+      "service_id": "1b501a"
+    },
+    "id": "ac4861",
+    "links": {
+      "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861"
+    },
+    "name": "public compute"
+  }
+}
+`
+
+// ListEndpointsOutput provides the endpoints of an endpoint group.
+const ListEndpointsOutput = `
+{
+  "endpoints": [
+    {
+      "id": "6fedc0",
+      "interface": "public",
+      "region": "RegionOne",
+      "service_id": "1b501a",
+      "url": "https://compute.example.com/v2.1"
+    }
+  ],
+  "links": {
+    "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861/endpoints",
+    "previous": null,
+    "next": null
+  }
+}
+`
+
+// ListProjectsOutput provides the projects of an endpoint group.
+const ListProjectsOutput = `
+{
+  "projects": [
+    {
+      "description": "The team that is red",
+      "domain_id": "default",
+      "enabled": true,
+      "id": "263fd9",
+      "is_domain": false,
+      "name": "Red Team",
+      "parent_id": "default"
+    }
+  ],
+  "links": {
+    "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861/projects",
+    "previous": null,
+    "next": null
+  }
+}
+`
+
+// PublicCompute is an EndpointGroup fixture.
+var PublicCompute = endpointgroups.EndpointGroup{
+	ID:          "ac4861",
+	Name:        "public compute",
+	Description: "The public endpoints of the compute service",
+	Filters: endpointgroups.Filters{
+		Availability: gophercloud.AvailabilityPublic,
+		ServiceID:    "1b501a",
+	},
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861",
+	},
+}
+
+// RegionTwo is an EndpointGroup fixture.
+var RegionTwo = endpointgroups.EndpointGroup{
+	ID:   "3de68c",
+	Name: "region two",
+	Filters: endpointgroups.Filters{
+		RegionID: "RegionTwo",
+	},
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/3de68c",
+	},
+}
+
+// UpdatedPublicCompute is an EndpointGroup fixture.
+var UpdatedPublicCompute = endpointgroups.EndpointGroup{
+	ID:          "ac4861",
+	Name:        "public compute",
+	Description: "The public endpoints of the compute service",
+	Filters: endpointgroups.Filters{
+		Availability: gophercloud.AvailabilityInternal,
+		ServiceID:    "1b501a",
+	},
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861",
+	},
+}
+
+// ExpectedEndpointGroupsSlice is the slice of endpoint groups expected to be
+// returned from ListOutput.
+var ExpectedEndpointGroupsSlice = []endpointgroups.EndpointGroup{PublicCompute, RegionTwo}
+
+// HandleListEndpointGroupsSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups` on the test handler mux that responds with
+// a list of two endpoint groups.
+func HandleListEndpointGroupsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListOutput)
+	})
+}
+
+// HandleGetEndpointGroupSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups/ac4861` on the test handler mux that
+// responds with a single endpoint group.
+func HandleGetEndpointGroupSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, GetOutput)
+	})
+}
+
+// HandleCreateEndpointGroupSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups` on the test handler mux that tests
+// endpoint group creation.
+func HandleCreateEndpointGroupSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, CreateRequest)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, GetOutput)
+	})
+}
+
+// HandleUpdateEndpointGroupSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups/ac4861` on the test handler mux that tests
+// endpoint group updates.
+func HandleUpdateEndpointGroupSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, UpdateRequest)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, UpdateOutput)
+	})
+}
+
+// HandleDeleteEndpointGroupSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups/ac4861` on the test handler mux that tests
+// endpoint group deletion.
+func HandleDeleteEndpointGroupSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleEndpointGroupAssociationsSuccessfully creates HTTP handlers on the
+// test handler mux that list the endpoints and projects of an endpoint
+// group, and that add, check and remove its association with project
+// 263fd9. Checking any other project results in a 404.
+func HandleEndpointGroupAssociationsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListEndpointsOutput)
+	})
+
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861/projects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListProjectsOutput)
+	})
+
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861/projects/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		if r.URL.Path != "/OS-EP-FILTER/endpoint_groups/ac4861/projects/263fd9" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case "PUT", "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		case "HEAD":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/OS-EP-FILTER/projects/263fd9/endpoint_groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListOutput)
+	})
+}
@@ -0,0 +1,133 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/endpoints"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/endpointgroups"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestListEndpointGroups(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListEndpointGroupsSuccessfully(t)
+
+	count := 0
+	err := endpointgroups.List(client.ServiceClient(), nil).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := endpointgroups.ExtractEndpointGroups(page)
+		th.AssertNoErr(t, err)
+
+		th.CheckDeepEquals(t, ExpectedEndpointGroupsSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestGetEndpointGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetEndpointGroupSuccessfully(t)
+
+	actual, err := endpointgroups.Get(client.ServiceClient(), "ac4861").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, PublicCompute, *actual)
+}
+
+func TestCreateEndpointGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateEndpointGroupSuccessfully(t)
+
+	createOpts := endpointgroups.CreateOpts{
+		Name:        "public compute",
+		Description: "The public endpoints of the compute service",
+		Filters: endpointgroups.Filters{
+			Availability: gophercloud.AvailabilityPublic,
+			ServiceID:    "1b501a",
+		},
+	}
+
+	actual, err := endpointgroups.Create(client.ServiceClient(), createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, PublicCompute, *actual)
+}
+
+func TestUpdateEndpointGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUpdateEndpointGroupSuccessfully(t)
+
+	updateOpts := endpointgroups.UpdateOpts{
+		Filters: &endpointgroups.Filters{
+			Availability: gophercloud.AvailabilityInternal,
+			ServiceID:    "1b501a",
+		},
+	}
+
+	actual, err := endpointgroups.Update(client.ServiceClient(), "ac4861", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, UpdatedPublicCompute, *actual)
+}
+
+func TestDeleteEndpointGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteEndpointGroupSuccessfully(t)
+
+	res := endpointgroups.Delete(client.ServiceClient(), "ac4861")
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestEndpointGroupAssociations(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleEndpointGroupAssociationsSuccessfully(t)
+
+	allPages, err := endpointgroups.ListEndpoints(client.ServiceClient(), "ac4861").AllPages()
+	th.AssertNoErr(t, err)
+	allEndpoints, err := endpoints.ExtractEndpoints(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []endpoints.Endpoint{{
+		ID:           "6fedc0",
+		Availability: gophercloud.AvailabilityPublic,
+		Region:       "RegionOne",
+		ServiceID:    "1b501a",
+		URL:          "https://compute.example.com/v2.1",
+	}}, allEndpoints)
+
+	allPages, err = endpointgroups.ListProjects(client.ServiceClient(), "ac4861").AllPages()
+	th.AssertNoErr(t, err)
+	allProjects, err := projects.ExtractProjects(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(allProjects))
+	th.AssertEquals(t, "263fd9", allProjects[0].ID)
+
+	err = endpointgroups.AddProject(client.ServiceClient(), "ac4861", "263fd9").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = endpointgroups.CheckProject(client.ServiceClient(), "ac4861", "263fd9").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = endpointgroups.CheckProject(client.ServiceClient(), "ac4861", "9876").ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("Expected ErrDefault404, got %v", err)
+	}
+
+	err = endpointgroups.RemoveProject(client.ServiceClient(), "ac4861", "263fd9").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	allPages, err = endpointgroups.ListForProject(client.ServiceClient(), "263fd9").AllPages()
+	th.AssertNoErr(t, err)
+	allEndpointGroups, err := endpointgroups.ExtractEndpointGroups(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedEndpointGroupsSlice, allEndpointGroups)
+}
@@ -0,0 +1,32 @@
+package endpointgroups
+
+import "github.com/gophercloud/gophercloud"
+
+const (
+	extensionPath = "OS-EP-FILTER"
+	resourcePath  = "endpoint_groups"
+)
+
+func rootURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL(extensionPath, resourcePath)
+}
+
+func resourceURL(client *gophercloud.ServiceClient, endpointGroupID string) string {
+	return client.ServiceURL(extensionPath, resourcePath, endpointGroupID)
+}
+
+func listEndpointsURL(client *gophercloud.ServiceClient, endpointGroupID string) string {
+	return client.ServiceURL(extensionPath, resourcePath, endpointGroupID, "endpoints")
+}
+
+func listProjectsURL(client *gophercloud.ServiceClient, endpointGroupID string) string {
+	return client.ServiceURL(extensionPath, resourcePath, endpointGroupID, "projects")
+}
+
+func projectURL(client *gophercloud.ServiceClient, endpointGroupID, projectID string) string {
+	return client.ServiceURL(extensionPath, resourcePath, endpointGroupID, "projects", projectID)
+}
+
+func listForProjectURL(client *gophercloud.ServiceClient, projectID string) string {
+	return client.ServiceURL(extensionPath, "projects", projectID, resourcePath)
+}
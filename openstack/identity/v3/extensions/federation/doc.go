@@ -0,0 +1,159 @@
+/*
+Package federation enables authentication with federated identities of the
+OpenStack Identity service (OS-FEDERATION).
+
+A federated identity is exchanged for an unscoped token at
+/v3/OS-FEDERATION/identity_providers/{idp}/protocols/{protocol}/auth, which
+Authenticate then rescopes to a project or domain. The exchange is performed
+by a Method: OIDCAccessToken, OIDCPassword, SAML2ECP or Keystone2Keystone.
+The projects and domains the unscoped token can be rescoped to are listed by
+projects.ListAvailable and domains.ListAvailable.
+
+The package also manages the identity providers, their protocols, the
+mappings and the Keystone-to-Keystone service providers.
+
+Example to Authenticate with an OpenID Connect Password Grant
+
+	provider, err := openstack.NewClient("https://keystone.example.com:5000/v3")
+	if err != nil {
+		panic(err)
+	}
+
+	authOptions := federation.AuthOptions{
+		Method: federation.OIDCPassword{
+			IdentityProvider:  "myidp",
+			ClientID:          "client_id",
+			ClientSecret:      "client_secret",
+			DiscoveryEndpoint: "https://idp.example.com/.well-known/openid-configuration",
+			Username:          "username",
+			Password:          "password",
+		},
+		Scope:       tokens.Scope{ProjectID: "project_id"},
+		AllowReauth: true,
+	}
+
+	err = federation.Authenticate(provider, authOptions)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authenticate with SAML2 ECP
+
+	authOptions := federation.AuthOptions{
+		Method: federation.SAML2ECP{
+			IdentityProvider:    "myidp",
+			IdentityProviderURL: "https://idp.example.com/idp/profile/SAML2/SOAP/ECP",
+			Username:            "username",
+			Password:            "password",
+		},
+		Scope: tokens.Scope{ProjectName: "project_name", DomainID: "default"},
+	}
+
+	err = federation.Authenticate(provider, authOptions)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authenticate at a Keystone Service Provider
+
+	// localIdentity is an authenticated identity v3 client of the local
+	// cloud, which acts as the identity provider.
+	remote, err := openstack.NewClient("https://keystone.sp.example.com:5000/v3")
+	if err != nil {
+		panic(err)
+	}
+
+	authOptions := federation.AuthOptions{
+		Method: federation.Keystone2Keystone{
+			LocalClient:       localIdentity,
+			ServiceProviderID: "mysp",
+		},
+		Scope: tokens.Scope{ProjectID: "remote_project_id"},
+	}
+
+	err = federation.Authenticate(remote, authOptions)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Obtain an Unscoped Token
+
+	identityClient, err := openstack.NewIdentityV3(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+	method := federation.OIDCAccessToken{
+		IdentityProvider: "myidp",
+		AccessToken:      "access_token",
+	}
+
+	token, err := method.UnscopedToken(identityClient).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Register an Identity Provider
+
+	enabled := true
+	createOpts := federation.CreateIdentityProviderOpts{
+		DomainID:  "domain_id",
+		Enabled:   &enabled,
+		RemoteIDs: []string{"https://idp.example.com/idp/shibboleth"},
+	}
+
+	idp, err := federation.CreateIdentityProvider(identityClient, "myidp", createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create a Mapping
+
+	mappingOpts := federation.MappingOpts{
+		Rules: []federation.MappingRule{
+			{
+				Local: []federation.RuleLocal{
+					{User: &federation.RuleUser{Name: "{0}"}},
+					{Group: &federation.Group{ID: "group_id"}},
+				},
+				Remote: []federation.RuleRemote{
+					{Type: "REMOTE_USER"},
+					{Type: "orgPersonType", AnyOneOf: []string{"Employee"}},
+				},
+			},
+		},
+	}
+
+	mapping, err := federation.CreateMapping(identityClient, "myidp_mapping", mappingOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Add a Protocol to an Identity Provider
+
+	protocolOpts := federation.ProtocolOpts{
+		MappingID: "myidp_mapping",
+	}
+
+	protocol, err := federation.CreateProtocol(identityClient, "myidp", "saml2", protocolOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List Service Providers
+
+	allPages, err := federation.ListServiceProviders(identityClient, nil).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allServiceProviders, err := federation.ExtractServiceProviders(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, sp := range allServiceProviders {
+		fmt.Printf("%+v\n", sp)
+	}
+*/
+package federation
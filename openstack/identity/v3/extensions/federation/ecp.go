@@ -0,0 +1,159 @@
+package federation
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+)
+
+const (
+	soapNS = "http://schemas.xmlsoap.org/soap/envelope/"
+	paosNS = "urn:liberty:paos:2003-08"
+	ecpNS  = "urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp"
+
+	paosContentType = "application/vnd.paos+xml"
+	paosHeader      = `ver="` + paosNS + `";"` + ecpNS + `"`
+)
+
+// soapEnvelope is a SOAP message of the SAML2 ECP profile. Only the header
+// blocks that are needed to relay the message are decoded; the body is kept
+// as is.
+type soapEnvelope struct {
+	Attrs []xml.Attr `xml:",any,attr"`
+
+	Header struct {
+		PAOSRequest *struct {
+			ResponseConsumerURL string `xml:"responseConsumerURL,attr"`
+		} `xml:"urn:liberty:paos:2003-08 Request"`
+
+		ECPResponse *struct {
+			AssertionConsumerServiceURL string `xml:"AssertionConsumerServiceURL,attr"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp Response"`
+
+		RelayState *struct {
+			Value string `xml:",chardata"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp RelayState"`
+	} `xml:"http://schemas.xmlsoap.org/soap/envelope/ Header"`
+
+	Body struct {
+		Inner []byte `xml:",innerxml"`
+	} `xml:"http://schemas.xmlsoap.org/soap/envelope/ Body"`
+}
+
+func parseEnvelope(b []byte) (*soapEnvelope, error) {
+	var e soapEnvelope
+	if err := xml.Unmarshal(b, &e); err != nil {
+		return nil, ErrInvalidECPMessage{Reason: err.Error()}
+	}
+	if len(bytes.TrimSpace(e.Body.Inner)) == 0 {
+		return nil, ErrInvalidECPMessage{Reason: "the SOAP body is empty"}
+	}
+	return &e, nil
+}
+
+// build returns a new envelope with the body of e and the given raw header
+// blocks. The namespace declarations of e are carried over, as the body may
+// depend on them.
+func (e *soapEnvelope) build(header string) []byte {
+	var b bytes.Buffer
+	b.WriteString(`<S:Envelope xmlns:S="` + soapNS + `"`)
+	for _, a := range e.Attrs {
+		switch {
+		case a.Name.Space == "xmlns" && a.Name.Local != "S":
+			b.WriteString(" xmlns:" + a.Name.Local + `="`)
+		case a.Name.Space == "" && a.Name.Local == "xmlns":
+			b.WriteString(` xmlns="`)
+		default:
+			continue
+		}
+		xml.EscapeText(&b, []byte(a.Value))
+		b.WriteString(`"`)
+	}
+	b.WriteString(">")
+	if header != "" {
+		b.WriteString("<S:Header>" + header + "</S:Header>")
+	}
+	b.WriteString("<S:Body>")
+	b.Write(e.Body.Inner)
+	b.WriteString("</S:Body></S:Envelope>")
+	return b.Bytes()
+}
+
+// relayStateHeader returns the ecp:RelayState header block that the service
+// provider sent, so that it can be returned to it along with the assertion.
+func (e *soapEnvelope) relayStateHeader() string {
+	if e.Header.RelayState == nil {
+		return ""
+	}
+	var b bytes.Buffer
+	b.WriteString(`<ecp:RelayState xmlns:ecp="` + ecpNS + `" S:mustUnderstand="1" S:actor="http://schemas.xmlsoap.org/soap/actor/next">`)
+	xml.EscapeText(&b, []byte(e.Header.RelayState.Value))
+	b.WriteString("</ecp:RelayState>")
+	return b.String()
+}
+
+// newSessionClient returns an unauthenticated ProviderClient that shares the
+// transport and settings of pc. It keeps the cookies it receives and doesn't
+// follow redirects, as the SAML2 ECP flows require.
+func newSessionClient(pc *gophercloud.ProviderClient) *gophercloud.ProviderClient {
+	jar, _ := cookiejar.New(nil)
+	httpClient := pc.HTTPClient
+	httpClient.Jar = jar
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &gophercloud.ProviderClient{
+		IdentityBase:     pc.IdentityBase,
+		IdentityEndpoint: pc.IdentityEndpoint,
+		HTTPClient:       httpClient,
+		UserAgent:        pc.UserAgent,
+		Context:          pc.Context,
+		RetryPolicy:      pc.RetryPolicy,
+		Logger:           pc.Logger,
+		LogBodies:        pc.LogBodies,
+	}
+}
+
+// sendXML issues a request with an XML body and returns the response body.
+func sendXML(pc *gophercloud.ProviderClient, method, url string, body []byte, headers map[string]string, okCodes []int) ([]byte, error) {
+	opts := &gophercloud.RequestOpts{
+		MoreHeaders: headers,
+		OkCodes:     okCodes,
+	}
+	if body != nil {
+		opts.RawBody = bytes.NewReader(body)
+	}
+	resp, err := pc.Request(method, url, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
+
+// sendAssertion delivers an ECP-wrapped SAML2 assertion to the service
+// provider's assertion consumer at consumerURL, and then exchanges the
+// resulting session for an unscoped token at authURL.
+func sendAssertion(pc *gophercloud.ProviderClient, consumerURL string, assertion []byte, authURL string) (r tokens.CreateResult) {
+	_, err := sendXML(pc, "POST", consumerURL, assertion, map[string]string{
+		"Content-Type": paosContentType,
+		"Accept":       "",
+	}, []int{200, 201, 302, 303})
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	resp, err := pc.Request("GET", authURL, &gophercloud.RequestOpts{
+		JSONResponse: &r.Body,
+		OkCodes:      []int{200, 201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
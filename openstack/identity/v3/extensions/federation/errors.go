@@ -0,0 +1,45 @@
+package federation
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrServiceProviderNotFound is returned by Keystone2Keystone when the local
+// token doesn't list the requested service provider.
+type ErrServiceProviderNotFound struct {
+	gophercloud.BaseError
+	ID string
+}
+
+func (e ErrServiceProviderNotFound) Error() string {
+	return fmt.Sprintf("Service provider %q is not listed in the token", e.ID)
+}
+
+// ErrConsumerURLMismatch is returned by SAML2ECP when the identity provider
+// wants its assertion delivered to another URL than the one the service
+// provider asked for. The assertion is not sent in that case, as it could
+// otherwise be handed to a third party.
+type ErrConsumerURLMismatch struct {
+	gophercloud.BaseError
+	ResponseConsumerURL         string
+	AssertionConsumerServiceURL string
+}
+
+func (e ErrConsumerURLMismatch) Error() string {
+	return fmt.Sprintf(
+		"The identity provider's assertion consumer URL %q doesn't match the service provider's response consumer URL %q",
+		e.AssertionConsumerServiceURL, e.ResponseConsumerURL)
+}
+
+// ErrInvalidECPMessage is returned when a SAML2 ECP message can't be parsed
+// or lacks a required element.
+type ErrInvalidECPMessage struct {
+	gophercloud.BaseError
+	Reason string
+}
+
+func (e ErrInvalidECPMessage) Error() string {
+	return fmt.Sprintf("Invalid SAML2 ECP message: %s", e.Reason)
+}
@@ -0,0 +1,894 @@
+package federation
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Method obtains an unscoped token for a federated identity.
+type Method interface {
+	// UnscopedToken exchanges the method's credentials for an unscoped
+	// token of the identity service c. c should not be authenticated.
+	UnscopedToken(c *gophercloud.ServiceClient) tokens.CreateResult
+}
+
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// OIDCAccessToken authenticates with an OpenID Connect access token that
+// the user obtained from the identity provider.
+type OIDCAccessToken struct {
+	// IdentityProvider is the ID of the identity provider in Keystone.
+	IdentityProvider string
+
+	// Protocol is the ID of the federation protocol in Keystone. It
+	// defaults to "openid".
+	Protocol string
+
+	// AccessToken is the access token issued by the identity provider.
+	AccessToken string
+}
+
+// UnscopedToken implements Method.
+func (m OIDCAccessToken) UnscopedToken(c *gophercloud.ServiceClient) (r tokens.CreateResult) {
+	if m.IdentityProvider == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "IdentityProvider"}
+		return
+	}
+	if m.AccessToken == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "AccessToken"}
+		return
+	}
+	protocol := m.Protocol
+	if protocol == "" {
+		protocol = "openid"
+	}
+
+	resp, err := c.Post(federatedAuthURL(c, m.IdentityProvider, protocol), nil, &r.Body, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"Authorization": "Bearer " + m.AccessToken},
+		OkCodes:     []int{200, 201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// OIDCPassword authenticates with the OpenID Connect resource owner password
+// credentials grant: the user's username and password are exchanged for an
+// access token at the identity provider, which is then exchanged for a
+// Keystone token.
+type OIDCPassword struct {
+	// IdentityProvider is the ID of the identity provider in Keystone.
+	IdentityProvider string
+
+	// Protocol is the ID of the federation protocol in Keystone. It
+	// defaults to "openid".
+	Protocol string
+
+	// ClientID and ClientSecret are the OAuth 2.0 credentials of the client
+	// registered with the identity provider.
+	ClientID     string
+	ClientSecret string
+
+	// TokenEndpoint is the URL of the identity provider's token endpoint.
+	// If it is empty, it is looked up in the provider's metadata at
+	// DiscoveryEndpoint, which is usually its
+	// ".well-known/openid-configuration" URL.
+	TokenEndpoint     string
+	DiscoveryEndpoint string
+
+	Username string
+	Password string
+
+	// Scope is the space separated list of OpenID Connect scopes to request.
+	// It defaults to "openid".
+	Scope string
+
+	// AccessTokenType names the field of the identity provider's response
+	// that holds the token to present to Keystone. It defaults to
+	// "access_token"; some deployments expect "id_token".
+	AccessTokenType string
+}
+
+// UnscopedToken implements Method.
+func (m OIDCPassword) UnscopedToken(c *gophercloud.ServiceClient) (r tokens.CreateResult) {
+	accessToken, err := m.accessToken(c.ProviderClient)
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	return OIDCAccessToken{
+		IdentityProvider: m.IdentityProvider,
+		Protocol:         m.Protocol,
+		AccessToken:      accessToken,
+	}.UnscopedToken(c)
+}
+
+// accessToken obtains an access token from the identity provider.
+func (m OIDCPassword) accessToken(pc *gophercloud.ProviderClient) (string, error) {
+	if m.ClientID == "" {
+		return "", gophercloud.ErrMissingInput{Argument: "ClientID"}
+	}
+	if m.Username == "" {
+		return "", gophercloud.ErrMissingInput{Argument: "Username"}
+	}
+	if m.Password == "" {
+		return "", gophercloud.ErrMissingPassword{}
+	}
+
+	endpoint := m.TokenEndpoint
+	if endpoint == "" {
+		if m.DiscoveryEndpoint == "" {
+			return "", gophercloud.ErrMissingInput{Argument: "TokenEndpoint"}
+		}
+		var discovery struct {
+			TokenEndpoint string `json:"token_endpoint"`
+		}
+		_, err := pc.Request("GET", m.DiscoveryEndpoint, &gophercloud.RequestOpts{
+			JSONResponse: &discovery,
+			OkCodes:      []int{200},
+		})
+		if err != nil {
+			return "", err
+		}
+		if discovery.TokenEndpoint == "" {
+			return "", gophercloud.ErrMissingInput{Argument: "token_endpoint"}
+		}
+		endpoint = discovery.TokenEndpoint
+	}
+
+	scope := m.Scope
+	if scope == "" {
+		scope = "openid"
+	}
+	form := url.Values{
+		"grant_type": {"password"},
+		"username":   {m.Username},
+		"password":   {m.Password},
+		"scope":      {scope},
+	}
+
+	var body map[string]interface{}
+	_, err := pc.Request("POST", endpoint, &gophercloud.RequestOpts{
+		RawBody:      strings.NewReader(form.Encode()),
+		JSONResponse: &body,
+		OkCodes:      []int{200},
+		MoreHeaders: map[string]string{
+			"Content-Type":  "application/x-www-form-urlencoded",
+			"Authorization": basicAuth(m.ClientID, m.ClientSecret),
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	tokenType := m.AccessTokenType
+	if tokenType == "" {
+		tokenType = "access_token"
+	}
+	token, _ := body[tokenType].(string)
+	if token == "" {
+		return "", gophercloud.ErrMissingInput{Argument: tokenType}
+	}
+	return token, nil
+}
+
+// SAML2ECP authenticates with a username and password at a SAML2 identity
+// provider, using the Enhanced Client or Proxy profile.
+type SAML2ECP struct {
+	// IdentityProvider is the ID of the identity provider in Keystone.
+	IdentityProvider string
+
+	// Protocol is the ID of the federation protocol in Keystone. It
+	// defaults to "saml2".
+	Protocol string
+
+	// IdentityProviderURL is the ECP endpoint of the identity provider,
+	// such as https://idp.example.com/idp/profile/SAML2/SOAP/ECP.
+	IdentityProviderURL string
+
+	Username string
+	Password string
+}
+
+// UnscopedToken implements Method.
+func (m SAML2ECP) UnscopedToken(c *gophercloud.ServiceClient) (r tokens.CreateResult) {
+	if m.IdentityProvider == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "IdentityProvider"}
+		return
+	}
+	if m.IdentityProviderURL == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "IdentityProviderURL"}
+		return
+	}
+	protocol := m.Protocol
+	if protocol == "" {
+		protocol = "saml2"
+	}
+	authURL := federatedAuthURL(c, m.IdentityProvider, protocol)
+	pc := newSessionClient(c.ProviderClient)
+
+	// Keystone's service provider answers with an authentication request
+	// for the identity provider.
+	b, err := sendXML(pc, "GET", authURL, nil, map[string]string{
+		"Accept": "text/html, " + paosContentType,
+		"PAOS":   paosHeader,
+	}, []int{200})
+	if err != nil {
+		r.Err = err
+		return
+	}
+	authnRequest, err := parseEnvelope(b)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	if authnRequest.Header.PAOSRequest == nil || authnRequest.Header.PAOSRequest.ResponseConsumerURL == "" {
+		r.Err = ErrInvalidECPMessage{Reason: "the authentication request has no paos:Request responseConsumerURL"}
+		return
+	}
+
+	// The identity provider authenticates the user and answers with the
+	// assertion.
+	b, err = sendXML(pc, "POST", m.IdentityProviderURL, authnRequest.build(""), map[string]string{
+		"Content-Type":  "text/xml; charset=utf-8",
+		"Accept":        "",
+		"Authorization": basicAuth(m.Username, m.Password),
+	}, []int{200})
+	if err != nil {
+		r.Err = err
+		return
+	}
+	assertion, err := parseEnvelope(b)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	if assertion.Header.ECPResponse == nil || assertion.Header.ECPResponse.AssertionConsumerServiceURL == "" {
+		r.Err = ErrInvalidECPMessage{Reason: "the assertion has no ecp:Response AssertionConsumerServiceURL"}
+		return
+	}
+
+	consumerURL := authnRequest.Header.PAOSRequest.ResponseConsumerURL
+	if consumerURL != assertion.Header.ECPResponse.AssertionConsumerServiceURL {
+		r.Err = ErrConsumerURLMismatch{
+			ResponseConsumerURL:         consumerURL,
+			AssertionConsumerServiceURL: assertion.Header.ECPResponse.AssertionConsumerServiceURL,
+		}
+		return
+	}
+
+	return sendAssertion(pc, consumerURL, assertion.build(authnRequest.relayStateHeader()), authURL)
+}
+
+// Keystone2Keystone authenticates at a Keystone service provider with a
+// SAML2 assertion issued by the local Keystone, which acts as the identity
+// provider.
+type Keystone2Keystone struct {
+	// LocalClient is an authenticated identity v3 client of the local
+	// Keystone.
+	LocalClient *gophercloud.ServiceClient
+
+	// ServiceProviderID is the ID of the service provider in the local
+	// Keystone.
+	ServiceProviderID string
+
+	// ServiceProviderURL and ServiceProviderAuthURL are the service
+	// provider's sp_url and auth_url. If either is empty, they are looked
+	// up in the local token.
+	ServiceProviderURL     string
+	ServiceProviderAuthURL string
+}
+
+// UnscopedToken implements Method. c is a client of the service provider's
+// identity service.
+func (m Keystone2Keystone) UnscopedToken(c *gophercloud.ServiceClient) (r tokens.CreateResult) {
+	if m.LocalClient == nil {
+		r.Err = gophercloud.ErrMissingInput{Argument: "LocalClient"}
+		return
+	}
+	if m.ServiceProviderID == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "ServiceProviderID"}
+		return
+	}
+
+	sp := ServiceProvider{
+		ID:      m.ServiceProviderID,
+		SPURL:   m.ServiceProviderURL,
+		AuthURL: m.ServiceProviderAuthURL,
+	}
+	if sp.SPURL == "" || sp.AuthURL == "" {
+		var err error
+		sp, err = m.serviceProvider()
+		if err != nil {
+			r.Err = err
+			return
+		}
+	}
+
+	assertion, err := m.assertion()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	return sendAssertion(newSessionClient(c.ProviderClient), sp.SPURL, assertion, sp.AuthURL)
+}
+
+// serviceProvider looks up the service provider in the local token.
+func (m Keystone2Keystone) serviceProvider() (ServiceProvider, error) {
+	var s struct {
+		TokenExt
+	}
+	err := tokens.Get(m.LocalClient, m.LocalClient.Token()).ExtractInto(&s)
+	if err != nil {
+		return ServiceProvider{}, err
+	}
+	for _, sp := range s.ServiceProviders {
+		if sp.ID == m.ServiceProviderID {
+			if m.ServiceProviderURL != "" {
+				sp.SPURL = m.ServiceProviderURL
+			}
+			if m.ServiceProviderAuthURL != "" {
+				sp.AuthURL = m.ServiceProviderAuthURL
+			}
+			return sp, nil
+		}
+	}
+	return ServiceProvider{}, ErrServiceProviderNotFound{ID: m.ServiceProviderID}
+}
+
+// assertion requests an ECP-wrapped SAML2 assertion for the service provider
+// from the local Keystone.
+func (m Keystone2Keystone) assertion() ([]byte, error) {
+	b := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"token"},
+				"token": map[string]interface{}{
+					"id": m.LocalClient.Token(),
+				},
+			},
+			"scope": map[string]interface{}{
+				"service_provider": map[string]interface{}{
+					"id": m.ServiceProviderID,
+				},
+			},
+		},
+	}
+
+	resp, err := m.LocalClient.Post(ecpAssertionURL(m.LocalClient), b, nil, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"Accept": ""},
+		OkCodes:     []int{200, 201},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
+
+// AuthOptions configures a federated authentication.
+type AuthOptions struct {
+	// Method obtains the unscoped token.
+	Method Method
+
+	// Scope is the project or domain to rescope the unscoped token to. If
+	// it is empty, the client uses the unscoped token.
+	Scope tokens.Scope
+
+	// AllowReauth allows Gophercloud to repeat the whole flow when the
+	// token expires. The Method must then hold credentials that can be
+	// reused; an OIDCAccessToken, for example, may have expired as well.
+	AllowReauth bool
+}
+
+// Authenticate obtains a token for client with a federated identity and
+// rescopes it according to opts.Scope. client must have been created with
+// the identity endpoint of the Keystone that acts as the service provider,
+// for example with openstack.NewClient.
+func Authenticate(client *gophercloud.ProviderClient, opts AuthOptions) error {
+	if opts.Method == nil {
+		return gophercloud.ErrMissingInput{Argument: "Method"}
+	}
+
+	v3Client, err := openstack.NewIdentityV3(client, gophercloud.EndpointOpts{})
+	if err != nil {
+		return err
+	}
+
+	// The client's own token, if any, mustn't be sent along, and a failure
+	// mustn't trigger its ReauthFunc.
+	session := &gophercloud.ServiceClient{
+		ProviderClient: newSessionClient(client),
+		Endpoint:       v3Client.Endpoint,
+		Type:           v3Client.Type,
+	}
+
+	result := opts.Method.UnscopedToken(session)
+	token, err := result.ExtractToken()
+	if err != nil {
+		return err
+	}
+
+	if opts.Scope == (tokens.Scope{}) {
+		catalog, err := result.ExtractServiceCatalog()
+		if err != nil {
+			return err
+		}
+		client.TokenID = token.ID
+		client.TokenExpiresAt = token.ExpiresAt
+		client.EndpointLocator = func(eo gophercloud.EndpointOpts) (string, error) {
+			return openstack.V3EndpointURL(catalog, eo)
+		}
+	} else {
+		err = openstack.AuthenticateV3(client, &tokens.AuthOptions{
+			TokenID: token.ID,
+			Scope:   opts.Scope,
+		}, gophercloud.EndpointOpts{})
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.AllowReauth {
+		// here we're creating a throw-away client (tac). it's a copy of the user's provider client, but
+		// with the token and reauth func zeroed out. combined with setting `AllowReauth` to `false`,
+		// this should retry authentication only once
+		tac := *client
+		tac.ReauthFunc = nil
+		tac.TokenID = ""
+		tac.TokenExpiresAt = time.Time{}
+		tao := opts
+		tao.AllowReauth = false
+		client.ReauthFunc = func() error {
+			err := Authenticate(&tac, tao)
+			if err != nil {
+				return err
+			}
+			client.TokenID = tac.TokenID
+			client.TokenExpiresAt = tac.TokenExpiresAt
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// ListIdentityProvidersOptsBuilder allows extensions to add additional
+// parameters to the ListIdentityProviders request.
+type ListIdentityProvidersOptsBuilder interface {
+	ToIdentityProviderListQuery() (string, error)
+}
+
+// ListIdentityProvidersOpts provides options to filter the
+// ListIdentityProviders results.
+type ListIdentityProvidersOpts struct {
+	// ID filters the response by identity provider ID.
+	ID string `q:"id"`
+
+	// Enabled filters the response by enabled identity providers.
+	Enabled *bool `q:"enabled"`
+}
+
+// ToIdentityProviderListQuery formats a ListIdentityProvidersOpts into a
+// query string.
+func (opts ListIdentityProvidersOpts) ToIdentityProviderListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// ListIdentityProviders enumerates the identity providers.
+func ListIdentityProviders(client *gophercloud.ServiceClient, opts ListIdentityProvidersOptsBuilder) pagination.Pager {
+	url := identityProvidersURL(client)
+	if opts != nil {
+		query, err := opts.ToIdentityProviderListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return IdentityProviderPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// GetIdentityProvider retrieves details on a single identity provider, by
+// ID.
+func GetIdentityProvider(client *gophercloud.ServiceClient, idpID string) (r GetIdentityProviderResult) {
+	resp, err := client.Get(identityProviderURL(client, idpID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateIdentityProviderOptsBuilder allows extensions to add additional
+// parameters to the CreateIdentityProvider request.
+type CreateIdentityProviderOptsBuilder interface {
+	ToIdentityProviderCreateMap() (map[string]interface{}, error)
+}
+
+// CreateIdentityProviderOpts provides options used to create an identity
+// provider.
+type CreateIdentityProviderOpts struct {
+	// DomainID is the domain that the identity provider's users are created
+	// in. If it is omitted, Keystone creates a domain for them.
+	DomainID string `json:"domain_id,omitempty"`
+
+	// Description is a description of the identity provider.
+	Description string `json:"description,omitempty"`
+
+	// Enabled sets the identity provider status to enabled or disabled.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RemoteIDs are the entity IDs by which the identity provider identifies
+	// itself, such as the issuer of an OpenID Connect provider.
+	RemoteIDs []string `json:"remote_ids,omitempty"`
+
+	// AuthorizationTTL is the number of minutes for which the group
+	// memberships of the identity provider's users are kept after they
+	// authenticated.
+	AuthorizationTTL *int `json:"authorization_ttl,omitempty"`
+}
+
+// ToIdentityProviderCreateMap formats a CreateIdentityProviderOpts into a
+// create request.
+func (opts CreateIdentityProviderOpts) ToIdentityProviderCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "identity_provider")
+}
+
+// CreateIdentityProvider registers an identity provider with the given ID.
+func CreateIdentityProvider(client *gophercloud.ServiceClient, idpID string, opts CreateIdentityProviderOptsBuilder) (r CreateIdentityProviderResult) {
+	b, err := opts.ToIdentityProviderCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(identityProviderURL(client, idpID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateIdentityProviderOptsBuilder allows extensions to add additional
+// parameters to the UpdateIdentityProvider request.
+type UpdateIdentityProviderOptsBuilder interface {
+	ToIdentityProviderUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateIdentityProviderOpts represents parameters to update an identity
+// provider.
+type UpdateIdentityProviderOpts struct {
+	// Description is a description of the identity provider.
+	Description *string `json:"description,omitempty"`
+
+	// Enabled sets the identity provider status to enabled or disabled.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RemoteIDs replaces the entity IDs of the identity provider.
+	RemoteIDs *[]string `json:"remote_ids,omitempty"`
+
+	// AuthorizationTTL is the number of minutes for which group memberships
+	// are kept.
+	AuthorizationTTL *int `json:"authorization_ttl,omitempty"`
+}
+
+// ToIdentityProviderUpdateMap formats an UpdateIdentityProviderOpts into an
+// update request.
+func (opts UpdateIdentityProviderOpts) ToIdentityProviderUpdateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "identity_provider")
+}
+
+// UpdateIdentityProvider modifies the attributes of an identity provider.
+func UpdateIdentityProvider(client *gophercloud.ServiceClient, idpID string, opts UpdateIdentityProviderOptsBuilder) (r UpdateIdentityProviderResult) {
+	b, err := opts.ToIdentityProviderUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(identityProviderURL(client, idpID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteIdentityProvider deletes an identity provider, along with its
+// protocols.
+func DeleteIdentityProvider(client *gophercloud.ServiceClient, idpID string) (r DeleteIdentityProviderResult) {
+	resp, err := client.Delete(identityProviderURL(client, idpID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListProtocols enumerates the federation protocols of an identity
+// provider.
+func ListProtocols(client *gophercloud.ServiceClient, idpID string) pagination.Pager {
+	url := protocolsURL(client, idpID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return ProtocolPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// GetProtocol retrieves details on a federation protocol of an identity
+// provider.
+func GetProtocol(client *gophercloud.ServiceClient, idpID, protocolID string) (r GetProtocolResult) {
+	resp, err := client.Get(protocolURL(client, idpID, protocolID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ProtocolOptsBuilder allows extensions to add additional parameters to the
+// CreateProtocol and UpdateProtocol requests.
+type ProtocolOptsBuilder interface {
+	ToProtocolMap() (map[string]interface{}, error)
+}
+
+// ProtocolOpts provides options used to create or update a federation
+// protocol.
+type ProtocolOpts struct {
+	// MappingID is the ID of the mapping that is applied to the attributes
+	// asserted with this protocol.
+	MappingID string `json:"mapping_id" required:"true"`
+
+	// RemoteIDAttribute is the attribute that holds the entity ID of the
+	// identity provider. It overrides the setting of the Keystone
+	// configuration.
+	RemoteIDAttribute string `json:"remote_id_attribute,omitempty"`
+}
+
+// ToProtocolMap formats a ProtocolOpts into a request body.
+func (opts ProtocolOpts) ToProtocolMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "protocol")
+}
+
+// CreateProtocol adds a federation protocol, such as "saml2" or "openid", to
+// an identity provider.
+func CreateProtocol(client *gophercloud.ServiceClient, idpID, protocolID string, opts ProtocolOptsBuilder) (r CreateProtocolResult) {
+	b, err := opts.ToProtocolMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(protocolURL(client, idpID, protocolID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateProtocol modifies the attributes of a federation protocol.
+func UpdateProtocol(client *gophercloud.ServiceClient, idpID, protocolID string, opts ProtocolOptsBuilder) (r UpdateProtocolResult) {
+	b, err := opts.ToProtocolMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(protocolURL(client, idpID, protocolID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteProtocol removes a federation protocol from an identity provider.
+func DeleteProtocol(client *gophercloud.ServiceClient, idpID, protocolID string) (r DeleteProtocolResult) {
+	resp, err := client.Delete(protocolURL(client, idpID, protocolID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListMappings enumerates the mappings.
+func ListMappings(client *gophercloud.ServiceClient) pagination.Pager {
+	url := mappingsURL(client)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return MappingPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// GetMapping retrieves details on a single mapping, by ID.
+func GetMapping(client *gophercloud.ServiceClient, mappingID string) (r GetMappingResult) {
+	resp, err := client.Get(mappingURL(client, mappingID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// MappingOptsBuilder allows extensions to add additional parameters to the
+// CreateMapping and UpdateMapping requests.
+type MappingOptsBuilder interface {
+	ToMappingMap() (map[string]interface{}, error)
+}
+
+// MappingOpts provides options used to create or update a mapping.
+type MappingOpts struct {
+	// Rules map the attributes asserted by an identity provider onto local
+	// users, groups and projects.
+	Rules []MappingRule `json:"rules" required:"true"`
+
+	// SchemaVersion is the version of the rules schema, such as "2.0". If
+	// it is omitted, Keystone assumes its default version.
+	SchemaVersion string `json:"schema_version,omitempty"`
+}
+
+// ToMappingMap formats a MappingOpts into a request body.
+func (opts MappingOpts) ToMappingMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "mapping")
+}
+
+// CreateMapping creates a mapping with the given ID.
+func CreateMapping(client *gophercloud.ServiceClient, mappingID string, opts MappingOptsBuilder) (r CreateMappingResult) {
+	b, err := opts.ToMappingMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(mappingURL(client, mappingID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateMapping replaces the rules of a mapping.
+func UpdateMapping(client *gophercloud.ServiceClient, mappingID string, opts MappingOptsBuilder) (r UpdateMappingResult) {
+	b, err := opts.ToMappingMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(mappingURL(client, mappingID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteMapping deletes a mapping.
+func DeleteMapping(client *gophercloud.ServiceClient, mappingID string) (r DeleteMappingResult) {
+	resp, err := client.Delete(mappingURL(client, mappingID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListServiceProvidersOptsBuilder allows extensions to add additional
+// parameters to the ListServiceProviders request.
+type ListServiceProvidersOptsBuilder interface {
+	ToServiceProviderListQuery() (string, error)
+}
+
+// ListServiceProvidersOpts provides options to filter the
+// ListServiceProviders results.
+type ListServiceProvidersOpts struct {
+	// ID filters the response by service provider ID.
+	ID string `q:"id"`
+
+	// Enabled filters the response by enabled service providers.
+	Enabled *bool `q:"enabled"`
+}
+
+// ToServiceProviderListQuery formats a ListServiceProvidersOpts into a
+// query string.
+func (opts ListServiceProvidersOpts) ToServiceProviderListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// ListServiceProviders enumerates the Keystone-to-Keystone service
+// providers.
+func ListServiceProviders(client *gophercloud.ServiceClient, opts ListServiceProvidersOptsBuilder) pagination.Pager {
+	url := serviceProvidersURL(client)
+	if opts != nil {
+		query, err := opts.ToServiceProviderListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return ServiceProviderPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// GetServiceProvider retrieves details on a single service provider, by ID.
+func GetServiceProvider(client *gophercloud.ServiceClient, spID string) (r GetServiceProviderResult) {
+	resp, err := client.Get(serviceProviderURL(client, spID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateServiceProviderOptsBuilder allows extensions to add additional
+// parameters to the CreateServiceProvider request.
+type CreateServiceProviderOptsBuilder interface {
+	ToServiceProviderCreateMap() (map[string]interface{}, error)
+}
+
+// CreateServiceProviderOpts provides options used to create a service
+// provider.
+type CreateServiceProviderOpts struct {
+	// AuthURL is the federated auth URL of the service provider's Keystone.
+	AuthURL string `json:"auth_url" required:"true"`
+
+	// SPURL is the URL to which SAML2 ECP assertions are sent.
+	SPURL string `json:"sp_url" required:"true"`
+
+	// Description is a description of the service provider.
+	Description string `json:"description,omitempty"`
+
+	// Enabled sets the service provider status to enabled or disabled.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RelayStatePrefix is the prefix of the RelayState of the assertions.
+	RelayStatePrefix string `json:"relay_state_prefix,omitempty"`
+}
+
+// ToServiceProviderCreateMap formats a CreateServiceProviderOpts into a
+// create request.
+func (opts CreateServiceProviderOpts) ToServiceProviderCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "service_provider")
+}
+
+// CreateServiceProvider registers a service provider with the given ID.
+func CreateServiceProvider(client *gophercloud.ServiceClient, spID string, opts CreateServiceProviderOptsBuilder) (r CreateServiceProviderResult) {
+	b, err := opts.ToServiceProviderCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(serviceProviderURL(client, spID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateServiceProviderOptsBuilder allows extensions to add additional
+// parameters to the UpdateServiceProvider request.
+type UpdateServiceProviderOptsBuilder interface {
+	ToServiceProviderUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateServiceProviderOpts represents parameters to update a service
+// provider.
+type UpdateServiceProviderOpts struct {
+	AuthURL          string  `json:"auth_url,omitempty"`
+	SPURL            string  `json:"sp_url,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	Enabled          *bool   `json:"enabled,omitempty"`
+	RelayStatePrefix string  `json:"relay_state_prefix,omitempty"`
+}
+
+// ToServiceProviderUpdateMap formats an UpdateServiceProviderOpts into an
+// update request.
+func (opts UpdateServiceProviderOpts) ToServiceProviderUpdateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "service_provider")
+}
+
+// UpdateServiceProvider modifies the attributes of a service provider.
+func UpdateServiceProvider(client *gophercloud.ServiceClient, spID string, opts UpdateServiceProviderOptsBuilder) (r UpdateServiceProviderResult) {
+	b, err := opts.ToServiceProviderUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(serviceProviderURL(client, spID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteServiceProvider deletes a service provider.
+func DeleteServiceProvider(client *gophercloud.ServiceClient, spID string) (r DeleteServiceProviderResult) {
+	resp, err := client.Delete(serviceProviderURL(client, spID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
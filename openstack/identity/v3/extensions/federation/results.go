@@ -0,0 +1,478 @@
+package federation
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// IdentityProvider is an external identity provider whose users may
+// authenticate with Keystone.
+type IdentityProvider struct {
+	// ID is the unique ID of the identity provider.
+	ID string `json:"id"`
+
+	// DomainID is the domain that the identity provider's users belong to.
+	DomainID string `json:"domain_id"`
+
+	// Description is the description of the identity provider.
+	Description string `json:"description"`
+
+	// Enabled is whether or not the identity provider is enabled.
+	Enabled bool `json:"enabled"`
+
+	// RemoteIDs are the entity IDs by which the identity provider
+	// identifies itself.
+	RemoteIDs []string `json:"remote_ids"`
+
+	// AuthorizationTTL is the number of minutes for which group memberships
+	// are kept, or nil if they aren't kept.
+	AuthorizationTTL *int `json:"authorization_ttl"`
+
+	// Links contains referencing links to the identity provider.
+	Links map[string]interface{} `json:"links"`
+}
+
+// Protocol is a federation protocol, such as "saml2" or "openid", that an
+// identity provider's users authenticate with.
+type Protocol struct {
+	// ID is the name of the protocol.
+	ID string `json:"id"`
+
+	// MappingID is the ID of the mapping applied to the asserted attributes.
+	MappingID string `json:"mapping_id"`
+
+	// RemoteIDAttribute is the attribute that holds the identity provider's
+	// entity ID.
+	RemoteIDAttribute string `json:"remote_id_attribute"`
+
+	// Links contains referencing links to the protocol.
+	Links map[string]interface{} `json:"links"`
+}
+
+// Mapping is a set of rules that map the attributes asserted by an identity
+// provider onto local users, groups and projects.
+type Mapping struct {
+	// ID is the unique ID of the mapping.
+	ID string `json:"id"`
+
+	// Rules are the rules of the mapping.
+	Rules []MappingRule `json:"rules"`
+
+	// SchemaVersion is the version of the rules schema.
+	SchemaVersion string `json:"schema_version"`
+
+	// Links contains referencing links to the mapping.
+	Links map[string]interface{} `json:"links"`
+}
+
+// MappingRule applies its Local attributes when all of its Remote
+// conditions are met.
+type MappingRule struct {
+	// Local are the local attributes the rule assigns. They may refer to the
+	// values of the Remote conditions by position, as in "{0}".
+	Local []RuleLocal `json:"local"`
+
+	// Remote are the conditions on the asserted attributes.
+	Remote []RuleRemote `json:"remote"`
+}
+
+// RuleLocal is a local attribute assigned by a MappingRule.
+type RuleLocal struct {
+	// User sets the identity of the user.
+	User *RuleUser `json:"user,omitempty"`
+
+	// Group adds the user to a group.
+	Group *Group `json:"group,omitempty"`
+
+	// Groups adds the user to the groups whose names are listed in it. It
+	// usually refers to a remote attribute, as in "{1}".
+	Groups string `json:"groups,omitempty"`
+
+	// GroupIDs adds the user to the groups whose IDs are listed in it.
+	GroupIDs string `json:"group_ids,omitempty"`
+
+	// Domain is the domain of the groups named in Groups.
+	Domain *Domain `json:"domain,omitempty"`
+
+	// Projects are created for the user, if they don't exist, with the
+	// given roles.
+	Projects []RuleProject `json:"projects,omitempty"`
+}
+
+// UserType is the kind of user created by a mapping.
+type UserType string
+
+const (
+	// UserTypeEphemeral users exist only for the duration of their token.
+	UserTypeEphemeral UserType = "ephemeral"
+
+	// UserTypeLocal users are existing local users.
+	UserTypeLocal UserType = "local"
+)
+
+// RuleUser identifies the user of a RuleLocal.
+type RuleUser struct {
+	ID     string   `json:"id,omitempty"`
+	Name   string   `json:"name,omitempty"`
+	Email  string   `json:"email,omitempty"`
+	Type   UserType `json:"type,omitempty"`
+	Domain *Domain  `json:"domain,omitempty"`
+}
+
+// Group identifies a group by ID, or by name and domain.
+type Group struct {
+	ID     string  `json:"id,omitempty"`
+	Name   string  `json:"name,omitempty"`
+	Domain *Domain `json:"domain,omitempty"`
+}
+
+// Domain identifies a domain by ID or name.
+type Domain struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// RuleProject is a project that is created for the user.
+type RuleProject struct {
+	Name  string     `json:"name"`
+	Roles []RuleRole `json:"roles"`
+}
+
+// RuleRole is a role, by name, of a RuleProject.
+type RuleRole struct {
+	Name string `json:"name"`
+}
+
+// RuleRemote is a condition on an asserted attribute. Without any of
+// AnyOneOf, NotAnyOf, Blacklist and Whitelist, it only requires the
+// attribute to be present and makes its value available to the local
+// attributes.
+type RuleRemote struct {
+	// Type is the name of the asserted attribute.
+	Type string `json:"type"`
+
+	// AnyOneOf requires the attribute to have one of the listed values.
+	AnyOneOf []string `json:"any_one_of,omitempty"`
+
+	// NotAnyOf requires the attribute to have none of the listed values.
+	NotAnyOf []string `json:"not_any_of,omitempty"`
+
+	// Regex makes AnyOneOf and NotAnyOf match regular expressions.
+	Regex *bool `json:"regex,omitempty"`
+
+	// Blacklist and Whitelist filter the group names of the attribute
+	// before they are passed on to the local Groups.
+	Blacklist []string `json:"blacklist,omitempty"`
+	Whitelist []string `json:"whitelist,omitempty"`
+}
+
+// ServiceProvider is a Keystone-to-Keystone service provider, which accepts
+// SAML2 assertions issued by this Keystone.
+type ServiceProvider struct {
+	// ID is the ID of the service provider.
+	ID string `json:"id"`
+
+	// AuthURL is the federated auth URL of the service provider's identity
+	// service, at which the unscoped token is obtained.
+	AuthURL string `json:"auth_url"`
+
+	// SPURL is the URL to which SAML2 ECP assertions are sent.
+	SPURL string `json:"sp_url"`
+
+	// Description is the description of the service provider. It isn't
+	// listed in tokens.
+	Description string `json:"description,omitempty"`
+
+	// Enabled is whether or not the service provider is enabled. It isn't
+	// listed in tokens.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// RelayStatePrefix is the prefix of the RelayState of the assertions.
+	// It isn't listed in tokens.
+	RelayStatePrefix string `json:"relay_state_prefix,omitempty"`
+
+	// Links contains referencing links to the service provider.
+	Links map[string]interface{} `json:"links,omitempty"`
+}
+
+// TokenExt represents an extension of the base token result that lists the
+// service providers the token can be exchanged with.
+type TokenExt struct {
+	ServiceProviders []ServiceProvider `json:"service_providers"`
+}
+
+type identityProviderResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any identityProviderResult as an IdentityProvider.
+func (r identityProviderResult) Extract() (*IdentityProvider, error) {
+	var s struct {
+		IdentityProvider *IdentityProvider `json:"identity_provider"`
+	}
+	err := r.ExtractInto(&s)
+	return s.IdentityProvider, err
+}
+
+// GetIdentityProviderResult is the response from a GetIdentityProvider
+// operation. Call its Extract method to interpret it as an
+// IdentityProvider.
+type GetIdentityProviderResult struct {
+	identityProviderResult
+}
+
+// CreateIdentityProviderResult is the response from a
+// CreateIdentityProvider operation. Call its Extract method to interpret it
+// as an IdentityProvider.
+type CreateIdentityProviderResult struct {
+	identityProviderResult
+}
+
+// UpdateIdentityProviderResult is the response from an
+// UpdateIdentityProvider operation. Call its Extract method to interpret it
+// as an IdentityProvider.
+type UpdateIdentityProviderResult struct {
+	identityProviderResult
+}
+
+// DeleteIdentityProviderResult is the response from a
+// DeleteIdentityProvider operation. Call its ExtractErr to determine if the
+// request succeeded or failed.
+type DeleteIdentityProviderResult struct {
+	gophercloud.ErrResult
+}
+
+// IdentityProviderPage is a single page of IdentityProvider results.
+type IdentityProviderPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of IdentityProviders contains
+// any results.
+func (r IdentityProviderPage) IsEmpty() (bool, error) {
+	idps, err := ExtractIdentityProviders(r)
+	return len(idps) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r IdentityProviderPage) NextPageURL() (string, error) {
+	return nextPageURL(r.LinkedPageBase)
+}
+
+// ExtractIdentityProviders returns a slice of IdentityProviders contained in
+// a single page of results.
+func ExtractIdentityProviders(r pagination.Page) ([]IdentityProvider, error) {
+	var s struct {
+		IdentityProviders []IdentityProvider `json:"identity_providers"`
+	}
+	err := (r.(IdentityProviderPage)).ExtractInto(&s)
+	return s.IdentityProviders, err
+}
+
+type protocolResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any protocolResult as a Protocol.
+func (r protocolResult) Extract() (*Protocol, error) {
+	var s struct {
+		Protocol *Protocol `json:"protocol"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Protocol, err
+}
+
+// GetProtocolResult is the response from a GetProtocol operation. Call its
+// Extract method to interpret it as a Protocol.
+type GetProtocolResult struct {
+	protocolResult
+}
+
+// CreateProtocolResult is the response from a CreateProtocol operation.
+// Call its Extract method to interpret it as a Protocol.
+type CreateProtocolResult struct {
+	protocolResult
+}
+
+// UpdateProtocolResult is the response from an UpdateProtocol operation.
+// Call its Extract method to interpret it as a Protocol.
+type UpdateProtocolResult struct {
+	protocolResult
+}
+
+// DeleteProtocolResult is the response from a DeleteProtocol operation.
+// Call its ExtractErr to determine if the request succeeded or failed.
+type DeleteProtocolResult struct {
+	gophercloud.ErrResult
+}
+
+// ProtocolPage is a single page of Protocol results.
+type ProtocolPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of Protocols contains any
+// results.
+func (r ProtocolPage) IsEmpty() (bool, error) {
+	protocols, err := ExtractProtocols(r)
+	return len(protocols) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r ProtocolPage) NextPageURL() (string, error) {
+	return nextPageURL(r.LinkedPageBase)
+}
+
+// ExtractProtocols returns a slice of Protocols contained in a single page
+// of results.
+func ExtractProtocols(r pagination.Page) ([]Protocol, error) {
+	var s struct {
+		Protocols []Protocol `json:"protocols"`
+	}
+	err := (r.(ProtocolPage)).ExtractInto(&s)
+	return s.Protocols, err
+}
+
+type mappingResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any mappingResult as a Mapping.
+func (r mappingResult) Extract() (*Mapping, error) {
+	var s struct {
+		Mapping *Mapping `json:"mapping"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Mapping, err
+}
+
+// GetMappingResult is the response from a GetMapping operation. Call its
+// Extract method to interpret it as a Mapping.
+type GetMappingResult struct {
+	mappingResult
+}
+
+// CreateMappingResult is the response from a CreateMapping operation. Call
+// its Extract method to interpret it as a Mapping.
+type CreateMappingResult struct {
+	mappingResult
+}
+
+// UpdateMappingResult is the response from an UpdateMapping operation. Call
+// its Extract method to interpret it as a Mapping.
+type UpdateMappingResult struct {
+	mappingResult
+}
+
+// DeleteMappingResult is the response from a DeleteMapping operation. Call
+// its ExtractErr to determine if the request succeeded or failed.
+type DeleteMappingResult struct {
+	gophercloud.ErrResult
+}
+
+// MappingPage is a single page of Mapping results.
+type MappingPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of Mappings contains any results.
+func (r MappingPage) IsEmpty() (bool, error) {
+	mappings, err := ExtractMappings(r)
+	return len(mappings) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r MappingPage) NextPageURL() (string, error) {
+	return nextPageURL(r.LinkedPageBase)
+}
+
+// ExtractMappings returns a slice of Mappings contained in a single page of
+// results.
+func ExtractMappings(r pagination.Page) ([]Mapping, error) {
+	var s struct {
+		Mappings []Mapping `json:"mappings"`
+	}
+	err := (r.(MappingPage)).ExtractInto(&s)
+	return s.Mappings, err
+}
+
+type serviceProviderResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any serviceProviderResult as a ServiceProvider.
+func (r serviceProviderResult) Extract() (*ServiceProvider, error) {
+	var s struct {
+		ServiceProvider *ServiceProvider `json:"service_provider"`
+	}
+	err := r.ExtractInto(&s)
+	return s.ServiceProvider, err
+}
+
+// GetServiceProviderResult is the response from a GetServiceProvider
+// operation. Call its Extract method to interpret it as a ServiceProvider.
+type GetServiceProviderResult struct {
+	serviceProviderResult
+}
+
+// CreateServiceProviderResult is the response from a CreateServiceProvider
+// operation. Call its Extract method to interpret it as a ServiceProvider.
+type CreateServiceProviderResult struct {
+	serviceProviderResult
+}
+
+// UpdateServiceProviderResult is the response from an
+// UpdateServiceProvider operation. Call its Extract method to interpret it
+// as a ServiceProvider.
+type UpdateServiceProviderResult struct {
+	serviceProviderResult
+}
+
+// DeleteServiceProviderResult is the response from a DeleteServiceProvider
+// operation. Call its ExtractErr to determine if the request succeeded or
+// failed.
+type DeleteServiceProviderResult struct {
+	gophercloud.ErrResult
+}
+
+// ServiceProviderPage is a single page of ServiceProvider results.
+type ServiceProviderPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of ServiceProviders contains any
+// results.
+func (r ServiceProviderPage) IsEmpty() (bool, error) {
+	sps, err := ExtractServiceProviders(r)
+	return len(sps) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r ServiceProviderPage) NextPageURL() (string, error) {
+	return nextPageURL(r.LinkedPageBase)
+}
+
+// ExtractServiceProviders returns a slice of ServiceProviders contained in
+// a single page of results.
+func ExtractServiceProviders(r pagination.Page) ([]ServiceProvider, error) {
+	var s struct {
+		ServiceProviders []ServiceProvider `json:"service_providers"`
+	}
+	err := (r.(ServiceProviderPage)).ExtractInto(&s)
+	return s.ServiceProviders, err
+}
+
+// nextPageURL extracts the "next" link from the links section of a page.
+func nextPageURL(r pagination.LinkedPageBase) (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
@@ -0,0 +1,572 @@
+package testing
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/federation"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+// UnscopedTokenResponse is the body of an unscoped federated token.
+const UnscopedTokenResponse = `
+{
+    "token": {
+        "methods": ["mapped"],
+        "expires_at": "2030-01-01T12:00:00.000000Z",
+        "user": {
+            "id": "fed-user",
+            "name": "alice",
+            "OS-FEDERATION": {
+                "identity_provider": {"id": "myidp"},
+                "protocol": {"id": "saml2"},
+                "groups": [{"id": "fed-group"}]
+            }
+        }
+    }
+}
+`
+
+// ScopedTokenResponse is the body of the token the unscoped token is
+// rescoped to. Its catalog points to the given compute endpoint.
+const ScopedTokenResponse = `
+{
+    "token": {
+        "methods": ["token"],
+        "expires_at": "2030-01-01T12:00:00.000000Z",
+        "project": {"id": "fed-project", "name": "federated"},
+        "catalog": [
+            {
+                "type": "compute",
+                "name": "nova",
+                "endpoints": [
+                    {"interface": "public", "region": "RegionOne", "url": "%s"}
+                ]
+            }
+        ]
+    }
+}
+`
+
+// RescopeRequest is the request that rescopes the unscoped token.
+const RescopeRequest = `
+{
+    "auth": {
+        "identity": {
+            "methods": ["token"],
+            "token": {"id": "unscoped-token"}
+        },
+        "scope": {
+            "project": {"id": "fed-project"}
+        }
+    }
+}
+`
+
+// AuthnRequestEnvelope is the SAML2 ECP authentication request of a
+// Shibboleth service provider. It is formatted with the consumer URL.
+const AuthnRequestEnvelope = `<S:Envelope xmlns:S="http://schemas.xmlsoap.org/soap/envelope/" xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol">
+  <S:Header>
+    <paos:Request xmlns:paos="urn:liberty:paos:2003-08" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1" responseConsumerURL="%s" service="urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp"/>
+    <ecp:Request xmlns:ecp="urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp" IsPassive="0" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1"/>
+    <ecp:RelayState xmlns:ecp="urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp" S:actor="http://schemas.xmlsoap.org/soap/actor/next" S:mustUnderstand="1">ss:mem:6f1f20fafbb38433467e9d477df67615</ecp:RelayState>
+  </S:Header>
+  <S:Body>
+    <samlp:AuthnRequest AssertionConsumerServiceURL="%s" ID="_a07186e3992e70e92c17b9d249495643" IssueInstant="2030-01-01T11:00:00Z" ProtocolBinding="urn:oasis:names:tc:SAML:2.0:bindings:PAOS" Version="2.0"><saml:Issuer xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">https://sp.example.com/shibboleth</saml:Issuer></samlp:AuthnRequest>
+  </S:Body>
+</S:Envelope>`
+
+// ResponseEnvelope is the SAML2 ECP response of an identity provider. It is
+// formatted with the assertion consumer URL.
+const ResponseEnvelope = `<soap11:Envelope xmlns:soap11="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap11:Header>
+    <ecp:Response xmlns:ecp="urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp" AssertionConsumerServiceURL="%s" soap11:actor="http://schemas.xmlsoap.org/soap/actor/next" soap11:mustUnderstand="1"/>
+  </soap11:Header>
+  <soap11:Body>
+    <saml2p:Response xmlns:saml2p="urn:oasis:names:tc:SAML:2.0:protocol" ID="_response" Version="2.0"><saml2:Assertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion" ID="_assertion">alice</saml2:Assertion></saml2p:Response>
+  </soap11:Body>
+</soap11:Envelope>`
+
+// K2KAssertion is the ECP-wrapped assertion a Keystone identity provider
+// issues for a service provider.
+const K2KAssertion = `<soap11:Envelope xmlns:soap11="http://schemas.xmlsoap.org/soap/envelope/"><soap11:Header><ecp:RelayState xmlns:ecp="urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp" soap11:actor="http://schemas.xmlsoap.org/soap/actor/next" soap11:mustUnderstand="1">ss:mem</ecp:RelayState></soap11:Header><soap11:Body><ns0:Response xmlns:ns0="urn:oasis:names:tc:SAML:2.0:protocol" ID="k2k">keystone-assertion</ns0:Response></soap11:Body></soap11:Envelope>`
+
+// HandleRescope registers a handler that rescopes the unscoped token to
+// fed-project. The scoped token's catalog lists computeURL.
+func HandleRescope(t *testing.T, mux *http.ServeMux, computeURL string) {
+	mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, RescopeRequest)
+
+		w.Header().Set("X-Subject-Token", "scoped-token")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, ScopedTokenResponse, computeURL)
+	})
+}
+
+// writeUnscopedToken answers a federated auth request with the unscoped
+// token.
+func writeUnscopedToken(w http.ResponseWriter) {
+	w.Header().Set("X-Subject-Token", "unscoped-token")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprint(w, UnscopedTokenResponse)
+}
+
+// HandleFederatedAuthWithCookie registers the handler of the federated auth
+// URL that issues the unscoped token once the service provider established
+// a session. Without the session, it answers like a Shibboleth service
+// provider with an ECP authentication request.
+func HandleFederatedAuthWithCookie(t *testing.T, mux *http.ServeMux, path, consumerURL string) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		if r.Header.Get("X-Auth-Token") != "" {
+			t.Errorf("The federated auth request carries an X-Auth-Token")
+		}
+
+		if _, err := r.Cookie("_shibsession"); err == nil {
+			writeUnscopedToken(w)
+			return
+		}
+
+		th.TestHeader(t, r, "PAOS", `ver="urn:liberty:paos:2003-08";"urn:oasis:names:tc:SAML:2.0:profiles:SSO:ecp"`)
+		w.Header().Set("Content-Type", "application/vnd.paos+xml")
+		fmt.Fprintf(w, AuthnRequestEnvelope, consumerURL, consumerURL)
+	})
+}
+
+// HandleAssertionConsumer registers a Shibboleth assertion consumer that
+// checks the delivered assertion, starts a session and redirects to
+// authURL.
+func HandleAssertionConsumer(t *testing.T, mux *http.ServeMux, path, authURL string, expected ...string) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Content-Type", "application/vnd.paos+xml")
+		body := readBody(t, r)
+		for _, e := range expected {
+			th.AssertEquals(t, true, strings.Contains(body, e))
+		}
+
+		http.SetCookie(w, &http.Cookie{Name: "_shibsession", Value: "session", Path: "/"})
+		http.Redirect(w, r, authURL, http.StatusFound)
+	})
+}
+
+// NewIdentityProvider starts a stand-in SAML2 ECP identity provider that
+// authenticates alice and asserts her identity to consumerURL.
+func NewIdentityProvider(t *testing.T, consumerURL string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Content-Type", "text/xml; charset=utf-8")
+
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "alice" || password != "wonderland" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body := readBody(t, r)
+		th.AssertEquals(t, true, strings.Contains(body, "<samlp:AuthnRequest"))
+		th.AssertEquals(t, false, strings.Contains(body, "paos:Request"))
+
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprintf(w, ResponseEnvelope, consumerURL)
+	}))
+}
+
+// NewOIDCProvider starts a stand-in OpenID Connect provider that issues an
+// access token to alice with the password grant.
+func NewOIDCProvider(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"issuer": "%s", "token_endpoint": "%s/token"}`, server.URL, server.URL)
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Content-Type", "application/x-www-form-urlencoded")
+
+		clientID, clientSecret, ok := r.BasicAuth()
+		th.AssertEquals(t, true, ok)
+		th.AssertEquals(t, "gophercloud", clientID)
+		th.AssertEquals(t, "client-secret", clientSecret)
+
+		th.AssertNoErr(t, r.ParseForm())
+		th.AssertEquals(t, "password", r.PostForm.Get("grant_type"))
+		th.AssertEquals(t, "alice", r.PostForm.Get("username"))
+		th.AssertEquals(t, "wonderland", r.PostForm.Get("password"))
+		th.AssertEquals(t, "openid profile", r.PostForm.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "oidc-access-token", "token_type": "Bearer", "expires_in": 300}`)
+	})
+
+	return server
+}
+
+// HandleOIDCAuth registers the federated auth URL of the "openid" protocol,
+// which exchanges the access token for an unscoped token.
+func HandleOIDCAuth(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/v3/OS-FEDERATION/identity_providers/myidp/protocols/openid/auth", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", "Bearer oidc-access-token")
+		if r.Header.Get("X-Auth-Token") != "" {
+			t.Errorf("The federated auth request carries an X-Auth-Token")
+		}
+		writeUnscopedToken(w)
+	})
+}
+
+func readBody(t *testing.T, r *http.Request) string {
+	b, err := ioutil.ReadAll(r.Body)
+	th.AssertNoErr(t, err)
+	return string(b)
+}
+
+// ListIdentityProvidersOutput provides a single page of identity providers.
+const ListIdentityProvidersOutput = `
+{
+    "identity_providers": [
+        {
+            "id": "ACME",
+            "domain_id": "1789d1",
+            "description": "Stores ACME identities",
+            "enabled": true,
+            "remote_ids": ["https://idp.acme.example.com/idp/shibboleth"],
+            "authorization_ttl": null,
+            "links": {
+                "protocols": "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols",
+                "self": "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME"
+            }
+        }
+    ],
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "https://example.com/identity/v3/OS-FEDERATION/identity_providers"
+    }
+}
+`
+
+// CreateIdentityProviderRequest is the request to create the ACME identity
+// provider.
+const CreateIdentityProviderRequest = `
+{
+    "identity_provider": {
+        "domain_id": "1789d1",
+        "description": "Stores ACME identities",
+        "enabled": true,
+        "remote_ids": ["https://idp.acme.example.com/idp/shibboleth"]
+    }
+}
+`
+
+// GetIdentityProviderOutput provides the ACME identity provider.
+const GetIdentityProviderOutput = `
+{
+    "identity_provider": {
+        "id": "ACME",
+        "domain_id": "1789d1",
+        "description": "Stores ACME identities",
+        "enabled": true,
+        "remote_ids": ["https://idp.acme.example.com/idp/shibboleth"],
+        "authorization_ttl": null,
+        "links": {
+            "protocols": "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols",
+            "self": "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME"
+        }
+    }
+}
+`
+
+// UpdateIdentityProviderRequest disables the ACME identity provider and
+// keeps group memberships for an hour.
+const UpdateIdentityProviderRequest = `
+{
+    "identity_provider": {
+        "enabled": false,
+        "remote_ids": [],
+        "authorization_ttl": 60
+    }
+}
+`
+
+// UpdateIdentityProviderOutput provides the updated ACME identity provider.
+const UpdateIdentityProviderOutput = `
+{
+    "identity_provider": {
+        "id": "ACME",
+        "domain_id": "1789d1",
+        "description": "Stores ACME identities",
+        "enabled": false,
+        "remote_ids": [],
+        "authorization_ttl": 60
+    }
+}
+`
+
+// ProtocolRequest is the request to create or update the saml2 protocol.
+const ProtocolRequest = `
+{
+    "protocol": {
+        "mapping_id": "ACME-mapping",
+        "remote_id_attribute": "Shib-Identity-Provider"
+    }
+}
+`
+
+// ProtocolOutput provides the saml2 protocol.
+const ProtocolOutput = `
+{
+    "protocol": {
+        "id": "saml2",
+        "mapping_id": "ACME-mapping",
+        "remote_id_attribute": "Shib-Identity-Provider",
+        "links": {
+            "identity_provider": "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+            "self": "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols/saml2"
+        }
+    }
+}
+`
+
+// ListProtocolsOutput provides a single page of protocols.
+const ListProtocolsOutput = `
+{
+    "protocols": [
+        {
+            "id": "saml2",
+            "mapping_id": "ACME-mapping",
+            "links": {}
+        }
+    ],
+    "links": {"next": null, "previous": null}
+}
+`
+
+// MappingRequest is the request to create the ACME mapping.
+const MappingRequest = `
+{
+    "mapping": {
+        "rules": [
+            {
+                "local": [
+                    {
+                        "user": {"name": "{0}", "type": "ephemeral"}
+                    },
+                    {
+                        "groups": "{1}",
+                        "domain": {"id": "1789d1"}
+                    },
+                    {
+                        "projects": [
+                            {"name": "Production", "roles": [{"name": "reader"}]}
+                        ]
+                    }
+                ],
+                "remote": [
+                    {"type": "REMOTE_USER"},
+                    {
+                        "type": "orgPersonType",
+                        "whitelist": ["Developers", "Contractors"]
+                    },
+                    {
+                        "type": "Email",
+                        "any_one_of": [".*@acme.example.com$"],
+                        "regex": true
+                    }
+                ]
+            }
+        ],
+        "schema_version": "2.0"
+    }
+}
+`
+
+// MappingOutput provides the ACME mapping.
+const MappingOutput = `
+{
+    "mapping": {
+        "id": "ACME-mapping",
+        "rules": [
+            {
+                "local": [
+                    {
+                        "user": {"name": "{0}", "type": "ephemeral"}
+                    },
+                    {
+                        "groups": "{1}",
+                        "domain": {"id": "1789d1"}
+                    },
+                    {
+                        "projects": [
+                            {"name": "Production", "roles": [{"name": "reader"}]}
+                        ]
+                    }
+                ],
+                "remote": [
+                    {"type": "REMOTE_USER"},
+                    {
+                        "type": "orgPersonType",
+                        "whitelist": ["Developers", "Contractors"]
+                    },
+                    {
+                        "type": "Email",
+                        "any_one_of": [".*@acme.example.com$"],
+                        "regex": true
+                    }
+                ]
+            }
+        ],
+        "schema_version": "2.0",
+        "links": {
+            "self": "https://example.com/identity/v3/OS-FEDERATION/mappings/ACME-mapping"
+        }
+    }
+}
+`
+
+// ListMappingsOutput provides a single page of mappings.
+const ListMappingsOutput = `
+{
+    "mappings": [
+        {
+            "id": "ACME-mapping",
+            "rules": [
+                {
+                    "local": [{"group": {"id": "0cd5e9"}}],
+                    "remote": [{"type": "UserName", "not_any_of": ["root"]}]
+                }
+            ],
+            "schema_version": "1.0"
+        }
+    ],
+    "links": {"next": null, "previous": null}
+}
+`
+
+// CreateServiceProviderRequest is the request to create the BETA service
+// provider.
+const CreateServiceProviderRequest = `
+{
+    "service_provider": {
+        "auth_url": "https://beta.example.com/v3/OS-FEDERATION/identity_providers/beta/protocols/saml2/auth",
+        "sp_url": "https://beta.example.com/Shibboleth.sso/SAML2/ECP",
+        "description": "Remote region",
+        "enabled": true
+    }
+}
+`
+
+// ServiceProviderOutput provides the BETA service provider.
+const ServiceProviderOutput = `
+{
+    "service_provider": {
+        "id": "BETA",
+        "auth_url": "https://beta.example.com/v3/OS-FEDERATION/identity_providers/beta/protocols/saml2/auth",
+        "sp_url": "https://beta.example.com/Shibboleth.sso/SAML2/ECP",
+        "description": "Remote region",
+        "enabled": true,
+        "relay_state_prefix": "ss:mem:"
+    }
+}
+`
+
+// ListServiceProvidersOutput provides a single page of service providers.
+const ListServiceProvidersOutput = `
+{
+    "service_providers": [
+        {
+            "id": "BETA",
+            "auth_url": "https://beta.example.com/v3/OS-FEDERATION/identity_providers/beta/protocols/saml2/auth",
+            "sp_url": "https://beta.example.com/Shibboleth.sso/SAML2/ECP",
+            "description": "Remote region",
+            "enabled": true,
+            "relay_state_prefix": "ss:mem:"
+        }
+    ],
+    "links": {"next": null, "previous": null}
+}
+`
+
+// ACMEIdentityProvider is the identity provider in GetIdentityProviderOutput.
+var ACMEIdentityProvider = federation.IdentityProvider{
+	ID:          "ACME",
+	DomainID:    "1789d1",
+	Description: "Stores ACME identities",
+	Enabled:     true,
+	RemoteIDs:   []string{"https://idp.acme.example.com/idp/shibboleth"},
+	Links: map[string]interface{}{
+		"protocols": "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols",
+		"self":      "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+	},
+}
+
+// SAML2Protocol is the protocol in ProtocolOutput.
+var SAML2Protocol = federation.Protocol{
+	ID:                "saml2",
+	MappingID:         "ACME-mapping",
+	RemoteIDAttribute: "Shib-Identity-Provider",
+	Links: map[string]interface{}{
+		"identity_provider": "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+		"self":              "https://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols/saml2",
+	},
+}
+
+var regex = true
+
+// ACMERules are the rules of MappingRequest.
+var ACMERules = []federation.MappingRule{
+	{
+		Local: []federation.RuleLocal{
+			{User: &federation.RuleUser{Name: "{0}", Type: federation.UserTypeEphemeral}},
+			{Groups: "{1}", Domain: &federation.Domain{ID: "1789d1"}},
+			{Projects: []federation.RuleProject{
+				{Name: "Production", Roles: []federation.RuleRole{{Name: "reader"}}},
+			}},
+		},
+		Remote: []federation.RuleRemote{
+			{Type: "REMOTE_USER"},
+			{Type: "orgPersonType", Whitelist: []string{"Developers", "Contractors"}},
+			{Type: "Email", AnyOneOf: []string{".*@acme.example.com$"}, Regex: &regex},
+		},
+	},
+}
+
+// BetaServiceProvider is the service provider in ServiceProviderOutput.
+var BetaServiceProvider = federation.ServiceProvider{
+	ID:               "BETA",
+	AuthURL:          "https://beta.example.com/v3/OS-FEDERATION/identity_providers/beta/protocols/saml2/auth",
+	SPURL:            "https://beta.example.com/Shibboleth.sso/SAML2/ECP",
+	Description:      "Remote region",
+	Enabled:          true,
+	RelayStatePrefix: "ss:mem:",
+}
+
+// HandleResource registers a handler at path that checks the method and,
+// if given, the request body, and responds with status and output.
+func HandleResource(t *testing.T, path, method, request string, status int, output string) {
+	th.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, method)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		if request != "" {
+			th.TestJSONRequest(t, r, request)
+		}
+
+		if output != "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(status)
+		fmt.Fprint(w, output)
+	})
+}
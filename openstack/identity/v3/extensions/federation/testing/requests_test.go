@@ -0,0 +1,496 @@
+package testing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/federation"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+const samlAuthPath = "/v3/OS-FEDERATION/identity_providers/myidp/protocols/saml2/auth"
+
+func TestAuthenticateOIDCPassword(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	idp := NewOIDCProvider(t)
+	defer idp.Close()
+
+	HandleOIDCAuth(t, th.Mux)
+	HandleRescope(t, th.Mux, "https://compute.example.com/v2.1/")
+
+	client, err := openstack.NewClient(th.Endpoint() + "v3/")
+	th.AssertNoErr(t, err)
+
+	err = federation.Authenticate(client, federation.AuthOptions{
+		Method: federation.OIDCPassword{
+			IdentityProvider:  "myidp",
+			ClientID:          "gophercloud",
+			ClientSecret:      "client-secret",
+			DiscoveryEndpoint: idp.URL + "/.well-known/openid-configuration",
+			Username:          "alice",
+			Password:          "wonderland",
+			Scope:             "openid profile",
+		},
+		Scope:       tokens.Scope{ProjectID: "fed-project"},
+		AllowReauth: true,
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, "scoped-token", client.TokenID)
+	th.AssertEquals(t, true, client.TokenExpiresAt.Equal(time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)))
+
+	compute, err := openstack.NewComputeV2(client, gophercloud.EndpointOpts{Region: "RegionOne"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://compute.example.com/v2.1/", compute.Endpoint)
+
+	// Reauthentication repeats the whole flow.
+	client.TokenID = "expired"
+	th.AssertNoErr(t, client.ReauthFunc())
+	th.AssertEquals(t, "scoped-token", client.TokenID)
+}
+
+func TestOIDCAccessTokenMissingInput(t *testing.T) {
+	r := federation.OIDCAccessToken{IdentityProvider: "myidp"}.UnscopedToken(nil)
+	if _, ok := r.Err.(gophercloud.ErrMissingInput); !ok {
+		t.Errorf("expected ErrMissingInput, got %T: %v", r.Err, r.Err)
+	}
+}
+
+func TestAuthenticateSAML2ECP(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	consumerURL := th.Endpoint() + "Shibboleth.sso/SAML2/ECP"
+	idp := NewIdentityProvider(t, consumerURL)
+	defer idp.Close()
+
+	HandleFederatedAuthWithCookie(t, th.Mux, samlAuthPath, consumerURL)
+	HandleAssertionConsumer(t, th.Mux, "/Shibboleth.sso/SAML2/ECP", th.Endpoint()+samlAuthPath[1:],
+		"ss:mem:6f1f20fafbb38433467e9d477df67615", "<saml2:Assertion", "<saml2p:Response")
+
+	client, err := openstack.NewClient(th.Endpoint() + "v3/")
+	th.AssertNoErr(t, err)
+
+	err = federation.Authenticate(client, federation.AuthOptions{
+		Method: federation.SAML2ECP{
+			IdentityProvider:    "myidp",
+			IdentityProviderURL: idp.URL,
+			Username:            "alice",
+			Password:            "wonderland",
+		},
+	})
+	th.AssertNoErr(t, err)
+
+	// Without a scope, the unscoped token is used.
+	th.AssertEquals(t, "unscoped-token", client.TokenID)
+	if client.ReauthFunc != nil {
+		t.Errorf("ReauthFunc is set although AllowReauth is false")
+	}
+}
+
+func TestSAML2ECPWrongPassword(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	consumerURL := th.Endpoint() + "Shibboleth.sso/SAML2/ECP"
+	idp := NewIdentityProvider(t, consumerURL)
+	defer idp.Close()
+
+	HandleFederatedAuthWithCookie(t, th.Mux, samlAuthPath, consumerURL)
+
+	client, err := openstack.NewClient(th.Endpoint() + "v3/")
+	th.AssertNoErr(t, err)
+
+	err = federation.Authenticate(client, federation.AuthOptions{
+		Method: federation.SAML2ECP{
+			IdentityProvider:    "myidp",
+			IdentityProviderURL: idp.URL,
+			Username:            "alice",
+			Password:            "looking-glass",
+		},
+	})
+	if _, ok := err.(gophercloud.ErrDefault401); !ok {
+		t.Errorf("expected ErrDefault401, got %T: %v", err, err)
+	}
+}
+
+func TestSAML2ECPConsumerURLMismatch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	// The identity provider wants the assertion delivered elsewhere.
+	idp := NewIdentityProvider(t, "https://attacker.example.com/ECP")
+	defer idp.Close()
+
+	consumerURL := th.Endpoint() + "Shibboleth.sso/SAML2/ECP"
+	HandleFederatedAuthWithCookie(t, th.Mux, samlAuthPath, consumerURL)
+	th.Mux.HandleFunc("/Shibboleth.sso/SAML2/ECP", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("The assertion was delivered despite the mismatch")
+	})
+
+	client, err := openstack.NewClient(th.Endpoint() + "v3/")
+	th.AssertNoErr(t, err)
+
+	err = federation.Authenticate(client, federation.AuthOptions{
+		Method: federation.SAML2ECP{
+			IdentityProvider:    "myidp",
+			IdentityProviderURL: idp.URL,
+			Username:            "alice",
+			Password:            "wonderland",
+		},
+	})
+	mismatch, ok := err.(federation.ErrConsumerURLMismatch)
+	if !ok {
+		t.Fatalf("expected ErrConsumerURLMismatch, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, consumerURL, mismatch.ResponseConsumerURL)
+	th.AssertEquals(t, "https://attacker.example.com/ECP", mismatch.AssertionConsumerServiceURL)
+}
+
+func TestAuthenticateKeystone2Keystone(t *testing.T) {
+	// The service provider's Keystone.
+	spMux := http.NewServeMux()
+	sp := httptest.NewServer(spMux)
+	defer sp.Close()
+
+	spAuthURL := sp.URL + "/v3/OS-FEDERATION/identity_providers/keystone-idp/protocols/saml2/auth"
+	HandleAssertionConsumer(t, spMux, "/Shibboleth.sso/SAML2/ECP", spAuthURL, "keystone-assertion", "ss:mem")
+	spMux.HandleFunc("/v3/OS-FEDERATION/identity_providers/keystone-idp/protocols/saml2/auth", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		if _, err := r.Cookie("_shibsession"); err != nil {
+			t.Errorf("The federated auth request carries no session cookie")
+		}
+		writeUnscopedToken(w)
+	})
+	HandleRescope(t, spMux, "https://compute.sp.example.com/v2.1/")
+
+	// The local Keystone, which acts as the identity provider.
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Subject-Token", "local-token")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token": {"expires_at": "2030-01-01T12:00:00.000000Z", "service_providers": [
+			{"id": "other", "auth_url": "https://other.example.com/auth", "sp_url": "https://other.example.com/ECP"},
+			{"id": "mysp", "auth_url": "` + spAuthURL + `", "sp_url": "` + sp.URL + `/Shibboleth.sso/SAML2/ECP"}
+		]}}`))
+	})
+	th.Mux.HandleFunc("/v3/auth/OS-FEDERATION/saml2/ecp", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", "local-token")
+		th.TestJSONRequest(t, r, `
+			{
+				"auth": {
+					"identity": {
+						"methods": ["token"],
+						"token": {"id": "local-token"}
+					},
+					"scope": {
+						"service_provider": {"id": "mysp"}
+					}
+				}
+			}
+		`)
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(K2KAssertion))
+	})
+
+	local, err := openstack.NewClient(th.Endpoint() + "v3/")
+	th.AssertNoErr(t, err)
+	local.TokenID = "local-token"
+	localIdentity, err := openstack.NewIdentityV3(local, gophercloud.EndpointOpts{})
+	th.AssertNoErr(t, err)
+
+	remote, err := openstack.NewClient(sp.URL + "/v3/")
+	th.AssertNoErr(t, err)
+
+	err = federation.Authenticate(remote, federation.AuthOptions{
+		Method: federation.Keystone2Keystone{
+			LocalClient:       localIdentity,
+			ServiceProviderID: "mysp",
+		},
+		Scope: tokens.Scope{ProjectID: "fed-project"},
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "scoped-token", remote.TokenID)
+
+	compute, err := openstack.NewComputeV2(remote, gophercloud.EndpointOpts{Region: "RegionOne"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://compute.sp.example.com/v2.1/", compute.Endpoint)
+
+	err = federation.Authenticate(remote, federation.AuthOptions{
+		Method: federation.Keystone2Keystone{
+			LocalClient:       localIdentity,
+			ServiceProviderID: "unknown",
+		},
+	})
+	if _, ok := err.(federation.ErrServiceProviderNotFound); !ok {
+		t.Errorf("expected ErrServiceProviderNotFound, got %T: %v", err, err)
+	}
+}
+
+func TestListIdentityProviders(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/identity_providers", "GET", "", http.StatusOK, ListIdentityProvidersOutput)
+
+	count := 0
+	err := federation.ListIdentityProviders(client.ServiceClient(), nil).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := federation.ExtractIdentityProviders(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []federation.IdentityProvider{ACMEIdentityProvider}, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestCreateIdentityProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/identity_providers/ACME", "PUT", CreateIdentityProviderRequest, http.StatusCreated, GetIdentityProviderOutput)
+
+	enabled := true
+	actual, err := federation.CreateIdentityProvider(client.ServiceClient(), "ACME", federation.CreateIdentityProviderOpts{
+		DomainID:    "1789d1",
+		Description: "Stores ACME identities",
+		Enabled:     &enabled,
+		RemoteIDs:   []string{"https://idp.acme.example.com/idp/shibboleth"},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ACMEIdentityProvider, *actual)
+}
+
+func TestGetIdentityProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/identity_providers/ACME", "GET", "", http.StatusOK, GetIdentityProviderOutput)
+
+	actual, err := federation.GetIdentityProvider(client.ServiceClient(), "ACME").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ACMEIdentityProvider, *actual)
+}
+
+func TestUpdateIdentityProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/identity_providers/ACME", "PATCH", UpdateIdentityProviderRequest, http.StatusOK, UpdateIdentityProviderOutput)
+
+	enabled := false
+	ttl := 60
+	actual, err := federation.UpdateIdentityProvider(client.ServiceClient(), "ACME", federation.UpdateIdentityProviderOpts{
+		Enabled:          &enabled,
+		RemoteIDs:        &[]string{},
+		AuthorizationTTL: &ttl,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, false, actual.Enabled)
+	th.CheckEquals(t, 0, len(actual.RemoteIDs))
+	th.CheckEquals(t, 60, *actual.AuthorizationTTL)
+}
+
+func TestDeleteIdentityProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/identity_providers/ACME", "DELETE", "", http.StatusNoContent, "")
+
+	err := federation.DeleteIdentityProvider(client.ServiceClient(), "ACME").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestCreateProtocol(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/identity_providers/ACME/protocols/saml2", "PUT", ProtocolRequest, http.StatusCreated, ProtocolOutput)
+
+	actual, err := federation.CreateProtocol(client.ServiceClient(), "ACME", "saml2", federation.ProtocolOpts{
+		MappingID:         "ACME-mapping",
+		RemoteIDAttribute: "Shib-Identity-Provider",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, SAML2Protocol, *actual)
+
+	_, err = federation.ProtocolOpts{}.ToProtocolMap()
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Errorf("expected ErrMissingInput for a missing MappingID, got %T: %v", err, err)
+	}
+}
+
+func TestUpdateProtocol(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/identity_providers/ACME/protocols/saml2", "PATCH", ProtocolRequest, http.StatusOK, ProtocolOutput)
+
+	actual, err := federation.UpdateProtocol(client.ServiceClient(), "ACME", "saml2", federation.ProtocolOpts{
+		MappingID:         "ACME-mapping",
+		RemoteIDAttribute: "Shib-Identity-Provider",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, SAML2Protocol, *actual)
+}
+
+func TestListProtocols(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/identity_providers/ACME/protocols", "GET", "", http.StatusOK, ListProtocolsOutput)
+
+	pages, err := federation.ListProtocols(client.ServiceClient(), "ACME").AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := federation.ExtractProtocols(pages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []federation.Protocol{{ID: "saml2", MappingID: "ACME-mapping", Links: map[string]interface{}{}}}, actual)
+}
+
+func TestDeleteProtocol(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/identity_providers/ACME/protocols/saml2", "DELETE", "", http.StatusNoContent, "")
+
+	err := federation.DeleteProtocol(client.ServiceClient(), "ACME", "saml2").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestCreateMapping(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/mappings/ACME-mapping", "PUT", MappingRequest, http.StatusCreated, MappingOutput)
+
+	actual, err := federation.CreateMapping(client.ServiceClient(), "ACME-mapping", federation.MappingOpts{
+		Rules:         ACMERules,
+		SchemaVersion: "2.0",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "ACME-mapping", actual.ID)
+	th.CheckEquals(t, "2.0", actual.SchemaVersion)
+	th.CheckDeepEquals(t, ACMERules, actual.Rules)
+}
+
+func TestGetMapping(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/mappings/ACME-mapping", "GET", "", http.StatusOK, MappingOutput)
+
+	actual, err := federation.GetMapping(client.ServiceClient(), "ACME-mapping").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ACMERules, actual.Rules)
+}
+
+func TestUpdateMapping(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/mappings/ACME-mapping", "PATCH", MappingRequest, http.StatusOK, MappingOutput)
+
+	actual, err := federation.UpdateMapping(client.ServiceClient(), "ACME-mapping", federation.MappingOpts{
+		Rules:         ACMERules,
+		SchemaVersion: "2.0",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ACMERules, actual.Rules)
+}
+
+func TestListMappings(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/mappings", "GET", "", http.StatusOK, ListMappingsOutput)
+
+	pages, err := federation.ListMappings(client.ServiceClient()).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := federation.ExtractMappings(pages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []federation.Mapping{
+		{
+			ID: "ACME-mapping",
+			Rules: []federation.MappingRule{
+				{
+					Local:  []federation.RuleLocal{{Group: &federation.Group{ID: "0cd5e9"}}},
+					Remote: []federation.RuleRemote{{Type: "UserName", NotAnyOf: []string{"root"}}},
+				},
+			},
+			SchemaVersion: "1.0",
+		},
+	}, actual)
+}
+
+func TestDeleteMapping(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/mappings/ACME-mapping", "DELETE", "", http.StatusNoContent, "")
+
+	err := federation.DeleteMapping(client.ServiceClient(), "ACME-mapping").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestCreateServiceProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/service_providers/BETA", "PUT", CreateServiceProviderRequest, http.StatusCreated, ServiceProviderOutput)
+
+	enabled := true
+	actual, err := federation.CreateServiceProvider(client.ServiceClient(), "BETA", federation.CreateServiceProviderOpts{
+		AuthURL:     BetaServiceProvider.AuthURL,
+		SPURL:       BetaServiceProvider.SPURL,
+		Description: "Remote region",
+		Enabled:     &enabled,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, BetaServiceProvider, *actual)
+}
+
+func TestGetServiceProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/service_providers/BETA", "GET", "", http.StatusOK, ServiceProviderOutput)
+
+	actual, err := federation.GetServiceProvider(client.ServiceClient(), "BETA").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, BetaServiceProvider, *actual)
+}
+
+func TestUpdateServiceProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/service_providers/BETA", "PATCH", `{"service_provider": {"description": ""}}`, http.StatusOK, ServiceProviderOutput)
+
+	description := ""
+	_, err := federation.UpdateServiceProvider(client.ServiceClient(), "BETA", federation.UpdateServiceProviderOpts{
+		Description: &description,
+	}).Extract()
+	th.AssertNoErr(t, err)
+}
+
+func TestListServiceProviders(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/service_providers", "GET", "", http.StatusOK, ListServiceProvidersOutput)
+
+	enabled := true
+	pages, err := federation.ListServiceProviders(client.ServiceClient(), federation.ListServiceProvidersOpts{Enabled: &enabled}).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := federation.ExtractServiceProviders(pages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []federation.ServiceProvider{BetaServiceProvider}, actual)
+}
+
+func TestDeleteServiceProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleResource(t, "/OS-FEDERATION/service_providers/BETA", "DELETE", "", http.StatusNoContent, "")
+
+	err := federation.DeleteServiceProvider(client.ServiceClient(), "BETA").ExtractErr()
+	th.AssertNoErr(t, err)
+}
@@ -0,0 +1,45 @@
+package federation
+
+import "github.com/gophercloud/gophercloud"
+
+const rootPath = "OS-FEDERATION"
+
+func federatedAuthURL(c *gophercloud.ServiceClient, idp, protocol string) string {
+	return c.ServiceURL(rootPath, "identity_providers", idp, "protocols", protocol, "auth")
+}
+
+func ecpAssertionURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("auth", rootPath, "saml2", "ecp")
+}
+
+func identityProvidersURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(rootPath, "identity_providers")
+}
+
+func identityProviderURL(c *gophercloud.ServiceClient, idpID string) string {
+	return c.ServiceURL(rootPath, "identity_providers", idpID)
+}
+
+func protocolsURL(c *gophercloud.ServiceClient, idpID string) string {
+	return c.ServiceURL(rootPath, "identity_providers", idpID, "protocols")
+}
+
+func protocolURL(c *gophercloud.ServiceClient, idpID, protocolID string) string {
+	return c.ServiceURL(rootPath, "identity_providers", idpID, "protocols", protocolID)
+}
+
+func mappingsURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(rootPath, "mappings")
+}
+
+func mappingURL(c *gophercloud.ServiceClient, mappingID string) string {
+	return c.ServiceURL(rootPath, "mappings", mappingID)
+}
+
+func serviceProvidersURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(rootPath, "service_providers")
+}
+
+func serviceProviderURL(c *gophercloud.ServiceClient, spID string) string {
+	return c.ServiceURL(rootPath, "service_providers", spID)
+}
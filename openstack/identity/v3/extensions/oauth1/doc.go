@@ -0,0 +1,105 @@
+/*
+Package oauth1 enables management of OpenStack OAuth1 consumers and tokens,
+and authentication with OAuth1 access tokens. A consumer obtains a request
+token, a user authorizes it with some of their roles on a project, and the
+consumer exchanges it for an access token it can then authenticate with.
+
+Example to Create an OAuth1 Consumer
+
+	createConsumerOpts := oauth1.CreateConsumerOpts{
+		Description: "My consumer",
+	}
+
+	consumer, err := oauth1.CreateConsumer(identityClient, createConsumerOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	// The secret is only returned when the consumer is created.
+	fmt.Printf("Consumer: %+v\n", consumer)
+
+Example to Request an Unauthorized OAuth1 Token
+
+	requestTokenOpts := oauth1.RequestTokenOpts{
+		OAuthConsumerKey:    consumer.ID,
+		OAuthConsumerSecret: consumer.Secret,
+		RequestedProjectID:  "ce9e07",
+	}
+
+	requestToken, err := oauth1.RequestToken(identityClient, requestTokenOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authorize an Unauthorized OAuth1 Token
+
+	authorizeTokenOpts := oauth1.AuthorizeTokenOpts{
+		Roles: []oauth1.Role{
+			{Name: "member"},
+		},
+	}
+
+	authToken, err := oauth1.AuthorizeToken(identityClient, requestToken.OAuthToken, authorizeTokenOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create an OAuth1 Access Token
+
+	accessTokenOpts := oauth1.CreateAccessTokenOpts{
+		OAuthConsumerKey:    consumer.ID,
+		OAuthConsumerSecret: consumer.Secret,
+		OAuthToken:          requestToken.OAuthToken,
+		OAuthTokenSecret:    requestToken.OAuthTokenSecret,
+		OAuthVerifier:       authToken.OAuthVerifier,
+	}
+
+	accessToken, err := oauth1.CreateAccessToken(identityClient, accessTokenOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authenticate a Client with an OAuth1 Access Token
+
+	authOptions := &oauth1.AuthOptions{
+		OAuthConsumerKey:    consumer.ID,
+		OAuthConsumerSecret: consumer.Secret,
+		OAuthToken:          accessToken.OAuthToken,
+		OAuthTokenSecret:    accessToken.OAuthTokenSecret,
+		AllowReauth:         true,
+	}
+
+	provider, err := openstack.NewClient("https://example.com:5000/v3")
+	if err != nil {
+		panic(err)
+	}
+
+	err = openstack.AuthenticateV3(provider, authOptions, gophercloud.EndpointOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the OAuth1 Access Tokens of a User
+
+	allPages, err := oauth1.ListAccessTokens(identityClient, "ce9e07").AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	accessTokens, err := oauth1.ExtractAccessTokens(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, accessToken := range accessTokens {
+		fmt.Printf("Access Token: %+v\n", accessToken)
+	}
+
+Example to Revoke an OAuth1 Access Token
+
+	err := oauth1.RevokeAccessToken(identityClient, "ce9e07", "6be26a").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package oauth1
@@ -0,0 +1,18 @@
+package oauth1
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrUnsupportedSignatureMethod is returned when a request is to be signed
+// with a method other than HMACSHA1 or PLAINTEXT.
+type ErrUnsupportedSignatureMethod struct {
+	gophercloud.BaseError
+	Method SignatureMethod
+}
+
+func (e ErrUnsupportedSignatureMethod) Error() string {
+	return fmt.Sprintf("Unsupported OAuth1 signature method %q", e.Method)
+}
@@ -0,0 +1,444 @@
+package oauth1
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// AuthOptions authenticates with an OAuth1 access token. It implements
+// tokens.AuthOptionsBuilder, so it can be passed to openstack.AuthenticateV3
+// or tokens.Create. The resulting token is scoped to the project the access
+// token was authorized for.
+type AuthOptions struct {
+	// OAuthConsumerKey is the ID of the consumer.
+	OAuthConsumerKey string `required:"true"`
+
+	// OAuthConsumerSecret is the secret of the consumer.
+	OAuthConsumerSecret string `required:"true"`
+
+	// OAuthToken is the ID of the access token.
+	OAuthToken string `required:"true"`
+
+	// OAuthTokenSecret is the secret of the access token.
+	OAuthTokenSecret string `required:"true"`
+
+	// OAuthSignatureMethod is the method the request is signed with. It
+	// defaults to HMACSHA1.
+	OAuthSignatureMethod SignatureMethod
+
+	// OAuthTimestamp is the time the request is signed at. It defaults to
+	// the current time, and should only be set for testing.
+	OAuthTimestamp *time.Time `json:"-"`
+
+	// OAuthNonce is the random string that makes the request unique. A new
+	// nonce is generated if it's empty, and it should only be set for
+	// testing.
+	OAuthNonce string
+
+	// AllowReauth allows Gophercloud to authenticate again when the token
+	// expires.
+	AllowReauth bool
+}
+
+// ToTokenV3CreateMap builds the body of an OAuth1 authentication request.
+func (opts AuthOptions) ToTokenV3CreateMap(map[string]interface{}) (map[string]interface{}, error) {
+	// Check the required fields.
+	if _, err := gophercloud.BuildRequestBody(opts, ""); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"oauth1"},
+				"oauth1":  map[string]interface{}{},
+			},
+		},
+	}, nil
+}
+
+// ToTokenV3ScopeMap returns no scope, as the token is scoped to the project
+// of the access token.
+func (opts AuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
+	return nil, nil
+}
+
+// CanReauth returns whether reauthentication is allowed.
+func (opts AuthOptions) CanReauth() bool {
+	return opts.AllowReauth
+}
+
+// ToTokenV3HeadersMap signs the authentication request sent to url.
+func (opts AuthOptions) ToTokenV3HeadersMap(url string) (map[string]string, error) {
+	s := signer{
+		consumerKey:    opts.OAuthConsumerKey,
+		consumerSecret: opts.OAuthConsumerSecret,
+		token:          opts.OAuthToken,
+		tokenSecret:    opts.OAuthTokenSecret,
+		method:         opts.OAuthSignatureMethod,
+		timestamp:      opts.OAuthTimestamp,
+		nonce:          opts.OAuthNonce,
+	}
+	authorization, err := s.authorization("POST", url)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"Authorization": authorization}, nil
+}
+
+// ListConsumers enumerates the OAuth1 consumers.
+func ListConsumers(client *gophercloud.ServiceClient) pagination.Pager {
+	return pagination.NewPager(client, consumersURL(client), func(r pagination.PageResult) pagination.Page {
+		return ConsumersPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// GetConsumer retrieves details on a single consumer, by ID.
+func GetConsumer(client *gophercloud.ServiceClient, id string) (r GetConsumerResult) {
+	resp, err := client.Get(consumerURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateConsumerOptsBuilder allows extensions to add additional parameters
+// to the CreateConsumer request.
+type CreateConsumerOptsBuilder interface {
+	ToOAuth1CreateConsumerMap() (map[string]interface{}, error)
+}
+
+// CreateConsumerOpts provides options used to create an OAuth1 consumer.
+type CreateConsumerOpts struct {
+	// Description is the consumer description.
+	Description string `json:"description"`
+}
+
+// ToOAuth1CreateConsumerMap formats a CreateConsumerOpts into a create
+// request.
+func (opts CreateConsumerOpts) ToOAuth1CreateConsumerMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "consumer")
+}
+
+// CreateConsumer creates a new OAuth1 consumer. Its secret is only
+// returned in the response.
+func CreateConsumer(client *gophercloud.ServiceClient, opts CreateConsumerOptsBuilder) (r CreateConsumerResult) {
+	b, err := opts.ToOAuth1CreateConsumerMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Post(consumersURL(client), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateConsumerOptsBuilder allows extensions to add additional parameters
+// to the UpdateConsumer request.
+type UpdateConsumerOptsBuilder interface {
+	ToOAuth1UpdateConsumerMap() (map[string]interface{}, error)
+}
+
+// UpdateConsumerOpts provides options used to update an OAuth1 consumer.
+type UpdateConsumerOpts struct {
+	// Description is the consumer description.
+	Description string `json:"description"`
+}
+
+// ToOAuth1UpdateConsumerMap formats an UpdateConsumerOpts into an update
+// request.
+func (opts UpdateConsumerOpts) ToOAuth1UpdateConsumerMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "consumer")
+}
+
+// UpdateConsumer updates an existing consumer.
+func UpdateConsumer(client *gophercloud.ServiceClient, id string, opts UpdateConsumerOptsBuilder) (r UpdateConsumerResult) {
+	b, err := opts.ToOAuth1UpdateConsumerMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(consumerURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteConsumer deletes an OAuth1 consumer, which revokes its access
+// tokens.
+func DeleteConsumer(client *gophercloud.ServiceClient, id string) (r DeleteConsumerResult) {
+	resp, err := client.Delete(consumerURL(client, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// RequestTokenOptsBuilder allows extensions to add additional parameters to
+// the RequestToken request.
+type RequestTokenOptsBuilder interface {
+	ToOAuth1RequestTokenHeaders(method, url string) (map[string]string, error)
+}
+
+// RequestTokenOpts provides options used to get a request token, which a
+// user then authorizes with AuthorizeToken.
+type RequestTokenOpts struct {
+	// OAuthConsumerKey is the ID of the consumer.
+	OAuthConsumerKey string `required:"true"`
+
+	// OAuthConsumerSecret is the secret of the consumer.
+	OAuthConsumerSecret string `required:"true"`
+
+	// OAuthSignatureMethod is the method the request is signed with. It
+	// defaults to HMACSHA1.
+	OAuthSignatureMethod SignatureMethod
+
+	// OAuthTimestamp is the time the request is signed at. It defaults to
+	// the current time, and should only be set for testing.
+	OAuthTimestamp *time.Time `json:"-"`
+
+	// OAuthNonce is the random string that makes the request unique. A new
+	// nonce is generated if it's empty, and it should only be set for
+	// testing.
+	OAuthNonce string
+
+	// RequestedProjectID is the ID of the project the consumer requests
+	// access to.
+	RequestedProjectID string `required:"true"`
+}
+
+// ToOAuth1RequestTokenHeaders builds the signed headers of a request token
+// request.
+func (opts RequestTokenOpts) ToOAuth1RequestTokenHeaders(method, url string) (map[string]string, error) {
+	// Check the required fields.
+	if _, err := gophercloud.BuildRequestBody(opts, ""); err != nil {
+		return nil, err
+	}
+
+	s := signer{
+		consumerKey:    opts.OAuthConsumerKey,
+		consumerSecret: opts.OAuthConsumerSecret,
+		method:         opts.OAuthSignatureMethod,
+		timestamp:      opts.OAuthTimestamp,
+		nonce:          opts.OAuthNonce,
+		// Keystone doesn't redirect, so the verifier is handed out of band.
+		params: map[string]string{"oauth_callback": "oob"},
+	}
+	authorization, err := s.authorization(method, url)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"Authorization":        authorization,
+		"Requested-Project-Id": opts.RequestedProjectID,
+	}, nil
+}
+
+// RequestToken requests an unauthorized token for a consumer. The request is
+// signed by the consumer, and doesn't carry the token of client.
+func RequestToken(client *gophercloud.ServiceClient, opts RequestTokenOptsBuilder) (r TokenResult) {
+	url := requestTokenURL(client)
+	h, err := opts.ToOAuth1RequestTokenHeaders("POST", url)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	r.Body, r.Header, r.Err = postForm(client, url, h, []int{201})
+	return
+}
+
+// AuthorizeTokenOptsBuilder allows extensions to add additional parameters
+// to the AuthorizeToken request.
+type AuthorizeTokenOptsBuilder interface {
+	ToOAuth1AuthorizeTokenMap() (map[string]interface{}, error)
+}
+
+// AuthorizeTokenOpts provides options used to authorize a request token.
+type AuthorizeTokenOpts struct {
+	// Roles are the roles on the requested project that are delegated to
+	// the consumer.
+	Roles []Role `json:"roles" required:"true"`
+}
+
+// ToOAuth1AuthorizeTokenMap formats an AuthorizeTokenOpts into an
+// authorize request.
+func (opts AuthorizeTokenOpts) ToOAuth1AuthorizeTokenMap() (map[string]interface{}, error) {
+	for _, role := range opts.Roles {
+		if role.ID == "" && role.Name == "" {
+			err := gophercloud.ErrMissingInput{}
+			err.Argument = "oauth1.AuthorizeTokenOpts.Roles.ID/oauth1.AuthorizeTokenOpts.Roles.Name"
+			return nil, err
+		}
+	}
+	return gophercloud.BuildRequestBody(opts, "")
+}
+
+// AuthorizeToken authorizes a request token on behalf of the user the
+// client is authenticated as. The returned verifier must be handed to the
+// consumer, which exchanges it for an access token with CreateAccessToken.
+func AuthorizeToken(client *gophercloud.ServiceClient, requestTokenID string, opts AuthorizeTokenOptsBuilder) (r AuthorizeTokenResult) {
+	b, err := opts.ToOAuth1AuthorizeTokenMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(authorizeTokenURL(client, requestTokenID), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateAccessTokenOptsBuilder allows extensions to add additional
+// parameters to the CreateAccessToken request.
+type CreateAccessTokenOptsBuilder interface {
+	ToOAuth1CreateAccessTokenHeaders(method, url string) (map[string]string, error)
+}
+
+// CreateAccessTokenOpts provides options used to exchange an authorized
+// request token for an access token.
+type CreateAccessTokenOpts struct {
+	// OAuthConsumerKey is the ID of the consumer.
+	OAuthConsumerKey string `required:"true"`
+
+	// OAuthConsumerSecret is the secret of the consumer.
+	OAuthConsumerSecret string `required:"true"`
+
+	// OAuthToken is the ID of the request token.
+	OAuthToken string `required:"true"`
+
+	// OAuthTokenSecret is the secret of the request token.
+	OAuthTokenSecret string `required:"true"`
+
+	// OAuthVerifier is the verifier returned by AuthorizeToken.
+	OAuthVerifier string `required:"true"`
+
+	// OAuthSignatureMethod is the method the request is signed with. It
+	// defaults to HMACSHA1.
+	OAuthSignatureMethod SignatureMethod
+
+	// OAuthTimestamp is the time the request is signed at. It defaults to
+	// the current time, and should only be set for testing.
+	OAuthTimestamp *time.Time `json:"-"`
+
+	// OAuthNonce is the random string that makes the request unique. A new
+	// nonce is generated if it's empty, and it should only be set for
+	// testing.
+	OAuthNonce string
+}
+
+// ToOAuth1CreateAccessTokenHeaders builds the signed headers of an access
+// token request.
+func (opts CreateAccessTokenOpts) ToOAuth1CreateAccessTokenHeaders(method, url string) (map[string]string, error) {
+	// Check the required fields.
+	if _, err := gophercloud.BuildRequestBody(opts, ""); err != nil {
+		return nil, err
+	}
+
+	s := signer{
+		consumerKey:    opts.OAuthConsumerKey,
+		consumerSecret: opts.OAuthConsumerSecret,
+		token:          opts.OAuthToken,
+		tokenSecret:    opts.OAuthTokenSecret,
+		method:         opts.OAuthSignatureMethod,
+		timestamp:      opts.OAuthTimestamp,
+		nonce:          opts.OAuthNonce,
+		params:         map[string]string{"oauth_verifier": opts.OAuthVerifier},
+	}
+	authorization, err := s.authorization(method, url)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"Authorization": authorization}, nil
+}
+
+// CreateAccessToken exchanges an authorized request token for an access
+// token, which the consumer authenticates with using AuthOptions. Like
+// RequestToken, the request doesn't carry the token of client.
+func CreateAccessToken(client *gophercloud.ServiceClient, opts CreateAccessTokenOptsBuilder) (r TokenResult) {
+	url := createAccessTokenURL(client)
+	h, err := opts.ToOAuth1CreateAccessTokenHeaders("POST", url)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	r.Body, r.Header, r.Err = postForm(client, url, h, []int{201})
+	return
+}
+
+// postForm sends a signed OAuth1 request and returns the form-encoded
+// response body.
+func postForm(client *gophercloud.ServiceClient, url string, headers map[string]string, okCodes []int) (string, map[string][]string, error) {
+	resp, err := newUnauthenticatedClient(client).Post(url, nil, nil, &gophercloud.RequestOpts{
+		MoreHeaders: headers,
+		OkCodes:     okCodes,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	return string(b), resp.Header, err
+}
+
+// newUnauthenticatedClient returns a copy of client with a provider that has
+// no token. OAuth1 requests are authenticated by their signature, so they
+// mustn't carry the X-Auth-Token of client, and a 401 mustn't trigger a
+// reauthentication that would replay the request with the same nonce.
+func newUnauthenticatedClient(client *gophercloud.ServiceClient) *gophercloud.ServiceClient {
+	pc := client.ProviderClient
+	c := *client
+	c.ProviderClient = &gophercloud.ProviderClient{
+		IdentityBase:     pc.IdentityBase,
+		IdentityEndpoint: pc.IdentityEndpoint,
+		HTTPClient:       pc.HTTPClient,
+		UserAgent:        pc.UserAgent,
+		Context:          pc.Context,
+		RetryPolicy:      pc.RetryPolicy,
+		Logger:           pc.Logger,
+		LogBodies:        pc.LogBodies,
+	}
+	return &c
+}
+
+// GetAccessToken retrieves details on a single access token of a user, by
+// ID.
+func GetAccessToken(client *gophercloud.ServiceClient, userID, id string) (r GetAccessTokenResult) {
+	resp, err := client.Get(userAccessTokenURL(client, userID, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// RevokeAccessToken revokes an access token of a user.
+func RevokeAccessToken(client *gophercloud.ServiceClient, userID, id string) (r RevokeAccessTokenResult) {
+	resp, err := client.Delete(userAccessTokenURL(client, userID, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListAccessTokens enumerates the access tokens a user authorized.
+func ListAccessTokens(client *gophercloud.ServiceClient, userID string) pagination.Pager {
+	url := userAccessTokensURL(client, userID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return AccessTokensPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// ListAccessTokenRoles enumerates the roles delegated by an access token.
+func ListAccessTokenRoles(client *gophercloud.ServiceClient, userID, id string) pagination.Pager {
+	url := userAccessTokenRolesURL(client, userID, id)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return AccessTokenRolesPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// GetAccessTokenRole retrieves details on a single role delegated by an
+// access token.
+func GetAccessTokenRole(client *gophercloud.ServiceClient, userID, id, roleID string) (r GetAccessTokenRoleResult) {
+	resp, err := client.Get(userAccessTokenRoleURL(client, userID, id, roleID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
@@ -0,0 +1,283 @@
+package oauth1
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Consumer represents a delegated authorization request between two
+// identities.
+type Consumer struct {
+	// ID is the unique ID of the consumer. It is also its OAuth1 key.
+	ID string `json:"id"`
+
+	// Secret is the secret of the consumer. It is only returned when the
+	// consumer is created.
+	Secret string `json:"secret"`
+
+	// Description is the consumer description.
+	Description string `json:"description"`
+
+	// Links contains referencing links to the consumer.
+	Links map[string]interface{} `json:"links"`
+}
+
+type consumerResult struct {
+	gophercloud.Result
+}
+
+// CreateConsumerResult is the response from a CreateConsumer operation. Call
+// its Extract method to interpret it as a Consumer.
+type CreateConsumerResult struct {
+	consumerResult
+}
+
+// GetConsumerResult is the response from a GetConsumer operation. Call its
+// Extract method to interpret it as a Consumer.
+type GetConsumerResult struct {
+	consumerResult
+}
+
+// UpdateConsumerResult is the response from an UpdateConsumer operation.
+// Call its Extract method to interpret it as a Consumer.
+type UpdateConsumerResult struct {
+	consumerResult
+}
+
+// DeleteConsumerResult is the response from a DeleteConsumer operation. Call
+// its ExtractErr to determine if the request succeeded or failed.
+type DeleteConsumerResult struct {
+	gophercloud.ErrResult
+}
+
+// Extract interprets any consumerResult as a Consumer.
+func (r consumerResult) Extract() (*Consumer, error) {
+	var s struct {
+		Consumer *Consumer `json:"consumer"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Consumer, err
+}
+
+// ConsumersPage is a single page of Consumer results.
+type ConsumersPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of Consumers contains any
+// results.
+func (r ConsumersPage) IsEmpty() (bool, error) {
+	consumers, err := ExtractConsumers(r)
+	return len(consumers) == 0, err
+}
+
+// ExtractConsumers returns a slice of Consumers contained in a single page
+// of results.
+func ExtractConsumers(r pagination.Page) ([]Consumer, error) {
+	var s struct {
+		Consumers []Consumer `json:"consumers"`
+	}
+	err := (r.(ConsumersPage)).ExtractInto(&s)
+	return s.Consumers, err
+}
+
+// Token is an OAuth1 request token or access token.
+type Token struct {
+	// OAuthToken is the ID of the token.
+	OAuthToken string
+
+	// OAuthTokenSecret is the secret of the token.
+	OAuthTokenSecret string
+
+	// OAuthExpiresAt is the time the token expires at. It is nil for a
+	// token that doesn't expire.
+	OAuthExpiresAt *time.Time
+}
+
+// TokenResult is the response from a RequestToken or CreateAccessToken
+// operation. Call its Extract method to interpret it as a Token.
+type TokenResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a TokenResult as a Token. Keystone returns the token
+// as a form-encoded body rather than as JSON.
+func (r TokenResult) Extract() (*Token, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	body, _ := r.Body.(string)
+	values, err := url.ParseQuery(body)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &Token{
+		OAuthToken:       values.Get("oauth_token"),
+		OAuthTokenSecret: values.Get("oauth_token_secret"),
+	}
+	if v := values.Get("oauth_expires_at"); v != "" {
+		t, err := time.Parse(gophercloud.RFC3339Milli, v)
+		if err != nil {
+			return nil, err
+		}
+		token.OAuthExpiresAt = &t
+	}
+	return token, nil
+}
+
+// AuthorizedToken is the result of the authorization of a request token.
+type AuthorizedToken struct {
+	// OAuthVerifier is the verifier the consumer exchanges the request token
+	// for an access token with.
+	OAuthVerifier string `json:"oauth_verifier"`
+}
+
+// AuthorizeTokenResult is the response from an AuthorizeToken operation.
+// Call its Extract method to interpret it as an AuthorizedToken.
+type AuthorizeTokenResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets an AuthorizeTokenResult as an AuthorizedToken.
+func (r AuthorizeTokenResult) Extract() (*AuthorizedToken, error) {
+	var s struct {
+		Token *AuthorizedToken `json:"token"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Token, err
+}
+
+// AccessToken is an OAuth1 access token, as listed for the user who
+// authorized it.
+type AccessToken struct {
+	// ID is the ID of the access token.
+	ID string `json:"id"`
+
+	// ConsumerID is the ID of the consumer the access token was issued to.
+	ConsumerID string `json:"consumer_id"`
+
+	// ProjectID is the ID of the project the access token grants access to.
+	ProjectID string `json:"project_id"`
+
+	// AuthorizingUserID is the ID of the user who authorized the access
+	// token.
+	AuthorizingUserID string `json:"authorizing_user_id"`
+
+	// ExpiresAt is the zero time for an access token that doesn't expire.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Links contains referencing links to the access token.
+	Links map[string]interface{} `json:"links"`
+}
+
+// GetAccessTokenResult is the response from a GetAccessToken operation.
+// Call its Extract method to interpret it as an AccessToken.
+type GetAccessTokenResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a GetAccessTokenResult as an AccessToken.
+func (r GetAccessTokenResult) Extract() (*AccessToken, error) {
+	var s struct {
+		AccessToken *AccessToken `json:"access_token"`
+	}
+	err := r.ExtractInto(&s)
+	return s.AccessToken, err
+}
+
+// RevokeAccessTokenResult is the response from a RevokeAccessToken
+// operation. Call its ExtractErr to determine if the request succeeded or
+// failed.
+type RevokeAccessTokenResult struct {
+	gophercloud.ErrResult
+}
+
+// AccessTokensPage is a single page of AccessToken results.
+type AccessTokensPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of AccessTokens contains any
+// results.
+func (r AccessTokensPage) IsEmpty() (bool, error) {
+	accessTokens, err := ExtractAccessTokens(r)
+	return len(accessTokens) == 0, err
+}
+
+// ExtractAccessTokens returns a slice of AccessTokens contained in a single
+// page of results.
+func ExtractAccessTokens(r pagination.Page) ([]AccessToken, error) {
+	var s struct {
+		AccessTokens []AccessToken `json:"access_tokens"`
+	}
+	err := (r.(AccessTokensPage)).ExtractInto(&s)
+	return s.AccessTokens, err
+}
+
+// Role is a role delegated by an OAuth1 access token. When authorizing a
+// request token, a role is identified by its ID or by its name.
+type Role struct {
+	// ID is the ID of the role.
+	ID string `json:"id,omitempty"`
+
+	// Name is the name of the role.
+	Name string `json:"name,omitempty"`
+}
+
+// AccessTokenRole is a role delegated by an OAuth1 access token, as listed
+// for the access token.
+type AccessTokenRole struct {
+	// ID is the ID of the role.
+	ID string `json:"id"`
+
+	// Name is the name of the role.
+	Name string `json:"name"`
+
+	// DomainID is the ID of the domain of a domain-specific role.
+	DomainID string `json:"domain_id"`
+
+	// Links contains referencing links to the role.
+	Links map[string]interface{} `json:"links"`
+}
+
+// AccessTokenRolesPage is a single page of AccessTokenRole results.
+type AccessTokenRolesPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of AccessTokenRoles contains any
+// results.
+func (r AccessTokenRolesPage) IsEmpty() (bool, error) {
+	roles, err := ExtractAccessTokenRoles(r)
+	return len(roles) == 0, err
+}
+
+// ExtractAccessTokenRoles returns a slice of AccessTokenRoles contained in a
+// single page of results.
+func ExtractAccessTokenRoles(r pagination.Page) ([]AccessTokenRole, error) {
+	var s struct {
+		Roles []AccessTokenRole `json:"roles"`
+	}
+	err := (r.(AccessTokenRolesPage)).ExtractInto(&s)
+	return s.Roles, err
+}
+
+// GetAccessTokenRoleResult is the response from a GetAccessTokenRole
+// operation. Call its Extract method to interpret it as an AccessTokenRole.
+type GetAccessTokenRoleResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a GetAccessTokenRoleResult as an AccessTokenRole.
+func (r GetAccessTokenRoleResult) Extract() (*AccessTokenRole, error) {
+	var s struct {
+		Role *AccessTokenRole `json:"role"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Role, err
+}
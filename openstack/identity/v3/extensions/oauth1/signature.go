@@ -0,0 +1,170 @@
+package oauth1
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureMethod is the method OAuth1 requests are signed with.
+type SignatureMethod string
+
+const (
+	// HMACSHA1 signs requests with HMAC-SHA1. It is the default.
+	HMACSHA1 SignatureMethod = "HMAC-SHA1"
+
+	// PLAINTEXT sends the secrets as the signature. It must only be used
+	// over TLS.
+	PLAINTEXT SignatureMethod = "PLAINTEXT"
+)
+
+// signer holds the credentials and parameters an OAuth1 request is signed
+// with, as described in RFC 5849.
+type signer struct {
+	consumerKey    string
+	consumerSecret string
+	token          string
+	tokenSecret    string
+	method         SignatureMethod
+	timestamp      *time.Time
+	nonce          string
+
+	// params are additional oauth_ protocol parameters, such as
+	// oauth_callback and oauth_verifier.
+	params map[string]string
+}
+
+// authorization returns the value of the Authorization header of a request
+// with the given method and URL.
+func (s signer) authorization(method, rawURL string) (string, error) {
+	if s.method == "" {
+		s.method = HMACSHA1
+	}
+	if s.method != HMACSHA1 && s.method != PLAINTEXT {
+		return "", ErrUnsupportedSignatureMethod{Method: s.method}
+	}
+
+	timestamp := time.Now()
+	if s.timestamp != nil {
+		timestamp = *s.timestamp
+	}
+	nonce := s.nonce
+	if nonce == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		nonce = hex.EncodeToString(b)
+	}
+
+	params := map[string]string{
+		"oauth_consumer_key":     s.consumerKey,
+		"oauth_nonce":            nonce,
+		"oauth_signature_method": string(s.method),
+		"oauth_timestamp":        strconv.FormatInt(timestamp.Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if s.token != "" {
+		params["oauth_token"] = s.token
+	}
+	for k, v := range s.params {
+		params[k] = v
+	}
+
+	key := percentEncode(s.consumerSecret) + "&" + percentEncode(s.tokenSecret)
+	if s.method == PLAINTEXT {
+		params["oauth_signature"] = key
+	} else {
+		base, err := signatureBase(method, rawURL, params)
+		if err != nil {
+			return "", err
+		}
+		params["oauth_signature"] = hmacSHA1(key, base)
+	}
+
+	names := make([]string, 0, len(params))
+	for k := range params {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, k := range names {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, percentEncode(k), percentEncode(params[k]))
+	}
+	return "OAuth " + strings.Join(pairs, ", "), nil
+}
+
+// signatureBase returns the signature base string of RFC 5849, section
+// 3.4.1. The query parameters of rawURL are signed along with the protocol
+// parameters.
+func signatureBase(method, rawURL string, params map[string]string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	if scheme == "http" && strings.HasSuffix(host, ":80") || scheme == "https" && strings.HasSuffix(host, ":443") {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+	baseURL := scheme + "://" + host + u.EscapedPath()
+
+	// The parameters are sorted by their encoded names, and then by their
+	// encoded values, as described in section 3.4.1.3.2.
+	var pairs [][2]string
+	for k, vs := range u.Query() {
+		for _, v := range vs {
+			pairs = append(pairs, [2]string{percentEncode(k), percentEncode(v)})
+		}
+	}
+	for k, v := range params {
+		pairs = append(pairs, [2]string{percentEncode(k), percentEncode(v)})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	normalized := make([]string, len(pairs))
+	for i, p := range pairs {
+		normalized[i] = p[0] + "=" + p[1]
+	}
+
+	return strings.ToUpper(method) + "&" + percentEncode(baseURL) + "&" + percentEncode(strings.Join(normalized, "&")), nil
+}
+
+// hmacSHA1 returns the HMAC-SHA1 signature of a signature base string, as
+// described in RFC 5849, section 3.4.2.
+func hmacSHA1(key, base string) string {
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(base))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// percentEncode encodes s as required by RFC 5849, section 3.6: all but the
+// unreserved characters of RFC 3986 are encoded.
+func percentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,35 @@
+package oauth1
+
+import (
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+// TestSignatureRFC5849 signs the example request of RFC 5849, section 1.2.
+func TestSignatureRFC5849(t *testing.T) {
+	params := map[string]string{
+		"oauth_consumer_key":     "dpf43f3p2l4k3l03",
+		"oauth_token":            "nnch734d00sl2jdk",
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        "137131202",
+		"oauth_nonce":            "chapoH",
+	}
+
+	base, err := signatureBase("GET", "http://photos.example.net/photos?file=vacation.jpg&size=original", params)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "GET&http%3A%2F%2Fphotos.example.net%2Fphotos&file%3Dvacation.jpg%26oauth_consumer_key%3Ddpf43f3p2l4k3l03%26oauth_nonce%3DchapoH%26oauth_signature_method%3DHMAC-SHA1%26oauth_timestamp%3D137131202%26oauth_token%3Dnnch734d00sl2jdk%26size%3Doriginal", base)
+	th.AssertEquals(t, "MdpQcU8iPSUjWoN/UDMsK2sui9I=", hmacSHA1("kd94hf93k423kf44&pfkkdhi9sl3r4s00", base))
+}
+
+func TestSignatureBaseSortsParameters(t *testing.T) {
+	// The repeated a3 parameter is sorted by value.
+	base, err := signatureBase("GET", "http://example.com/request?a3=a&a2=r%20b&a3=2+q", nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "GET&http%3A%2F%2Fexample.com%2Frequest&a2%3Dr%2520b%26a3%3D2%2520q%26a3%3Da", base)
+
+	// A name sorts before the longer names it's a prefix of.
+	base, err = signatureBase("GET", "http://example.com/request?a-b=1&a=2", nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "GET&http%3A%2F%2Fexample.com%2Frequest&a%3D2%26a-b%3D1", base)
+}
@@ -0,0 +1,2 @@
+// oauth1 unit tests
+package testing
@@ -0,0 +1,478 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/oauth1"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+// CreateConsumerRequest provides the input to a CreateConsumer request.
+const CreateConsumerRequest = `
+{
+  "consumer": {
+    "description": "My consumer"
+  }
+}
+`
+
+// CreateConsumerResponse provides the output of a CreateConsumer request.
+const CreateConsumerResponse = `
+{
+  "consumer": {
+    "secret": "a7e8b2",
+    "description": "My consumer",
+    "id": "7fea2d",
+    "links": {
+      "self": "http://example.com/identity/v3/OS-OAUTH1/consumers/7fea2d"
+    }
+  }
+}
+`
+
+// GetConsumerResponse provides the output of a GetConsumer request.
+const GetConsumerResponse = `
+{
+  "consumer": {
+    "description": "My consumer",
+    "id": "7fea2d",
+    "links": {
+      "self": "http://example.com/identity/v3/OS-OAUTH1/consumers/7fea2d"
+    }
+  }
+}
+`
+
+// UpdateConsumerRequest provides the input to an UpdateConsumer request.
+const UpdateConsumerRequest = `
+{
+  "consumer": {
+    "description": "My new consumer"
+  }
+}
+`
+
+// UpdateConsumerResponse provides the output of an UpdateConsumer request.
+const UpdateConsumerResponse = `
+{
+  "consumer": {
+    "description": "My new consumer",
+    "id": "7fea2d",
+    "links": {
+      "self": "http://example.com/identity/v3/OS-OAUTH1/consumers/7fea2d"
+    }
+  }
+}
+`
+
+// ListConsumersResponse provides a single page of Consumer results.
+const ListConsumersResponse = `
+{
+  "consumers": [
+    {
+      "description": "My consumer",
+      "id": "7fea2d",
+      "links": {
+        "self": "http://example.com/identity/v3/OS-OAUTH1/consumers/7fea2d"
+      }
+    },
+    {
+      "id": "0c2a74",
+      "links": {
+        "self": "http://example.com/identity/v3/OS-OAUTH1/consumers/0c2a74"
+      }
+    }
+  ],
+  "links": {
+    "next": null,
+    "previous": null,
+    "self": "http://example.com/identity/v3/OS-OAUTH1/consumers"
+  }
+}
+`
+
+// AuthorizeTokenRequest provides the input to an AuthorizeToken request.
+const AuthorizeTokenRequest = `
+{
+  "roles": [
+    {
+      "id": "a3b29b"
+    },
+    {
+      "name": "member"
+    }
+  ]
+}
+`
+
+// AuthorizeTokenResponse provides the output of an AuthorizeToken request.
+const AuthorizeTokenResponse = `
+{
+  "token": {
+    "oauth_verifier": "8171"
+  }
+}
+`
+
+// GetAccessTokenResponse provides the output of a GetAccessToken request.
+const GetAccessTokenResponse = `
+{
+  "access_token": {
+    "consumer_id": "7fea2d",
+    "id": "6be26a",
+    "expires_at": "2013-09-11T06:07:51.501805Z",
+    "links": {
+      "roles": "http://example.com/identity/v3/users/ce9e07/OS-OAUTH1/access_tokens/6be26a/roles",
+      "self": "http://example.com/identity/v3/users/ce9e07/OS-OAUTH1/access_tokens/6be26a"
+    },
+    "project_id": "b9fca3",
+    "authorizing_user_id": "ce9e07"
+  }
+}
+`
+
+// ListAccessTokensResponse provides a single page of AccessToken results.
+const ListAccessTokensResponse = `
+{
+  "access_tokens": [
+    {
+      "consumer_id": "7fea2d",
+      "id": "6be26a",
+      "expires_at": "2013-09-11T06:07:51.501805Z",
+      "links": {
+        "roles": "http://example.com/identity/v3/users/ce9e07/OS-OAUTH1/access_tokens/6be26a/roles",
+        "self": "http://example.com/identity/v3/users/ce9e07/OS-OAUTH1/access_tokens/6be26a"
+      },
+      "project_id": "b9fca3",
+      "authorizing_user_id": "ce9e07"
+    }
+  ],
+  "links": {
+    "next": null,
+    "previous": null,
+    "self": "http://example.com/identity/v3/users/ce9e07/OS-OAUTH1/access_tokens"
+  }
+}
+`
+
+// ListAccessTokenRolesResponse provides a single page of AccessTokenRole
+// results.
+const ListAccessTokenRolesResponse = `
+{
+  "roles": [
+    {
+      "id": "5ad150",
+      "domain_id": "7cf37b",
+      "links": {
+        "self": "http://example.com/identity/v3/roles/5ad150"
+      },
+      "name": "admin"
+    },
+    {
+      "id": "a62eb6",
+      "domain_id": "7cf37b",
+      "links": {
+        "self": "http://example.com/identity/v3/roles/a62eb6"
+      },
+      "name": "member"
+    }
+  ],
+  "links": {
+    "next": null,
+    "previous": null,
+    "self": "http://example.com/identity/v3/users/ce9e07/OS-OAUTH1/access_tokens/6be26a/roles"
+  }
+}
+`
+
+// GetAccessTokenRoleResponse provides the output of a GetAccessTokenRole
+// request.
+const GetAccessTokenRoleResponse = `
+{
+  "role": {
+    "id": "5ad150",
+    "domain_id": "7cf37b",
+    "links": {
+      "self": "http://example.com/identity/v3/roles/5ad150"
+    },
+    "name": "admin"
+  }
+}
+`
+
+// OAuth1TokenResponse provides the output of an OAuth1 authentication.
+const OAuth1TokenResponse = `
+{
+  "token": {
+    "methods": [
+      "oauth1"
+    ],
+    "roles": [
+      {
+        "id": "5ad150",
+        "name": "admin"
+      }
+    ],
+    "expires_at": "2017-11-08T22:59:26.000000Z",
+    "project": {
+      "domain": {
+        "id": "default",
+        "name": "Default"
+      },
+      "id": "b9fca3",
+      "name": "admin"
+    },
+    "OS-OAUTH1": {
+      "consumer_id": "7fea2d",
+      "access_token_id": "accd36"
+    },
+    "user": {
+      "domain": {
+        "id": "default",
+        "name": "Default"
+      },
+      "id": "ce9e07",
+      "name": "demo"
+    }
+  }
+}
+`
+
+// Consumer is the consumer fixture, as returned by GetConsumer.
+var Consumer = oauth1.Consumer{
+	ID:          "7fea2d",
+	Description: "My consumer",
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/OS-OAUTH1/consumers/7fea2d",
+	},
+}
+
+// UpdatedConsumer is the consumer fixture after its description was updated.
+var UpdatedConsumer = oauth1.Consumer{
+	ID:          "7fea2d",
+	Description: "My new consumer",
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/OS-OAUTH1/consumers/7fea2d",
+	},
+}
+
+// SecondConsumer is a consumer fixture without a description.
+var SecondConsumer = oauth1.Consumer{
+	ID: "0c2a74",
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/OS-OAUTH1/consumers/0c2a74",
+	},
+}
+
+// ExpectedConsumersSlice is the slice of consumers expected to be returned
+// from ListConsumersResponse.
+var ExpectedConsumersSlice = []oauth1.Consumer{Consumer, SecondConsumer}
+
+// FirstRole and SecondRole are the roles delegated by the access token.
+var FirstRole = oauth1.AccessTokenRole{
+	ID:       "5ad150",
+	DomainID: "7cf37b",
+	Name:     "admin",
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/roles/5ad150",
+	},
+}
+
+var SecondRole = oauth1.AccessTokenRole{
+	ID:       "a62eb6",
+	DomainID: "7cf37b",
+	Name:     "member",
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/roles/a62eb6",
+	},
+}
+
+// ExpectedUserAccessTokenRolesSlice is the slice of roles expected to be
+// returned from ListAccessTokenRolesResponse.
+var ExpectedUserAccessTokenRolesSlice = []oauth1.AccessTokenRole{FirstRole, SecondRole}
+
+// UserAccessToken is the access token fixture.
+var UserAccessToken = oauth1.AccessToken{
+	ID:                "6be26a",
+	ConsumerID:        "7fea2d",
+	ProjectID:         "b9fca3",
+	AuthorizingUserID: "ce9e07",
+	ExpiresAt:         time.Date(2013, time.September, 11, 6, 7, 51, 501805000, time.UTC),
+	Links: map[string]interface{}{
+		"roles": "http://example.com/identity/v3/users/ce9e07/OS-OAUTH1/access_tokens/6be26a/roles",
+		"self":  "http://example.com/identity/v3/users/ce9e07/OS-OAUTH1/access_tokens/6be26a",
+	},
+}
+
+// HandleConsumersSuccessfully creates HTTP handlers at
+// `/OS-OAUTH1/consumers` and `/OS-OAUTH1/consumers/7fea2d` on the test
+// handler mux that create, list, get, update and delete consumers.
+func HandleConsumersSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-OAUTH1/consumers", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			th.TestJSONRequest(t, r, CreateConsumerRequest)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, CreateConsumerResponse)
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, ListConsumersResponse)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/OS-OAUTH1/consumers/7fea2d", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, GetConsumerResponse)
+		case "PATCH":
+			th.TestJSONRequest(t, r, UpdateConsumerRequest)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, UpdateConsumerResponse)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
+
+// testSignedRequest checks that a request is signed with OAuth1 and that its
+// Authorization header contains the given parameters.
+func testSignedRequest(t *testing.T, r *http.Request, params ...string) {
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "OAuth ") {
+		t.Errorf("Expected an OAuth Authorization header, got %q", authorization)
+	}
+	for _, p := range params {
+		if !strings.Contains(authorization, p) {
+			t.Errorf("Expected %s in the Authorization header %q", p, authorization)
+		}
+	}
+}
+
+// HandleRequestTokenSuccessfully creates an HTTP handler at
+// `/OS-OAUTH1/request_token` on the test handler mux that responds with a
+// request token.
+func HandleRequestTokenSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-OAUTH1/request_token", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Requested-Project-Id", "b9fca3")
+		testSignedRequest(t, r, `oauth_consumer_key="7fea2d"`, `oauth_callback="oob"`, `oauth_signature_method="HMAC-SHA1"`)
+		if r.Header.Get("X-Auth-Token") != "" {
+			t.Errorf("The OAuth1 request carries an X-Auth-Token")
+		}
+
+		w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `oauth_token=29971f&oauth_token_secret=238eb8&oauth_expires_at=2013-09-11T06:07:51.501805Z`)
+	})
+}
+
+// HandleAuthorizeTokenSuccessfully creates an HTTP handler at
+// `/OS-OAUTH1/authorize/29971f` on the test handler mux that authorizes a
+// request token.
+func HandleAuthorizeTokenSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-OAUTH1/authorize/29971f", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, AuthorizeTokenRequest)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, AuthorizeTokenResponse)
+	})
+}
+
+// HandleCreateAccessTokenSuccessfully creates an HTTP handler at
+// `/OS-OAUTH1/access_token` on the test handler mux that responds with an
+// access token that doesn't expire.
+func HandleCreateAccessTokenSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-OAUTH1/access_token", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		testSignedRequest(t, r, `oauth_token="29971f"`, `oauth_verifier="8171"`)
+		if r.Header.Get("X-Auth-Token") != "" {
+			t.Errorf("The OAuth1 request carries an X-Auth-Token")
+		}
+
+		w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `oauth_token=accd36&oauth_token_secret=aa47da`)
+	})
+}
+
+// HandleUserAccessTokensSuccessfully creates HTTP handlers on the test
+// handler mux that list, get and revoke the access tokens of user ce9e07,
+// and list and get the roles they delegate.
+func HandleUserAccessTokensSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/users/ce9e07/OS-OAUTH1/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListAccessTokensResponse)
+	})
+
+	th.Mux.HandleFunc("/users/ce9e07/OS-OAUTH1/access_tokens/6be26a", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, GetAccessTokenResponse)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/users/ce9e07/OS-OAUTH1/access_tokens/6be26a/roles", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListAccessTokenRolesResponse)
+	})
+
+	th.Mux.HandleFunc("/users/ce9e07/OS-OAUTH1/access_tokens/6be26a/roles/5ad150", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, GetAccessTokenRoleResponse)
+	})
+}
+
+// HandleAuthenticateSuccessfully creates an HTTP handler at `/auth/tokens`
+// on the test handler mux that authenticates with an OAuth1 access token.
+func HandleAuthenticateSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{"auth": {"identity": {"methods": ["oauth1"], "oauth1": {}}}}`)
+		testSignedRequest(t, r, `oauth_consumer_key="7fea2d"`, `oauth_token="accd36"`)
+
+		w.Header().Set("X-Subject-Token", "6e7a16")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, OAuth1TokenResponse)
+	})
+}
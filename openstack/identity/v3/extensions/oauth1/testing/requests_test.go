@@ -0,0 +1,245 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/oauth1"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+var signedAt = time.Unix(1586804894, 0)
+
+const nonce = "71416001758914252991586795052"
+
+func TestConsumers(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleConsumersSuccessfully(t)
+
+	created, err := oauth1.CreateConsumer(client.ServiceClient(), oauth1.CreateConsumerOpts{
+		Description: "My consumer",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	expected := Consumer
+	expected.Secret = "a7e8b2"
+	th.CheckDeepEquals(t, expected, *created)
+
+	actual, err := oauth1.GetConsumer(client.ServiceClient(), "7fea2d").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, Consumer, *actual)
+
+	actual, err = oauth1.UpdateConsumer(client.ServiceClient(), "7fea2d", oauth1.UpdateConsumerOpts{
+		Description: "My new consumer",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, UpdatedConsumer, *actual)
+
+	err = oauth1.DeleteConsumer(client.ServiceClient(), "7fea2d").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestListConsumers(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleConsumersSuccessfully(t)
+
+	count := 0
+	err := oauth1.ListConsumers(client.ServiceClient()).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := oauth1.ExtractConsumers(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, ExpectedConsumersSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestRequestTokenHeaders(t *testing.T) {
+	opts := oauth1.RequestTokenOpts{
+		OAuthConsumerKey:    "7fea2d",
+		OAuthConsumerSecret: "a7e8b2",
+		OAuthTimestamp:      &signedAt,
+		OAuthNonce:          nonce,
+		RequestedProjectID:  "b9fca3",
+	}
+
+	h, err := opts.ToOAuth1RequestTokenHeaders("POST", "https://keystone.example.com/v3/OS-OAUTH1/request_token")
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{
+		"Authorization":        `OAuth oauth_callback="oob", oauth_consumer_key="7fea2d", oauth_nonce="71416001758914252991586795052", oauth_signature="IMBldkJQRqA%2B4cwkLFSTUtJZtnI%3D", oauth_signature_method="HMAC-SHA1", oauth_timestamp="1586804894", oauth_version="1.0"`,
+		"Requested-Project-Id": "b9fca3",
+	}, h)
+
+	opts.RequestedProjectID = ""
+	_, err = opts.ToOAuth1RequestTokenHeaders("POST", "https://keystone.example.com/v3/OS-OAUTH1/request_token")
+	if err == nil {
+		t.Fatal("Expected an error for the missing RequestedProjectID")
+	}
+}
+
+func TestCreateAccessTokenHeadersPlaintext(t *testing.T) {
+	opts := oauth1.CreateAccessTokenOpts{
+		OAuthConsumerKey:     "7fea2d",
+		OAuthConsumerSecret:  "a7e8b2",
+		OAuthToken:           "29971f",
+		OAuthTokenSecret:     "238eb8",
+		OAuthVerifier:        "8171",
+		OAuthSignatureMethod: oauth1.PLAINTEXT,
+		OAuthTimestamp:       &signedAt,
+		OAuthNonce:           nonce,
+	}
+
+	h, err := opts.ToOAuth1CreateAccessTokenHeaders("POST", "https://keystone.example.com/v3/OS-OAUTH1/access_token")
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{
+		"Authorization": `OAuth oauth_consumer_key="7fea2d", oauth_nonce="71416001758914252991586795052", oauth_signature="a7e8b2%26238eb8", oauth_signature_method="PLAINTEXT", oauth_timestamp="1586804894", oauth_token="29971f", oauth_verifier="8171", oauth_version="1.0"`,
+	}, h)
+}
+
+func TestAuthOptionsHeaders(t *testing.T) {
+	opts := oauth1.AuthOptions{
+		OAuthConsumerKey:    "7fea2d",
+		OAuthConsumerSecret: "a7e8b2",
+		OAuthToken:          "accd36",
+		OAuthTokenSecret:    "aa47da",
+		OAuthTimestamp:      &signedAt,
+		OAuthNonce:          nonce,
+	}
+
+	expected := map[string]string{
+		"Authorization": `OAuth oauth_consumer_key="7fea2d", oauth_nonce="71416001758914252991586795052", oauth_signature="%2FGtHKnTMcMsg5Easr%2B%2FOsub6U0I%3D", oauth_signature_method="HMAC-SHA1", oauth_timestamp="1586804894", oauth_token="accd36", oauth_version="1.0"`,
+	}
+
+	h, err := opts.ToTokenV3HeadersMap("https://keystone.example.com/v3/auth/tokens")
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, expected, h)
+
+	// The default port isn't part of the signed URL.
+	h, err = opts.ToTokenV3HeadersMap("https://KEYSTONE.example.com:443/v3/auth/tokens")
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, expected, h)
+
+	opts.OAuthSignatureMethod = "RSA-SHA1"
+	_, err = opts.ToTokenV3HeadersMap("https://keystone.example.com/v3/auth/tokens")
+	if _, ok := err.(oauth1.ErrUnsupportedSignatureMethod); !ok {
+		t.Fatalf("Expected ErrUnsupportedSignatureMethod, got %v", err)
+	}
+}
+
+func TestRequestToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleRequestTokenSuccessfully(t)
+
+	token, err := oauth1.RequestToken(client.ServiceClient(), oauth1.RequestTokenOpts{
+		OAuthConsumerKey:    "7fea2d",
+		OAuthConsumerSecret: "a7e8b2",
+		RequestedProjectID:  "b9fca3",
+	}).Extract()
+	th.AssertNoErr(t, err)
+
+	expiresAt := time.Date(2013, time.September, 11, 6, 7, 51, 501805000, time.UTC)
+	th.CheckDeepEquals(t, oauth1.Token{
+		OAuthToken:       "29971f",
+		OAuthTokenSecret: "238eb8",
+		OAuthExpiresAt:   &expiresAt,
+	}, *token)
+}
+
+func TestAuthorizeToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleAuthorizeTokenSuccessfully(t)
+
+	token, err := oauth1.AuthorizeToken(client.ServiceClient(), "29971f", oauth1.AuthorizeTokenOpts{
+		Roles: []oauth1.Role{
+			{ID: "a3b29b"},
+			{Name: "member"},
+		},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "8171", token.OAuthVerifier)
+
+	_, err = oauth1.AuthorizeTokenOpts{Roles: []oauth1.Role{{}}}.ToOAuth1AuthorizeTokenMap()
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Fatalf("Expected ErrMissingInput, got %v", err)
+	}
+}
+
+func TestCreateAccessToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateAccessTokenSuccessfully(t)
+
+	token, err := oauth1.CreateAccessToken(client.ServiceClient(), oauth1.CreateAccessTokenOpts{
+		OAuthConsumerKey:    "7fea2d",
+		OAuthConsumerSecret: "a7e8b2",
+		OAuthToken:          "29971f",
+		OAuthTokenSecret:    "238eb8",
+		OAuthVerifier:       "8171",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, oauth1.Token{
+		OAuthToken:       "accd36",
+		OAuthTokenSecret: "aa47da",
+	}, *token)
+}
+
+func TestUserAccessTokens(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUserAccessTokensSuccessfully(t)
+
+	allPages, err := oauth1.ListAccessTokens(client.ServiceClient(), "ce9e07").AllPages()
+	th.AssertNoErr(t, err)
+	accessTokens, err := oauth1.ExtractAccessTokens(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []oauth1.AccessToken{UserAccessToken}, accessTokens)
+
+	accessToken, err := oauth1.GetAccessToken(client.ServiceClient(), "ce9e07", "6be26a").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, UserAccessToken, *accessToken)
+
+	allPages, err = oauth1.ListAccessTokenRoles(client.ServiceClient(), "ce9e07", "6be26a").AllPages()
+	th.AssertNoErr(t, err)
+	roles, err := oauth1.ExtractAccessTokenRoles(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedUserAccessTokenRolesSlice, roles)
+
+	role, err := oauth1.GetAccessTokenRole(client.ServiceClient(), "ce9e07", "6be26a", "5ad150").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FirstRole, *role)
+
+	err = oauth1.RevokeAccessToken(client.ServiceClient(), "ce9e07", "6be26a").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestAuthenticate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleAuthenticateSuccessfully(t)
+
+	authOptions := oauth1.AuthOptions{
+		OAuthConsumerKey:    "7fea2d",
+		OAuthConsumerSecret: "a7e8b2",
+		OAuthToken:          "accd36",
+		OAuthTokenSecret:    "aa47da",
+	}
+
+	result := tokens.Create(client.ServiceClient(), &authOptions)
+	token, err := result.ExtractToken()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "6e7a16", token.ID)
+
+	project, err := result.ExtractProject()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "b9fca3", project.ID)
+}
@@ -0,0 +1,41 @@
+package oauth1
+
+import "github.com/gophercloud/gophercloud"
+
+const extensionPath = "OS-OAUTH1"
+
+func consumersURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL(extensionPath, "consumers")
+}
+
+func consumerURL(client *gophercloud.ServiceClient, consumerID string) string {
+	return client.ServiceURL(extensionPath, "consumers", consumerID)
+}
+
+func requestTokenURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL(extensionPath, "request_token")
+}
+
+func authorizeTokenURL(client *gophercloud.ServiceClient, requestTokenID string) string {
+	return client.ServiceURL(extensionPath, "authorize", requestTokenID)
+}
+
+func createAccessTokenURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL(extensionPath, "access_token")
+}
+
+func userAccessTokensURL(client *gophercloud.ServiceClient, userID string) string {
+	return client.ServiceURL("users", userID, extensionPath, "access_tokens")
+}
+
+func userAccessTokenURL(client *gophercloud.ServiceClient, userID, accessTokenID string) string {
+	return client.ServiceURL("users", userID, extensionPath, "access_tokens", accessTokenID)
+}
+
+func userAccessTokenRolesURL(client *gophercloud.ServiceClient, userID, accessTokenID string) string {
+	return client.ServiceURL("users", userID, extensionPath, "access_tokens", accessTokenID, "roles")
+}
+
+func userAccessTokenRoleURL(client *gophercloud.ServiceClient, userID, accessTokenID, roleID string) string {
+	return client.ServiceURL("users", userID, extensionPath, "access_tokens", accessTokenID, "roles", roleID)
+}
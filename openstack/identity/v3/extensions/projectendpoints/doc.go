@@ -0,0 +1,42 @@
+/*
+Package projectendpoints manages the associations between projects and
+endpoints of the OpenStack Identity service (OS-EP-FILTER).
+
+Once a project is associated with an endpoint, or with an endpoint group by
+the endpointgroups package, the catalog of the tokens scoped to the project
+only contains the associated endpoints.
+
+Example to Associate an Endpoint with a Project
+
+	projectID := "263fd9"
+	endpointID := "6fedc0"
+
+	err := projectendpoints.Create(identityClient, projectID, endpointID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the Endpoints of a Project
+
+	allPages, err := projectendpoints.List(identityClient, "263fd9").AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allEndpoints, err := endpoints.ExtractEndpoints(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, endpoint := range allEndpoints {
+		fmt.Printf("%+v\n", endpoint)
+	}
+
+Example to Remove the Association of an Endpoint with a Project
+
+	err := projectendpoints.Delete(identityClient, "263fd9", "6fedc0").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package projectendpoints
@@ -0,0 +1,53 @@
+package projectendpoints
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/endpoints"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Create associates an endpoint with a project, which adds the endpoint to
+// the catalog of the tokens scoped to the project.
+func Create(client *gophercloud.ServiceClient, projectID, endpointID string) (r CreateResult) {
+	resp, err := client.Put(associationURL(client, projectID, endpointID), nil, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Check checks whether an endpoint is associated with a project. The
+// result's ExtractErr returns a gophercloud.ErrDefault404 if it isn't.
+func Check(client *gophercloud.ServiceClient, projectID, endpointID string) (r CheckResult) {
+	resp, err := client.Head(associationURL(client, projectID, endpointID), &gophercloud.RequestOpts{
+		OkCodes: []int{200, 204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete removes the association of an endpoint with a project.
+func Delete(client *gophercloud.ServiceClient, projectID, endpointID string) (r DeleteResult) {
+	resp, err := client.Delete(associationURL(client, projectID, endpointID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// List enumerates the endpoints that are associated with a project. Use
+// endpoints.ExtractEndpoints to interpret the pages.
+func List(client *gophercloud.ServiceClient, projectID string) pagination.Pager {
+	url := listURL(client, projectID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return endpoints.EndpointPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// ListProjects enumerates the projects an endpoint is associated with. Use
+// projects.ExtractProjects to interpret the pages.
+func ListProjects(client *gophercloud.ServiceClient, endpointID string) pagination.Pager {
+	url := listProjectsURL(client, endpointID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return projects.ProjectPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+	})
+}
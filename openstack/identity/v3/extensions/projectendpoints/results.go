@@ -0,0 +1,21 @@
+package projectendpoints
+
+import "github.com/gophercloud/gophercloud"
+
+// CreateResult is the response from a Create operation. Call its ExtractErr
+// to determine if the request succeeded or failed.
+type CreateResult struct {
+	gophercloud.ErrResult
+}
+
+// CheckResult is the response from a Check operation. Call its ExtractErr
+// to determine if the endpoint is associated with the project.
+type CheckResult struct {
+	gophercloud.ErrResult
+}
+
+// DeleteResult is the response from a Delete operation. Call its ExtractErr
+// to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
@@ -0,0 +1,2 @@
+// projectendpoints unit tests
+package testing
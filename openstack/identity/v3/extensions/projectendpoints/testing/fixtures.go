@@ -0,0 +1,92 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+// ListEndpointsOutput provides the endpoints of a project.
+const ListEndpointsOutput = `
+{
+  "endpoints": [
+    {
+      "id": "6fedc0",
+      "interface": "public",
+      "region": "RegionOne",
+      "service_id": "1b501a",
+      "url": "https://compute.example.com/v2.1"
+    }
+  ],
+  "links": {
+    "self": "http://example.com/identity/v3/OS-EP-FILTER/projects/263fd9/endpoints",
+    "previous": null,
+    "next": null
+  }
+}
+`
+
+// ListProjectsOutput provides the projects of an endpoint.
+const ListProjectsOutput = `
+{
+  "projects": [
+    {
+      "description": "The team that is red",
+      "domain_id": "default",
+      "enabled": true,
+      "id": "263fd9",
+      "is_domain": false,
+      "name": "Red Team",
+      "parent_id": "default"
+    }
+  ],
+  "links": {
+    "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoints/6fedc0/projects",
+    "previous": null,
+    "next": null
+  }
+}
+`
+
+// HandleProjectEndpointsSuccessfully creates HTTP handlers on the test
+// handler mux that list the endpoints of project 263fd9 and the projects of
+// endpoint 6fedc0, and that create, check and delete their association.
+// Checking any other endpoint results in a 404.
+func HandleProjectEndpointsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/projects/263fd9/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListEndpointsOutput)
+	})
+
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoints/6fedc0/projects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListProjectsOutput)
+	})
+
+	th.Mux.HandleFunc("/OS-EP-FILTER/projects/263fd9/endpoints/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		if r.URL.Path != "/OS-EP-FILTER/projects/263fd9/endpoints/6fedc0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case "PUT", "HEAD", "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/endpoints"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/projectendpoints"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestProjectEndpoints(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleProjectEndpointsSuccessfully(t)
+
+	err := projectendpoints.Create(client.ServiceClient(), "263fd9", "6fedc0").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = projectendpoints.Check(client.ServiceClient(), "263fd9", "6fedc0").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = projectendpoints.Check(client.ServiceClient(), "263fd9", "c1a0e2").ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("Expected ErrDefault404, got %v", err)
+	}
+
+	err = projectendpoints.Delete(client.ServiceClient(), "263fd9", "6fedc0").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestListProjectEndpoints(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleProjectEndpointsSuccessfully(t)
+
+	allPages, err := projectendpoints.List(client.ServiceClient(), "263fd9").AllPages()
+	th.AssertNoErr(t, err)
+	allEndpoints, err := endpoints.ExtractEndpoints(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []endpoints.Endpoint{{
+		ID:           "6fedc0",
+		Availability: gophercloud.AvailabilityPublic,
+		Region:       "RegionOne",
+		ServiceID:    "1b501a",
+		URL:          "https://compute.example.com/v2.1",
+	}}, allEndpoints)
+
+	allPages, err = projectendpoints.ListProjects(client.ServiceClient(), "6fedc0").AllPages()
+	th.AssertNoErr(t, err)
+	allProjects, err := projects.ExtractProjects(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(allProjects))
+	th.AssertEquals(t, "263fd9", allProjects[0].ID)
+}
@@ -0,0 +1,17 @@
+package projectendpoints
+
+import "github.com/gophercloud/gophercloud"
+
+const extensionPath = "OS-EP-FILTER"
+
+func listURL(client *gophercloud.ServiceClient, projectID string) string {
+	return client.ServiceURL(extensionPath, "projects", projectID, "endpoints")
+}
+
+func associationURL(client *gophercloud.ServiceClient, projectID, endpointID string) string {
+	return client.ServiceURL(extensionPath, "projects", projectID, "endpoints", endpointID)
+}
+
+func listProjectsURL(client *gophercloud.ServiceClient, endpointID string) string {
+	return client.ServiceURL(extensionPath, "endpoints", endpointID, "projects")
+}
@@ -22,5 +22,85 @@ Example to Create a Token with Username, Password, and Trust ID
 	if err != nil {
 		panic(err)
 	}
+
+A trust-scoped ProviderClient, as used by a long-running job, authenticates
+again with the same trust when its token expires if AllowReauth is set.
+
+	authOptions := &tokens.AuthOptions{
+		IdentityEndpoint: "https://example.com:5000/v3",
+		UserID:           "trustee_user_id",
+		Password:         "password",
+		AllowReauth:      true,
+	}
+
+	provider, err := openstack.NewClient(authOptions.IdentityEndpoint)
+	if err != nil {
+		panic(err)
+	}
+
+	err = openstack.AuthenticateV3(provider, trusts.AuthOptsExt{
+		AuthOptionsBuilder: authOptions,
+		TrustID:            "de0945a",
+	}, gophercloud.EndpointOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create a Trust
+
+	expiresAt := time.Date(2019, 12, 1, 14, 0, 0, 0, time.UTC)
+	remainingUses := 10
+	createOpts := trusts.CreateOpts{
+		ExpiresAt:     &expiresAt,
+		Impersonation: true,
+		ProjectID:     "9b71012f5a4a4aef9193f1995fe159b2",
+		Roles: []trusts.Role{
+			{
+				Name: "member",
+			},
+		},
+		RemainingUses: &remainingUses,
+		TrusteeUserID: "ecb37e88cc86431c99d0332208cb6fbf",
+		TrustorUserID: "959ed913a32c4ec88c041c98e61cbbc3",
+	}
+
+	trust, err := trusts.Create(identityClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List Trusts
+
+	listOpts := trusts.ListOpts{
+		TrustorUserID: "959ed913a32c4ec88c041c98e61cbbc3",
+	}
+
+	allPages, err := trusts.List(identityClient, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allTrusts, err := trusts.ExtractTrusts(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, trust := range allTrusts {
+		fmt.Printf("%+v\n", trust)
+	}
+
+Example to Check a Delegated Role
+
+	err := trusts.CheckRole(identityClient, "trust_id", "role_id").ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault404); ok {
+		fmt.Println("The role isn't delegated by the trust")
+	}
+
+Example to Delete a Trust
+
+	err := trusts.Delete(identityClient, "trust_id").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
 */
 package trusts
@@ -1,6 +1,12 @@
 package trusts
 
-import "github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+import (
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/gophercloud/pagination"
+)
 
 // AuthOptsExt extends the base Identity v3 tokens AuthOpts with a TrustID.
 type AuthOptsExt struct {
@@ -37,3 +43,150 @@ func (opts AuthOptsExt) ToTokenV3ScopeMap() (map[string]interface{}, error) {
 func (opts AuthOptsExt) CanReauth() bool {
 	return opts.AuthOptionsBuilder.CanReauth()
 }
+
+// CreateOptsBuilder allows extensions to add additional parameters to
+// the Create request.
+type CreateOptsBuilder interface {
+	ToTrustCreateMap() (map[string]interface{}, error)
+}
+
+// CreateOpts provides options used to create a trust.
+type CreateOpts struct {
+	// TrustorUserID is the ID of the user who delegates the roles. It must
+	// be the user of the token the request is made with.
+	TrustorUserID string `json:"trustor_user_id" required:"true"`
+
+	// TrusteeUserID is the ID of the user who is granted the roles.
+	TrusteeUserID string `json:"trustee_user_id" required:"true"`
+
+	// Impersonation makes the trustee appear as the trustor in the tokens
+	// it obtains with the trust.
+	Impersonation bool `json:"impersonation"`
+
+	// ProjectID is the project the delegated roles are granted on. It is
+	// required if Roles is set.
+	ProjectID string `json:"project_id,omitempty"`
+
+	// Roles are the roles to delegate, by ID or name. The trustor must have
+	// them on ProjectID.
+	Roles []Role `json:"roles,omitempty"`
+
+	// AllowRedelegation allows the trustee to create trusts of its own from
+	// this trust.
+	AllowRedelegation bool `json:"allow_redelegation,omitempty"`
+
+	// RedelegationCount limits how often the trust may be redelegated.
+	RedelegationCount *int `json:"redelegation_count,omitempty"`
+
+	// RemainingUses limits the number of tokens that may be obtained with
+	// the trust. If it is nil, the trust may be used without limit.
+	RemainingUses *int `json:"remaining_uses,omitempty"`
+
+	// ExpiresAt is the time at which the trust expires. If it is nil, the
+	// trust doesn't expire.
+	ExpiresAt *time.Time `json:"-"`
+}
+
+// ToTrustCreateMap formats a CreateOpts into a create request.
+func (opts CreateOpts) ToTrustCreateMap() (map[string]interface{}, error) {
+	parent := "trust"
+	b, err := gophercloud.BuildRequestBody(opts, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.ExpiresAt != nil {
+		b[parent].(map[string]interface{})["expires_at"] = opts.ExpiresAt.UTC().Format(gophercloud.RFC3339Milli)
+	}
+
+	return b, nil
+}
+
+// Create creates a new Trust.
+func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToTrustCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Post(rootURL(client), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete deletes a Trust.
+func Delete(client *gophercloud.ServiceClient, trustID string) (r DeleteResult) {
+	resp, err := client.Delete(resourceURL(client, trustID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListOptsBuilder allows extensions to add additional parameters to
+// the List request.
+type ListOptsBuilder interface {
+	ToTrustListQuery() (string, error)
+}
+
+// ListOpts provides options to filter the List results.
+type ListOpts struct {
+	// TrustorUserID filters the response by the trustor's user ID.
+	TrustorUserID string `q:"trustor_user_id"`
+
+	// TrusteeUserID filters the response by the trustee's user ID.
+	TrusteeUserID string `q:"trustee_user_id"`
+}
+
+// ToTrustListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToTrustListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List enumerates the Trusts to which the current token has access.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(client)
+	if opts != nil {
+		query, err := opts.ToTrustListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return TrustPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves details on a single Trust, by ID.
+func Get(client *gophercloud.ServiceClient, trustID string) (r GetResult) {
+	resp, err := client.Get(resourceURL(client, trustID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListRoles enumerates the roles delegated by a Trust.
+func ListRoles(client *gophercloud.ServiceClient, trustID string) pagination.Pager {
+	url := listRolesURL(client, trustID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return RolesPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// GetRole retrieves a role delegated by a Trust.
+func GetRole(client *gophercloud.ServiceClient, trustID, roleID string) (r GetRoleResult) {
+	resp, err := client.Get(getRoleURL(client, trustID, roleID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CheckRole checks whether a role is delegated by a Trust. The result's
+// ExtractErr returns a gophercloud.ErrDefault404 if it isn't.
+func CheckRole(client *gophercloud.ServiceClient, trustID, roleID string) (r CheckRoleResult) {
+	resp, err := client.Head(getRoleURL(client, trustID, roleID), &gophercloud.RequestOpts{
+		OkCodes: []int{200, 204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
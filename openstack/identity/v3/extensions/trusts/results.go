@@ -1,5 +1,12 @@
 package trusts
 
+import (
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
 // TrusteeUser represents the trusted user ID of a trust.
 type TrusteeUser struct {
 	ID string `json:"id"`
@@ -10,6 +17,13 @@ type TrustorUser struct {
 	ID string `json:"id"`
 }
 
+// Role identifies a role delegated by a trust. Only one of ID and Name is
+// needed when creating a trust.
+type Role struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
 // Trust represents a delegated authorization request between two
 // identities.
 type Trust struct {
@@ -19,9 +33,145 @@ type Trust struct {
 	TrustorUser        TrustorUser `json:"trustor_user"`
 	RedelegatedTrustID string      `json:"redelegated_trust_id"`
 	RedelegationCount  int         `json:"redelegation_count"`
+	AllowRedelegation  bool        `json:"allow_redelegation"`
+	TrusteeUserID      string      `json:"trustee_user_id"`
+	TrustorUserID      string      `json:"trustor_user_id"`
+	ProjectID          string      `json:"project_id"`
+	Roles              []Role      `json:"roles"`
+
+	// RemainingUses is the number of tokens that can still be obtained with
+	// the trust. It is nil if the trust may be used without limit.
+	RemainingUses *int `json:"remaining_uses"`
+
+	// ExpiresAt is the zero time for a trust that doesn't expire.
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // TokenExt represents an extension of the base token result.
 type TokenExt struct {
 	Trust Trust `json:"OS-TRUST:trust"`
 }
+
+type trustResult struct {
+	gophercloud.Result
+}
+
+// CreateResult is the response from a Create operation. Call its Extract
+// method to interpret it as a Trust.
+type CreateResult struct {
+	trustResult
+}
+
+// GetResult is the response from a Get operation. Call its Extract method
+// to interpret it as a Trust.
+type GetResult struct {
+	trustResult
+}
+
+// DeleteResult is the response from a Delete operation. Call its ExtractErr
+// to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// Extract interprets any trust result as a Trust.
+func (r trustResult) Extract() (*Trust, error) {
+	var s struct {
+		Trust *Trust `json:"trust"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Trust, err
+}
+
+// TrustPage is a single page of Trust results.
+type TrustPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of Trusts contains any results.
+func (r TrustPage) IsEmpty() (bool, error) {
+	trusts, err := ExtractTrusts(r)
+	return len(trusts) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r TrustPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractTrusts returns a slice of Trusts contained in a single page of
+// results.
+func ExtractTrusts(r pagination.Page) ([]Trust, error) {
+	var s struct {
+		Trusts []Trust `json:"trusts"`
+	}
+	err := (r.(TrustPage)).ExtractInto(&s)
+	return s.Trusts, err
+}
+
+// RolesPage is a single page of the roles delegated by a Trust.
+type RolesPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of Roles contains any results.
+func (r RolesPage) IsEmpty() (bool, error) {
+	roles, err := ExtractRoles(r)
+	return len(roles) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r RolesPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractRoles returns a slice of Roles contained in a single page of
+// results.
+func ExtractRoles(r pagination.Page) ([]Role, error) {
+	var s struct {
+		Roles []Role `json:"roles"`
+	}
+	err := (r.(RolesPage)).ExtractInto(&s)
+	return s.Roles, err
+}
+
+// GetRoleResult is the response from a GetRole operation. Call its Extract
+// method to interpret it as a Role.
+type GetRoleResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a GetRoleResult as a Role.
+func (r GetRoleResult) Extract() (*Role, error) {
+	var s struct {
+		Role *Role `json:"role"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Role, err
+}
+
+// CheckRoleResult is the response from a CheckRole operation. Call its
+// ExtractErr method to determine if the request succeeded or failed.
+type CheckRoleResult struct {
+	gophercloud.ErrResult
+}
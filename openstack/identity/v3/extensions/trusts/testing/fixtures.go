@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
 )
 
 // HandleCreateTokenWithTrustID verifies that providing certain AuthOptions and Scope results in an expected JSON structure.
@@ -65,3 +68,227 @@ func HandleCreateTokenWithTrustID(t *testing.T, options tokens.AuthOptionsBuilde
 }`)
 	})
 }
+
+// CreateRequest is a sample request to create a trust.
+const CreateRequest = `
+{
+    "trust": {
+        "expires_at": "2019-12-01T14:00:00.999999Z",
+        "impersonation": true,
+        "allow_redelegation": true,
+        "project_id": "9b71012f5a4a4aef9193f1995fe159b2",
+        "roles": [
+            {
+                "name": "member"
+            }
+        ],
+        "remaining_uses": 1,
+        "trustee_user_id": "ecb37e88cc86431c99d0332208cb6fbf",
+        "trustor_user_id": "959ed913a32c4ec88c041c98e61cbbc3"
+    }
+}
+`
+
+// CreateResponse is a sample response to a Create request.
+const CreateResponse = `
+{
+    "trust": {
+        "expires_at": "2019-12-01T14:00:00.999999Z",
+        "id": "3422b7c113894f5d90665e1a79655e23",
+        "impersonation": true,
+        "redelegation_count": 10,
+        "allow_redelegation": true,
+        "project_id": "9b71012f5a4a4aef9193f1995fe159b2",
+        "remaining_uses": 1,
+        "roles": [
+            {
+                "id": "b627fca5-beb0-471a-9857-0e852b719e76",
+                "links": {
+                    "self": "http://example.com/identity/v3/roles/b627fca5-beb0-471a-9857-0e852b719e76"
+                },
+                "name": "member"
+            }
+        ],
+        "trustee_user_id": "ecb37e88cc86431c99d0332208cb6fbf",
+        "trustor_user_id": "959ed913a32c4ec88c041c98e61cbbc3"
+    }
+}
+`
+
+// ListResponse is a sample response to a List request.
+const ListResponse = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-TRUST/trusts"
+    },
+    "trusts": [
+        {
+            "id": "3422b7c113894f5d90665e1a79655e23",
+            "impersonation": true,
+            "expires_at": null,
+            "project_id": "9b71012f5a4a4aef9193f1995fe159b2",
+            "remaining_uses": null,
+            "trustee_user_id": "ecb37e88cc86431c99d0332208cb6fbf",
+            "trustor_user_id": "959ed913a32c4ec88c041c98e61cbbc3"
+        }
+    ]
+}
+`
+
+// ListRolesResponse is a sample response to a ListRoles request.
+const ListRolesResponse = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-TRUST/trusts/3422b7c113894f5d90665e1a79655e23/roles"
+    },
+    "roles": [
+        {
+            "id": "b627fca5-beb0-471a-9857-0e852b719e76",
+            "links": {
+                "self": "http://example.com/identity/v3/roles/b627fca5-beb0-471a-9857-0e852b719e76"
+            },
+            "name": "member"
+        }
+    ]
+}
+`
+
+// GetRoleResponse is a sample response to a GetRole request.
+const GetRoleResponse = `
+{
+    "role": {
+        "id": "b627fca5-beb0-471a-9857-0e852b719e76",
+        "links": {
+            "self": "http://example.com/identity/v3/roles/b627fca5-beb0-471a-9857-0e852b719e76"
+        },
+        "name": "member"
+    }
+}
+`
+
+var remainingUses = 1
+
+// FirstTrust is the trust in the Create and Get responses.
+var FirstTrust = trusts.Trust{
+	ID:                "3422b7c113894f5d90665e1a79655e23",
+	Impersonation:     true,
+	RedelegationCount: 10,
+	AllowRedelegation: true,
+	ProjectID:         "9b71012f5a4a4aef9193f1995fe159b2",
+	RemainingUses:     &remainingUses,
+	Roles: []trusts.Role{
+		{
+			ID:   "b627fca5-beb0-471a-9857-0e852b719e76",
+			Name: "member",
+		},
+	},
+	TrusteeUserID: "ecb37e88cc86431c99d0332208cb6fbf",
+	TrustorUserID: "959ed913a32c4ec88c041c98e61cbbc3",
+	ExpiresAt:     time.Date(2019, 12, 1, 14, 0, 0, 999999000, time.UTC),
+}
+
+// UnlimitedTrust is the trust in the List response.
+var UnlimitedTrust = trusts.Trust{
+	ID:            "3422b7c113894f5d90665e1a79655e23",
+	Impersonation: true,
+	ProjectID:     "9b71012f5a4a4aef9193f1995fe159b2",
+	TrusteeUserID: "ecb37e88cc86431c99d0332208cb6fbf",
+	TrustorUserID: "959ed913a32c4ec88c041c98e61cbbc3",
+}
+
+// MemberRole is the role delegated by the trust.
+var MemberRole = trusts.Role{
+	ID:   "b627fca5-beb0-471a-9857-0e852b719e76",
+	Name: "member",
+}
+
+// HandleCreateTrust creates an HTTP handler at `/OS-TRUST/trusts` on the
+// test handler mux that tests trust creation.
+func HandleCreateTrust(t *testing.T) {
+	testhelper.Mux.HandleFunc("/OS-TRUST/trusts", func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestMethod(t, r, "POST")
+		testhelper.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		testhelper.TestJSONRequest(t, r, CreateRequest)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, CreateResponse)
+	})
+}
+
+// HandleListTrusts creates an HTTP handler at `/OS-TRUST/trusts` on the
+// test handler mux that responds with a list of trusts.
+func HandleListTrusts(t *testing.T) {
+	testhelper.Mux.HandleFunc("/OS-TRUST/trusts", func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestMethod(t, r, "GET")
+		testhelper.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		testhelper.TestFormValues(t, r, map[string]string{
+			"trustor_user_id": "959ed913a32c4ec88c041c98e61cbbc3",
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListResponse)
+	})
+}
+
+// HandleGetTrust creates an HTTP handler at `/OS-TRUST/trusts/{trust_id}`
+// on the test handler mux that responds with a single trust.
+func HandleGetTrust(t *testing.T) {
+	testhelper.Mux.HandleFunc("/OS-TRUST/trusts/3422b7c113894f5d90665e1a79655e23", func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestMethod(t, r, "GET")
+		testhelper.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, CreateResponse)
+	})
+}
+
+// HandleDeleteTrust creates an HTTP handler at `/OS-TRUST/trusts/{trust_id}`
+// on the test handler mux that tests trust deletion.
+func HandleDeleteTrust(t *testing.T) {
+	testhelper.Mux.HandleFunc("/OS-TRUST/trusts/3422b7c113894f5d90665e1a79655e23", func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestMethod(t, r, "DELETE")
+		testhelper.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleTrustRoles creates HTTP handlers on the test handler mux that list,
+// get and check the roles delegated by a trust.
+func HandleTrustRoles(t *testing.T) {
+	testhelper.Mux.HandleFunc("/OS-TRUST/trusts/3422b7c113894f5d90665e1a79655e23/roles", func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestMethod(t, r, "GET")
+		testhelper.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListRolesResponse)
+	})
+
+	testhelper.Mux.HandleFunc("/OS-TRUST/trusts/3422b7c113894f5d90665e1a79655e23/roles/b627fca5-beb0-471a-9857-0e852b719e76", func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, GetRoleResponse)
+		case "HEAD":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	testhelper.Mux.HandleFunc("/OS-TRUST/trusts/3422b7c113894f5d90665e1a79655e23/roles/admin", func(w http.ResponseWriter, r *http.Request) {
+		testhelper.TestMethod(t, r, "HEAD")
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
 	"github.com/gophercloud/gophercloud/testhelper/client"
 )
@@ -72,3 +74,106 @@ func TestCreateUserIDPasswordTrustID(t *testing.T) {
 
 	th.AssertDeepEquals(t, expected, actual)
 }
+
+func TestCreateTrust(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateTrust(t)
+
+	expiresAt := time.Date(2019, 12, 1, 14, 0, 0, 999999000, time.UTC)
+	remainingUses := 1
+	createOpts := trusts.CreateOpts{
+		ExpiresAt:         &expiresAt,
+		Impersonation:     true,
+		AllowRedelegation: true,
+		ProjectID:         "9b71012f5a4a4aef9193f1995fe159b2",
+		Roles: []trusts.Role{
+			{
+				Name: "member",
+			},
+		},
+		RemainingUses: &remainingUses,
+		TrusteeUserID: "ecb37e88cc86431c99d0332208cb6fbf",
+		TrustorUserID: "959ed913a32c4ec88c041c98e61cbbc3",
+	}
+
+	actual, err := trusts.Create(client.ServiceClient(), createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FirstTrust, *actual)
+}
+
+func TestCreateTrustRequiresUsers(t *testing.T) {
+	res := trusts.Create(client.ServiceClient(), trusts.CreateOpts{
+		TrustorUserID: "959ed913a32c4ec88c041c98e61cbbc3",
+	})
+	if res.Err == nil {
+		t.Fatal("Create without a trustee should fail")
+	}
+}
+
+func TestListTrusts(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListTrusts(t)
+
+	listOpts := trusts.ListOpts{
+		TrustorUserID: "959ed913a32c4ec88c041c98e61cbbc3",
+	}
+
+	count := 0
+	err := trusts.List(client.ServiceClient(), listOpts).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := trusts.ExtractTrusts(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []trusts.Trust{UnlimitedTrust}, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 1, count)
+}
+
+func TestGetTrust(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetTrust(t)
+
+	actual, err := trusts.Get(client.ServiceClient(), "3422b7c113894f5d90665e1a79655e23").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FirstTrust, *actual)
+	th.CheckEquals(t, 1, *actual.RemainingUses)
+}
+
+func TestDeleteTrust(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteTrust(t)
+
+	res := trusts.Delete(client.ServiceClient(), "3422b7c113894f5d90665e1a79655e23")
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestTrustRoles(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleTrustRoles(t)
+
+	allPages, err := trusts.ListRoles(client.ServiceClient(), "3422b7c113894f5d90665e1a79655e23").AllPages()
+	th.AssertNoErr(t, err)
+	roles, err := trusts.ExtractRoles(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []trusts.Role{MemberRole}, roles)
+
+	role, err := trusts.GetRole(client.ServiceClient(), "3422b7c113894f5d90665e1a79655e23", "b627fca5-beb0-471a-9857-0e852b719e76").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, MemberRole, *role)
+
+	err = trusts.CheckRole(client.ServiceClient(), "3422b7c113894f5d90665e1a79655e23", "b627fca5-beb0-471a-9857-0e852b719e76").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = trusts.CheckRole(client.ServiceClient(), "3422b7c113894f5d90665e1a79655e23", "admin").ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("Expected a 404 for an undelegated role, got %v", err)
+	}
+}
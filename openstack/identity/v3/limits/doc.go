@@ -0,0 +1,68 @@
+/*
+Package limits manages the project and domain limits of the unified limits in
+the OpenStack Identity Service. A limit overrides the default limit of the
+registered limit for the same service, region and resource.
+
+Example to Get the Enforcement Model
+
+	model, err := limits.GetEnforcementModel(identityClient).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List Limits
+
+	listOpts := limits.ListOpts{
+		ProjectID: "3a705b9f56bb439381b43c4fe59dccce",
+	}
+
+	allPages, err := limits.List(identityClient, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allLimits, err := limits.ExtractLimits(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, limit := range allLimits {
+		fmt.Printf("%+v\n", limit)
+	}
+
+Example to Create Limits
+
+	batchCreateOpts := limits.BatchCreateOpts{
+		limits.CreateOpts{
+			ServiceID:     "9408080f1970482aa0e38bc2d4ea34b7",
+			ProjectID:     "3a705b9f56bb439381b43c4fe59dccce",
+			ResourceName:  "cores",
+			ResourceLimit: 20,
+		},
+	}
+
+	createdLimits, err := limits.BatchCreate(identityClient, batchCreateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Update a Limit
+
+	resourceLimit := 40
+	updateOpts := limits.UpdateOpts{
+		ResourceLimit: &resourceLimit,
+	}
+
+	limit, err := limits.Update(identityClient, "limit_id", updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Limit
+
+	err := limits.Delete(identityClient, "limit_id").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package limits
@@ -0,0 +1,170 @@
+package limits
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// GetEnforcementModel retrieves the enforcement model of the unified limits,
+// which determines how limits of projects in a hierarchy relate.
+func GetEnforcementModel(client *gophercloud.ServiceClient) (r EnforcementModelResult) {
+	resp, err := client.Get(enforcementModelURL(client), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListOptsBuilder allows extensions to add additional parameters to
+// the List request
+type ListOptsBuilder interface {
+	ToLimitListQuery() (string, error)
+}
+
+// ListOpts provides options to filter the List results.
+type ListOpts struct {
+	// ServiceID filters the response by a service ID.
+	ServiceID string `q:"service_id"`
+
+	// RegionID filters the response by a region ID.
+	RegionID string `q:"region_id"`
+
+	// ResourceName filters the response by a resource name.
+	ResourceName string `q:"resource_name"`
+
+	// ProjectID filters the response by a project ID.
+	ProjectID string `q:"project_id"`
+
+	// DomainID filters the response by a domain ID.
+	DomainID string `q:"domain_id"`
+}
+
+// ToLimitListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToLimitListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List enumerates the limits.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(client)
+	if opts != nil {
+		query, err := opts.ToLimitListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return LimitPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// BatchCreateOptsBuilder allows extensions to add additional parameters to
+// the BatchCreate request.
+type BatchCreateOptsBuilder interface {
+	ToLimitsCreateMap() (map[string]interface{}, error)
+}
+
+// CreateOpts provides options used to create a limit.
+type CreateOpts struct {
+	// ServiceID is the ID of the service the limit applies to.
+	ServiceID string `json:"service_id" required:"true"`
+
+	// ProjectID is the ID of the project the limit applies to.
+	// Note: exactly one of ProjectID or DomainID must be provided
+	ProjectID string `json:"project_id,omitempty" xor:"DomainID"`
+
+	// DomainID is the ID of the domain the limit applies to.
+	// Note: exactly one of ProjectID or DomainID must be provided
+	DomainID string `json:"domain_id,omitempty" xor:"ProjectID"`
+
+	// RegionID is the ID of the region the limit applies to.
+	RegionID string `json:"region_id,omitempty"`
+
+	// ResourceName is the name of the limited resource, e.g. "cores". A
+	// registered limit must exist for it.
+	ResourceName string `json:"resource_name" required:"true"`
+
+	// ResourceLimit overrides the default limit of the registered limit.
+	ResourceLimit int `json:"resource_limit"`
+
+	// Description is a description of the limit.
+	Description string `json:"description,omitempty"`
+}
+
+// BatchCreateOpts provides the limits to create in a single request.
+type BatchCreateOpts []CreateOpts
+
+// ToLimitsCreateMap formats a BatchCreateOpts into a create request.
+func (opts BatchCreateOpts) ToLimitsCreateMap() (map[string]interface{}, error) {
+	limits := make([]map[string]interface{}, len(opts))
+	for i, limit := range opts {
+		b, err := gophercloud.BuildRequestBody(limit, "")
+		if err != nil {
+			return nil, err
+		}
+		limits[i] = b
+	}
+	return map[string]interface{}{"limits": limits}, nil
+}
+
+// BatchCreate creates new limits.
+func BatchCreate(client *gophercloud.ServiceClient, opts BatchCreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToLimitsCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Post(rootURL(client), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Get retrieves details on a single limit, by ID.
+func Get(client *gophercloud.ServiceClient, limitID string) (r GetResult) {
+	resp, err := client.Get(resourceURL(client, limitID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to
+// the Update request.
+type UpdateOptsBuilder interface {
+	ToLimitUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateOpts provides options for updating a limit.
+type UpdateOpts struct {
+	// ResourceLimit is the new limit of the resource.
+	ResourceLimit *int `json:"resource_limit,omitempty"`
+
+	// Description is a description of the limit.
+	Description *string `json:"description,omitempty"`
+}
+
+// ToLimitUpdateMap formats an UpdateOpts into an update request.
+func (opts UpdateOpts) ToLimitUpdateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "limit")
+}
+
+// Update updates an existing limit.
+func Update(client *gophercloud.ServiceClient, limitID string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToLimitUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(resourceURL(client, limitID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete deletes a limit.
+func Delete(client *gophercloud.ServiceClient, limitID string) (r DeleteResult) {
+	resp, err := client.Delete(resourceURL(client, limitID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
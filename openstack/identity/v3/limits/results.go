@@ -0,0 +1,144 @@
+package limits
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// EnforcementModel is the model Keystone uses to enforce the limits of
+// projects in a hierarchy, e.g. "flat" or "strict-two-level".
+type EnforcementModel struct {
+	// Name is the name of the enforcement model.
+	Name string `json:"name"`
+
+	// Description describes the enforcement model.
+	Description string `json:"description"`
+}
+
+// EnforcementModelResult is the response from a GetEnforcementModel
+// operation. Call its Extract method to interpret it as an EnforcementModel.
+type EnforcementModelResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets an EnforcementModelResult as an EnforcementModel.
+func (r EnforcementModelResult) Extract() (*EnforcementModel, error) {
+	var s struct {
+		Model *EnforcementModel `json:"model"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Model, err
+}
+
+// Limit is the limit of a resource of a service for a project or domain. It
+// overrides the default limit of the corresponding registered limit.
+type Limit struct {
+	// ID is the unique ID of the limit.
+	ID string `json:"id"`
+
+	// ServiceID is the ID of the service the limit applies to.
+	ServiceID string `json:"service_id"`
+
+	// ProjectID is the ID of the project the limit applies to.
+	ProjectID string `json:"project_id"`
+
+	// DomainID is the ID of the domain the limit applies to.
+	DomainID string `json:"domain_id"`
+
+	// RegionID is the ID of the region the limit applies to.
+	RegionID string `json:"region_id"`
+
+	// ResourceName is the name of the limited resource.
+	ResourceName string `json:"resource_name"`
+
+	// ResourceLimit is the limit of the resource.
+	ResourceLimit int `json:"resource_limit"`
+
+	// Description is a description of the limit.
+	Description string `json:"description"`
+
+	// Links contains referencing links to the limit.
+	Links map[string]interface{} `json:"links"`
+}
+
+type limitResult struct {
+	gophercloud.Result
+}
+
+// GetResult is the response from a Get operation. Call its Extract method
+// to interpret it as a Limit.
+type GetResult struct {
+	limitResult
+}
+
+// UpdateResult is the response from an Update operation. Call its Extract
+// method to interpret it as a Limit.
+type UpdateResult struct {
+	limitResult
+}
+
+// DeleteResult is the response from a Delete operation. Call its ExtractErr
+// to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// CreateResult is the response from a BatchCreate operation. Call its
+// Extract method to interpret it as a slice of Limits.
+type CreateResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any limitResult as a Limit.
+func (r limitResult) Extract() (*Limit, error) {
+	var s struct {
+		Limit *Limit `json:"limit"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Limit, err
+}
+
+// Extract interprets a CreateResult as a slice of Limits.
+func (r CreateResult) Extract() ([]Limit, error) {
+	var s struct {
+		Limits []Limit `json:"limits"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Limits, err
+}
+
+// LimitPage is a single page of Limit results.
+type LimitPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of Limits contains any results.
+func (r LimitPage) IsEmpty() (bool, error) {
+	limits, err := ExtractLimits(r)
+	return len(limits) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r LimitPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractLimits returns a slice of Limits contained in a single page of
+// results.
+func ExtractLimits(r pagination.Page) ([]Limit, error) {
+	var s struct {
+		Limits []Limit `json:"limits"`
+	}
+	err := (r.(LimitPage)).ExtractInto(&s)
+	return s.Limits, err
+}
@@ -0,0 +1,232 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/limits"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+// GetEnforcementModelOutput provides a GetEnforcementModel result.
+const GetEnforcementModelOutput = `
+{
+    "model": {
+        "description": "Limit enforcement and validation does not take project hierarchy into consideration.",
+        "name": "flat"
+    }
+}
+`
+
+// ListOutput provides a single page of Limit results.
+const ListOutput = `
+{
+    "links": {
+        "self": "http://example.com/identity/v3/limits",
+        "previous": null,
+        "next": null
+    },
+    "limits": [
+        {
+            "id": "25a04c7a065c430590881c646cdcdd58",
+            "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+            "project_id": "3a705b9f56bb439381b43c4fe59dccce",
+            "domain_id": null,
+            "region_id": null,
+            "resource_name": "snapshot",
+            "resource_limit": 5,
+            "description": null,
+            "links": {
+                "self": "http://example.com/identity/v3/limits/25a04c7a065c430590881c646cdcdd58"
+            }
+        },
+        {
+            "id": "3229b3849f584faea483d6851f7aab05",
+            "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+            "project_id": null,
+            "domain_id": "edbafc92be354ffa977c58aa79c7bdb2",
+            "region_id": "RegionOne",
+            "resource_name": "volume",
+            "resource_limit": 11,
+            "description": "Number of volumes for the domain",
+            "links": {
+                "self": "http://example.com/identity/v3/limits/3229b3849f584faea483d6851f7aab05"
+            }
+        }
+    ]
+}
+`
+
+// GetOutput provides a Get result.
+const GetOutput = `
+{
+    "limit": {
+        "id": "25a04c7a065c430590881c646cdcdd58",
+        "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+        "project_id": "3a705b9f56bb439381b43c4fe59dccce",
+        "domain_id": null,
+        "region_id": null,
+        "resource_name": "snapshot",
+        "resource_limit": 5,
+        "description": null,
+        "links": {
+            "self": "http://example.com/identity/v3/limits/25a04c7a065c430590881c646cdcdd58"
+        }
+    }
+}
+`
+
+// CreateRequest provides the input to a BatchCreate request.
+const CreateRequest = `
+{
+    "limits": [
+        {
+            "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+            "project_id": "3a705b9f56bb439381b43c4fe59dccce",
+            "resource_name": "snapshot",
+            "resource_limit": 5
+        },
+        {
+            "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+            "domain_id": "edbafc92be354ffa977c58aa79c7bdb2",
+            "region_id": "RegionOne",
+            "resource_name": "volume",
+            "resource_limit": 11,
+            "description": "Number of volumes for the domain"
+        }
+    ]
+}
+`
+
+// UpdateRequest provides the input to an Update request.
+const UpdateRequest = `
+{
+    "limit": {
+        "resource_limit": 10,
+        "description": "Number of snapshots for the project"
+    }
+}
+`
+
+// UpdateOutput provides an Update result.
+const UpdateOutput = `
+{
+    "limit": {
+        "id": "25a04c7a065c430590881c646cdcdd58",
+        "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+        "project_id": "3a705b9f56bb439381b43c4fe59dccce",
+        "domain_id": null,
+        "region_id": null,
+        "resource_name": "snapshot",
+        "resource_limit": 10,
+        "description": "Number of snapshots for the project",
+        "links": {
+            "self": "http://example.com/identity/v3/limits/25a04c7a065c430590881c646cdcdd58"
+        }
+    }
+}
+`
+
+// FlatModel is the enforcement model in GetEnforcementModelOutput.
+var FlatModel = limits.EnforcementModel{
+	Name:        "flat",
+	Description: "Limit enforcement and validation does not take project hierarchy into consideration.",
+}
+
+// FirstLimit is the first limit in the List request.
+var FirstLimit = limits.Limit{
+	ID:            "25a04c7a065c430590881c646cdcdd58",
+	ServiceID:     "9408080f1970482aa0e38bc2d4ea34b7",
+	ProjectID:     "3a705b9f56bb439381b43c4fe59dccce",
+	ResourceName:  "snapshot",
+	ResourceLimit: 5,
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/limits/25a04c7a065c430590881c646cdcdd58",
+	},
+}
+
+// SecondLimit is the second limit in the List request.
+var SecondLimit = limits.Limit{
+	ID:            "3229b3849f584faea483d6851f7aab05",
+	ServiceID:     "9408080f1970482aa0e38bc2d4ea34b7",
+	DomainID:      "edbafc92be354ffa977c58aa79c7bdb2",
+	RegionID:      "RegionOne",
+	ResourceName:  "volume",
+	ResourceLimit: 11,
+	Description:   "Number of volumes for the domain",
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/limits/3229b3849f584faea483d6851f7aab05",
+	},
+}
+
+// ExpectedLimitsSlice is the slice of limits expected to be returned from
+// ListOutput.
+var ExpectedLimitsSlice = []limits.Limit{FirstLimit, SecondLimit}
+
+// HandleGetEnforcementModelSuccessfully creates an HTTP handler at
+// `/limits/model` on the test handler mux that responds with the enforcement
+// model.
+func HandleGetEnforcementModelSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/limits/model", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, GetEnforcementModelOutput)
+	})
+}
+
+// HandleListLimitsSuccessfully creates an HTTP handler at `/limits` on the
+// test handler mux that responds with a list of two limits.
+func HandleListLimitsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/limits", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{"service_id": "9408080f1970482aa0e38bc2d4ea34b7"})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListOutput)
+	})
+}
+
+// HandleBatchCreateLimitsSuccessfully creates an HTTP handler at `/limits`
+// on the test handler mux that tests limit creation.
+func HandleBatchCreateLimitsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/limits", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, CreateRequest)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, ListOutput)
+	})
+}
+
+// HandleLimitSuccessfully creates an HTTP handler at `/limits/{id}` on the
+// test handler mux that gets, updates and deletes a limit.
+func HandleLimitSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/limits/25a04c7a065c430590881c646cdcdd58", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, GetOutput)
+		case "PATCH":
+			th.TestJSONRequest(t, r, UpdateRequest)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, UpdateOutput)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/limits"
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestGetEnforcementModel(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetEnforcementModelSuccessfully(t)
+
+	actual, err := limits.GetEnforcementModel(client.ServiceClient()).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FlatModel, *actual)
+}
+
+func TestListLimits(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListLimitsSuccessfully(t)
+
+	listOpts := limits.ListOpts{
+		ServiceID: "9408080f1970482aa0e38bc2d4ea34b7",
+	}
+
+	count := 0
+	err := limits.List(client.ServiceClient(), listOpts).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := limits.ExtractLimits(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, ExpectedLimitsSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestBatchCreateLimits(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleBatchCreateLimitsSuccessfully(t)
+
+	createOpts := limits.BatchCreateOpts{
+		limits.CreateOpts{
+			ServiceID:     "9408080f1970482aa0e38bc2d4ea34b7",
+			ProjectID:     "3a705b9f56bb439381b43c4fe59dccce",
+			ResourceName:  "snapshot",
+			ResourceLimit: 5,
+		},
+		limits.CreateOpts{
+			ServiceID:     "9408080f1970482aa0e38bc2d4ea34b7",
+			DomainID:      "edbafc92be354ffa977c58aa79c7bdb2",
+			RegionID:      "RegionOne",
+			ResourceName:  "volume",
+			ResourceLimit: 11,
+			Description:   "Number of volumes for the domain",
+		},
+	}
+
+	actual, err := limits.BatchCreate(client.ServiceClient(), createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedLimitsSlice, actual)
+}
+
+func TestBatchCreateLimitsRequiresProjectOrDomain(t *testing.T) {
+	for _, opts := range []limits.CreateOpts{
+		{
+			ServiceID:    "9408080f1970482aa0e38bc2d4ea34b7",
+			ResourceName: "snapshot",
+		},
+		{
+			ServiceID:    "9408080f1970482aa0e38bc2d4ea34b7",
+			ProjectID:    "3a705b9f56bb439381b43c4fe59dccce",
+			DomainID:     "edbafc92be354ffa977c58aa79c7bdb2",
+			ResourceName: "snapshot",
+		},
+	} {
+		res := limits.BatchCreate(client.ServiceClient(), limits.BatchCreateOpts{opts})
+		if res.Err == nil {
+			t.Errorf("BatchCreate should fail for %+v", opts)
+		}
+	}
+}
+
+func TestGetUpdateDeleteLimit(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleLimitSuccessfully(t)
+
+	actual, err := limits.Get(client.ServiceClient(), "25a04c7a065c430590881c646cdcdd58").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FirstLimit, *actual)
+
+	resourceLimit := 10
+	description := "Number of snapshots for the project"
+	updateOpts := limits.UpdateOpts{
+		ResourceLimit: &resourceLimit,
+		Description:   &description,
+	}
+	actual, err = limits.Update(client.ServiceClient(), "25a04c7a065c430590881c646cdcdd58", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 10, actual.ResourceLimit)
+	th.CheckEquals(t, description, actual.Description)
+
+	err = limits.Delete(client.ServiceClient(), "25a04c7a065c430590881c646cdcdd58").ExtractErr()
+	th.AssertNoErr(t, err)
+}
@@ -0,0 +1,207 @@
+package oslopolicy
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// check is a node of a parsed rule.
+type check interface {
+	eval(e *evaluation) bool
+}
+
+// evaluation is the state of an Enforce call.
+type evaluation struct {
+	rules  map[string]check
+	target map[string]interface{}
+	creds  map[string]interface{}
+}
+
+type constCheck bool
+
+func (c constCheck) eval(*evaluation) bool { return bool(c) }
+
+type notCheck struct{ check check }
+
+func (c notCheck) eval(e *evaluation) bool { return !c.check.eval(e) }
+
+type andCheck []check
+
+func (c andCheck) eval(e *evaluation) bool {
+	for _, sub := range c {
+		if !sub.eval(e) {
+			return false
+		}
+	}
+	return true
+}
+
+type orCheck []check
+
+func (c orCheck) eval(e *evaluation) bool {
+	for _, sub := range c {
+		if sub.eval(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleCheck is "rule:<name>". An undefined rule doesn't pass.
+type ruleCheck string
+
+func (c ruleCheck) eval(e *evaluation) bool {
+	rule, ok := e.rules[string(c)]
+	return ok && rule.eval(e)
+}
+
+// roleCheck is "role:<name>". Role names are compared case-insensitively.
+type roleCheck string
+
+func (c roleCheck) eval(e *evaluation) bool {
+	match, ok := substitute(string(c), e.target)
+	if !ok {
+		return false
+	}
+	roles, _ := e.creds["roles"].([]string)
+	for _, role := range roles {
+		if strings.EqualFold(role, match) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteCheck is "http:<url>" or "https:<url>". Remote checks aren't
+// supported, so they never pass.
+type remoteCheck string
+
+func (c remoteCheck) eval(*evaluation) bool { return false }
+
+// genericCheck is "<kind>:<match>". It compares the credential named kind
+// with match, which may refer to target attributes as %(name)s. A literal
+// kind, such as 'member' or True, is compared with match instead.
+type genericCheck struct {
+	kind  string
+	match string
+}
+
+func (c genericCheck) eval(e *evaluation) bool {
+	match, ok := substitute(c.match, e.target)
+	if !ok {
+		return false
+	}
+
+	if literal, ok := parseLiteral(c.kind); ok {
+		return match == literal
+	}
+
+	value, ok := lookup(e.creds, c.kind)
+	if !ok {
+		return false
+	}
+	if values, ok := value.([]string); ok {
+		for _, v := range values {
+			if v == match {
+				return true
+			}
+		}
+		return false
+	}
+	if values, ok := value.([]interface{}); ok {
+		for _, v := range values {
+			if format(v) == match {
+				return true
+			}
+		}
+		return false
+	}
+	return format(value) == match
+}
+
+var substitution = regexp.MustCompile(`%\(([^)]*)\)s`)
+
+// substitute replaces the %(name)s references of s with target attributes.
+// It returns false if an attribute is missing.
+func substitute(s string, target map[string]interface{}) (string, bool) {
+	found := true
+	result := substitution.ReplaceAllStringFunc(s, func(ref string) string {
+		value, ok := lookup(target, ref[2:len(ref)-2])
+		if !ok {
+			found = false
+			return ""
+		}
+		return format(value)
+	})
+	return result, found
+}
+
+// lookup returns the value of key in m. A key with dots that isn't found as
+// is, e.g. "target.project.id", is followed through nested maps.
+func lookup(m map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+
+	var current interface{} = m
+	for _, part := range strings.Split(key, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			v, ok := node[part]
+			if !ok {
+				return nil, false
+			}
+			current = v
+		case map[string]string:
+			v, ok := node[part]
+			if !ok {
+				return nil, false
+			}
+			current = v
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// format returns the string that oslo.policy would compare a value as.
+func format(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "None"
+	case string:
+		return v
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return "[" + strings.Join(v, ", ") + "]"
+	default:
+		return ""
+	}
+}
+
+// parseLiteral interprets a quoted string, a number or a boolean.
+func parseLiteral(s string) (string, bool) {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], true
+	}
+	switch s {
+	case "True", "False", "None":
+		return s, true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return s, true
+	}
+	return "", false
+}
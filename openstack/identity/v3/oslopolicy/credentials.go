@@ -0,0 +1,111 @@
+package oslopolicy
+
+import (
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/authtoken"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+)
+
+// Credentials describe the user and scope of a token, as oslo.policy sees
+// them.
+type Credentials struct {
+	UserID          string
+	UserDomainID    string
+	ProjectID       string
+	ProjectDomainID string
+	DomainID        string
+
+	// SystemScope is "all" for a system-scoped token.
+	SystemScope string
+
+	// Roles are the names of the roles of the token.
+	Roles []string
+
+	// Extra holds additional credentials that rules may refer to, such as
+	// "is_admin". Nested maps are reached with dotted names.
+	Extra map[string]interface{}
+}
+
+// toMap returns the credentials by the names oslo.policy uses for them.
+func (c Credentials) toMap() map[string]interface{} {
+	m := make(map[string]interface{}, len(c.Extra)+7)
+	for k, v := range c.Extra {
+		m[k] = v
+	}
+
+	set := func(name, value string) {
+		if value != "" {
+			m[name] = value
+		}
+	}
+	set("user_id", c.UserID)
+	set("user_domain_id", c.UserDomainID)
+	set("project_id", c.ProjectID)
+	set("project_domain_id", c.ProjectDomainID)
+	set("domain_id", c.DomainID)
+	set("system_scope", c.SystemScope)
+
+	roles := c.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	m["roles"] = roles
+	return m
+}
+
+// TokenResult is the result of a tokens.Create or tokens.Get request.
+type TokenResult interface {
+	ExtractUser() (*tokens.User, error)
+	ExtractProject() (*tokens.Project, error)
+	ExtractDomain() (*tokens.Domain, error)
+	ExtractSystem() (*tokens.System, error)
+	ExtractRoles() ([]tokens.Role, error)
+}
+
+// CredentialsFromToken extracts the credentials of a token from the result
+// of a tokens.Create or tokens.Get request.
+func CredentialsFromToken(r TokenResult) (Credentials, error) {
+	var id authtoken.Identity
+
+	user, err := r.ExtractUser()
+	if err != nil {
+		return Credentials{}, err
+	}
+	if user != nil {
+		id.User = *user
+	}
+	if id.Project, err = r.ExtractProject(); err != nil {
+		return Credentials{}, err
+	}
+	if id.Domain, err = r.ExtractDomain(); err != nil {
+		return Credentials{}, err
+	}
+	if id.System, err = r.ExtractSystem(); err != nil {
+		return Credentials{}, err
+	}
+	if id.Roles, err = r.ExtractRoles(); err != nil {
+		return Credentials{}, err
+	}
+
+	return CredentialsFromIdentity(&id), nil
+}
+
+// CredentialsFromIdentity returns the credentials of a token that was
+// validated by the authtoken middleware.
+func CredentialsFromIdentity(id *authtoken.Identity) Credentials {
+	creds := Credentials{
+		UserID:       id.User.ID,
+		UserDomainID: id.User.Domain.ID,
+		Roles:        id.RoleNames(),
+	}
+	if id.Project != nil {
+		creds.ProjectID = id.Project.ID
+		creds.ProjectDomainID = id.Project.Domain.ID
+	}
+	if id.Domain != nil {
+		creds.DomainID = id.Domain.ID
+	}
+	if id.System != nil && id.System.All {
+		creds.SystemScope = "all"
+	}
+	return creds
+}
@@ -0,0 +1,69 @@
+/*
+Package oslopolicy evaluates oslo.policy rules locally, so that services and
+user interfaces can make the same policy decisions as OpenStack services.
+
+Rules are parsed from policy.json or policy.yaml files, or from the blob of a
+policy stored with the policies package. The rule syntax of oslo.policy is
+supported:
+
+	"@"                               always passes
+	"!"                               never passes
+	"role:admin"                      the token has the role
+	"rule:admin_required"             the named rule passes
+	"project_id:%(project_id)s"       a credential equals a target attribute
+	"'member':%(target.role.name)s"   a literal equals a target attribute
+	"not", "and", "or", "( )"         combine checks
+
+Remote "http:" checks aren't supported and never pass.
+
+Example to Enforce a Rule
+
+	policy, err := oslopolicy.ParseYAML(policyYAML)
+	if err != nil {
+		panic(err)
+	}
+
+	creds, err := oslopolicy.CredentialsFromToken(tokens.Get(identityClient, tokenID))
+	if err != nil {
+		panic(err)
+	}
+
+	target := map[string]interface{}{
+		"project_id": "263fd9",
+	}
+
+	allowed, err := policy.Enforce("os_compute_api:servers:delete", target, creds)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Enforce a Rule in an HTTP Handler Behind the authtoken Middleware
+
+	func deleteServer(w http.ResponseWriter, r *http.Request) {
+		id, ok := authtoken.FromContext(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		creds := oslopolicy.CredentialsFromIdentity(id)
+		allowed, err := policy.Enforce("os_compute_api:servers:delete", target, creds)
+		if err != nil || !allowed {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+	}
+
+Example to Load a Policy Stored in the Identity Service
+
+	p, err := policies.Get(identityClient, "policy_id").Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	policy, err := oslopolicy.FromPolicy(*p)
+	if err != nil {
+		panic(err)
+	}
+*/
+package oslopolicy
@@ -0,0 +1,50 @@
+package oslopolicy
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrSyntax is returned when a rule can't be parsed.
+type ErrSyntax struct {
+	gophercloud.BaseError
+	Rule   string
+	Reason string
+}
+
+func (e ErrSyntax) Error() string {
+	return fmt.Sprintf("Invalid policy rule %q: %s", e.Rule, e.Reason)
+}
+
+// ErrRuleCycle is returned when rules refer to each other in a cycle.
+type ErrRuleCycle struct {
+	gophercloud.BaseError
+	Rule string
+}
+
+func (e ErrRuleCycle) Error() string {
+	return fmt.Sprintf("Policy rule %q refers to itself", e.Rule)
+}
+
+// ErrRuleNotFound is returned by Enforce for a rule that isn't defined, if
+// there is no "default" rule either.
+type ErrRuleNotFound struct {
+	gophercloud.BaseError
+	Rule string
+}
+
+func (e ErrRuleNotFound) Error() string {
+	return fmt.Sprintf("Policy rule %q is not defined", e.Rule)
+}
+
+// ErrUnsupportedType is returned by FromPolicy for a policy blob of an
+// unsupported media type.
+type ErrUnsupportedType struct {
+	gophercloud.BaseError
+	Type string
+}
+
+func (e ErrUnsupportedType) Error() string {
+	return fmt.Sprintf("Unsupported policy type %q", e.Type)
+}
@@ -0,0 +1,202 @@
+package oslopolicy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// token is a lexical element of a rule: "(", ")", "and", "or", "not", or a
+// check.
+type token struct {
+	kind  string
+	value string
+}
+
+// tokenize splits a rule the way oslo.policy does: by whitespace, with
+// parentheses split off the start and end of each word.
+func tokenize(rule string) []token {
+	var tokens []token
+	for _, word := range strings.Fields(rule) {
+		clean := strings.TrimLeft(word, "(")
+		for i := 0; i < len(word)-len(clean); i++ {
+			tokens = append(tokens, token{kind: "("})
+		}
+		if clean == "" {
+			continue
+		}
+
+		trimmed := strings.TrimRight(clean, ")")
+		switch lowered := strings.ToLower(trimmed); lowered {
+		case "and", "or", "not":
+			tokens = append(tokens, token{kind: lowered})
+		case "":
+		default:
+			tokens = append(tokens, token{kind: "check", value: trimmed})
+		}
+		for i := 0; i < len(clean)-len(trimmed); i++ {
+			tokens = append(tokens, token{kind: ")"})
+		}
+	}
+	return tokens
+}
+
+// parser is a recursive descent parser for the rule grammar:
+//
+//	expr := and ("or" and)*
+//	and  := not ("and" not)*
+//	not  := "not" not | "(" expr ")" | check
+type parser struct {
+	rule   string
+	tokens []token
+	pos    int
+}
+
+// parseRule parses a rule. An empty rule always passes.
+func parseRule(rule string) (check, error) {
+	p := &parser{rule: rule, tokens: tokenize(rule)}
+	if len(p.tokens) == 0 {
+		return constCheck(true), nil
+	}
+
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, p.errorf("unexpected %q", p.tokens[p.pos].kind)
+	}
+	return c, nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return ErrSyntax{Rule: p.rule, Reason: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos].kind
+	}
+	return ""
+}
+
+func (p *parser) parseOr() (check, error) {
+	c, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	checks := orCheck{c}
+	for p.peek() == "or" {
+		p.pos++
+		c, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, c)
+	}
+	if len(checks) == 1 {
+		return checks[0], nil
+	}
+	return checks, nil
+}
+
+func (p *parser) parseAnd() (check, error) {
+	c, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	checks := andCheck{c}
+	for p.peek() == "and" {
+		p.pos++
+		c, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, c)
+	}
+	if len(checks) == 1 {
+		return checks[0], nil
+	}
+	return checks, nil
+}
+
+func (p *parser) parseNot() (check, error) {
+	switch p.peek() {
+	case "not":
+		p.pos++
+		c, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notCheck{c}, nil
+	case "(":
+		p.pos++
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, p.errorf("missing %q", ")")
+		}
+		p.pos++
+		return c, nil
+	case "check":
+		c, err := p.parseCheck(p.tokens[p.pos].value)
+		p.pos++
+		return c, err
+	case "":
+		return nil, p.errorf("unexpected end of rule")
+	default:
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+}
+
+// parseCheck parses a single check, such as "role:admin" or "@".
+func (p *parser) parseCheck(s string) (check, error) {
+	switch s {
+	case "@":
+		return constCheck(true), nil
+	case "!":
+		return constCheck(false), nil
+	}
+
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, p.errorf("%q is not a check", s)
+	}
+	kind, match := parts[0], parts[1]
+
+	switch kind {
+	case "rule":
+		return ruleCheck(match), nil
+	case "role":
+		return roleCheck(match), nil
+	case "http", "https":
+		return remoteCheck(s), nil
+	default:
+		return genericCheck{kind: kind, match: match}, nil
+	}
+}
+
+// references returns the names of the rules that c refers to.
+func references(c check) []string {
+	switch c := c.(type) {
+	case ruleCheck:
+		return []string{string(c)}
+	case notCheck:
+		return references(c.check)
+	case andCheck:
+		var refs []string
+		for _, sub := range c {
+			refs = append(refs, references(sub)...)
+		}
+		return refs
+	case orCheck:
+		var refs []string
+		for _, sub := range c {
+			refs = append(refs, references(sub)...)
+		}
+		return refs
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,195 @@
+package oslopolicy
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/policies"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DefaultRule is the rule that Enforce falls back to for rules that aren't
+// defined.
+const DefaultRule = "default"
+
+// Policy is a set of parsed oslo.policy rules.
+type Policy struct {
+	rules map[string]check
+}
+
+// Parse parses rules given by name.
+func Parse(rules map[string]string) (*Policy, error) {
+	p := &Policy{rules: make(map[string]check, len(rules))}
+	for name, rule := range rules {
+		c, err := parseRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		p.rules[name] = c
+	}
+
+	if err := p.checkCycles(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ParseJSON parses a policy.json file.
+func ParseJSON(b []byte) (*Policy, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	return parseRaw(raw)
+}
+
+// ParseYAML parses a policy.yaml file.
+func ParseYAML(b []byte) (*Policy, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	return parseRaw(raw)
+}
+
+// FromPolicy parses the blob of a policy stored in the Identity service.
+func FromPolicy(policy policies.Policy) (*Policy, error) {
+	switch policy.Type {
+	case "application/json":
+		return ParseJSON([]byte(policy.Blob))
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return ParseYAML([]byte(policy.Blob))
+	default:
+		return nil, ErrUnsupportedType{Type: policy.Type}
+	}
+}
+
+// parseRaw parses decoded rules, which are either strings or lists of lists
+// of checks in the legacy format.
+func parseRaw(raw map[string]interface{}) (*Policy, error) {
+	rules := make(map[string]string, len(raw))
+	for name, value := range raw {
+		switch value := value.(type) {
+		case string:
+			rules[name] = value
+		case []interface{}:
+			rule, err := legacyRule(value)
+			if err != nil {
+				return nil, ErrSyntax{Rule: name, Reason: err.Error()}
+			}
+			rules[name] = rule
+		default:
+			return nil, ErrSyntax{Rule: name, Reason: "a rule must be a string or a list"}
+		}
+	}
+	return Parse(rules)
+}
+
+// legacyRule converts a rule in the list format, where any of the inner
+// lists must pass all of its checks, to the string format.
+func legacyRule(value []interface{}) (string, error) {
+	if len(value) == 0 {
+		return "@", nil
+	}
+
+	alternatives := make([]string, len(value))
+	for i, v := range value {
+		inner, ok := v.([]interface{})
+		if !ok {
+			return "", ErrSyntax{Reason: "a legacy rule must be a list of lists"}
+		}
+		checks := make([]string, len(inner))
+		for j, c := range inner {
+			s, ok := c.(string)
+			if !ok {
+				return "", ErrSyntax{Reason: "a check must be a string"}
+			}
+			checks[j] = s
+		}
+		alternatives[i] = "(" + strings.Join(checks, " and ") + ")"
+	}
+	return strings.Join(alternatives, " or "), nil
+}
+
+// checkCycles makes sure that no rule refers to itself, directly or through
+// other rules.
+func (p *Policy) checkCycles() error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(p.rules))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return ErrRuleCycle{Rule: name}
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		if c, ok := p.rules[name]; ok {
+			for _, ref := range references(c) {
+				if err := visit(ref); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = done
+		return nil
+	}
+
+	for _, name := range p.Names() {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Names returns the names of the rules of the policy, in sorted order.
+func (p *Policy) Names() []string {
+	names := make([]string, 0, len(p.rules))
+	for name := range p.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Enforce evaluates the named rule against a target and credentials. A rule
+// that isn't defined is replaced by the "default" rule, if there is one.
+func (p *Policy) Enforce(rule string, target map[string]interface{}, creds Credentials) (bool, error) {
+	c, ok := p.rules[rule]
+	if !ok {
+		c, ok = p.rules[DefaultRule]
+		if !ok {
+			return false, ErrRuleNotFound{Rule: rule}
+		}
+	}
+	return c.eval(p.evaluation(target, creds)), nil
+}
+
+// Check evaluates a rule given as a string, such as
+// "role:admin or project_id:%(project_id)s", against a target and
+// credentials. The rule may refer to the rules of the policy.
+func (p *Policy) Check(rule string, target map[string]interface{}, creds Credentials) (bool, error) {
+	c, err := parseRule(rule)
+	if err != nil {
+		return false, err
+	}
+	return c.eval(p.evaluation(target, creds)), nil
+}
+
+func (p *Policy) evaluation(target map[string]interface{}, creds Credentials) *evaluation {
+	if target == nil {
+		target = map[string]interface{}{}
+	}
+	return &evaluation{
+		rules:  p.rules,
+		target: target,
+		creds:  creds.toMap(),
+	}
+}
@@ -0,0 +1,2 @@
+// oslopolicy unit tests
+package testing
@@ -0,0 +1,60 @@
+package testing
+
+import "github.com/gophercloud/gophercloud/openstack/identity/v3/oslopolicy"
+
+// PolicyYAML is a policy file in the YAML format.
+const PolicyYAML = `
+"admin_required": "role:admin or is_admin:True"
+"owner": "project_id:%(project_id)s"
+"admin_or_owner": "rule:admin_required or rule:owner"
+"system_reader": "role:reader and system_scope:all"
+"default": "rule:admin_or_owner"
+
+"compute:servers:get": "rule:admin_or_owner or rule:system_reader"
+"compute:servers:delete": "rule:admin_or_owner and not role:readonly"
+"compute:servers:lock": "(role:admin or role:operator) and project_id:%(target.server.project_id)s"
+"compute:flavors:list": "@"
+"compute:flavors:create": "!"
+"identity:update_user": "user_id:%(target.user.id)s or rule:admin_required"
+"identity:grant_role": "'member':%(target.role.name)s and rule:owner"
+"identity:list_domain_users": "domain_id:%(domain_id)s or user_domain_id:%(domain_id)s"
+"remote": "http://policy.example.com/check"
+"empty": ""
+`
+
+// PolicyJSON is a policy file in the JSON format with rules in the legacy
+// list format.
+const PolicyJSON = `
+{
+    "admin_required": [["role:admin"], ["is_admin:True"]],
+    "owner": "project_id:%(project_id)s",
+    "admin_and_owner": [["role:admin", "rule:owner"]],
+    "anyone": []
+}
+`
+
+// Member is a member of project 263fd9.
+var Member = oslopolicy.Credentials{
+	UserID:          "0ca8f6",
+	UserDomainID:    "default",
+	ProjectID:       "263fd9",
+	ProjectDomainID: "default",
+	Roles:           []string{"member", "reader"},
+}
+
+// Admin is an administrator of project 7a8b9c.
+var Admin = oslopolicy.Credentials{
+	UserID:          "a0b1c2",
+	UserDomainID:    "default",
+	ProjectID:       "7a8b9c",
+	ProjectDomainID: "default",
+	Roles:           []string{"Admin"},
+}
+
+// SystemReader has the reader role on the system.
+var SystemReader = oslopolicy.Credentials{
+	UserID:       "d4e5f6",
+	UserDomainID: "default",
+	SystemScope:  "all",
+	Roles:        []string{"reader"},
+}
@@ -0,0 +1,232 @@
+package testing
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/authtoken"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/oslopolicy"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/policies"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestEnforce(t *testing.T) {
+	policy, err := oslopolicy.ParseYAML([]byte(PolicyYAML))
+	th.AssertNoErr(t, err)
+
+	ownTarget := map[string]interface{}{"project_id": "263fd9"}
+	otherTarget := map[string]interface{}{"project_id": "999999"}
+	lockTarget := map[string]interface{}{
+		"target.server.project_id": "7a8b9c",
+	}
+	nestedLockTarget := map[string]interface{}{
+		"target": map[string]interface{}{
+			"server": map[string]interface{}{"project_id": "7a8b9c"},
+		},
+	}
+
+	isAdmin := Member
+	isAdmin.Extra = map[string]interface{}{"is_admin": true}
+
+	readonly := Member
+	readonly.Roles = []string{"member", "ReadOnly"}
+
+	cases := []struct {
+		rule     string
+		target   map[string]interface{}
+		creds    oslopolicy.Credentials
+		expected bool
+	}{
+		{"compute:servers:get", ownTarget, Member, true},
+		{"compute:servers:get", otherTarget, Member, false},
+		{"compute:servers:get", otherTarget, Admin, true},
+		{"compute:servers:get", otherTarget, SystemReader, true},
+		{"compute:servers:get", otherTarget, isAdmin, true},
+		{"compute:servers:delete", ownTarget, Member, true},
+		{"compute:servers:delete", ownTarget, readonly, false},
+		{"compute:servers:lock", lockTarget, Admin, true},
+		{"compute:servers:lock", nestedLockTarget, Admin, true},
+		{"compute:servers:lock", ownTarget, Admin, false},
+		{"compute:servers:lock", lockTarget, Member, false},
+		{"compute:flavors:list", nil, oslopolicy.Credentials{}, true},
+		{"compute:flavors:create", nil, Admin, false},
+		{"identity:update_user", map[string]interface{}{"target.user.id": "0ca8f6"}, Member, true},
+		{"identity:update_user", map[string]interface{}{"target.user.id": "a0b1c2"}, Member, false},
+		{"identity:grant_role", map[string]interface{}{"target.role.name": "member", "project_id": "263fd9"}, Member, true},
+		{"identity:grant_role", map[string]interface{}{"target.role.name": "admin", "project_id": "263fd9"}, Member, false},
+		{"identity:list_domain_users", map[string]interface{}{"domain_id": "default"}, Member, true},
+		{"remote", nil, Admin, false},
+		{"empty", nil, oslopolicy.Credentials{}, true},
+		// Undefined rules fall back to the default rule.
+		{"compute:servers:resize", ownTarget, Member, true},
+		{"compute:servers:resize", otherTarget, Member, false},
+	}
+
+	for _, c := range cases {
+		actual, err := policy.Enforce(c.rule, c.target, c.creds)
+		th.AssertNoErr(t, err)
+		if actual != c.expected {
+			t.Errorf("Enforce(%q, %v, %+v) = %v, expected %v", c.rule, c.target, c.creds, actual, c.expected)
+		}
+	}
+}
+
+func TestEnforceWithoutDefault(t *testing.T) {
+	policy, err := oslopolicy.Parse(map[string]string{"a": "@"})
+	th.AssertNoErr(t, err)
+
+	_, err = policy.Enforce("b", nil, Member)
+	if _, ok := err.(oslopolicy.ErrRuleNotFound); !ok {
+		t.Fatalf("Expected ErrRuleNotFound, got %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	policy, err := oslopolicy.ParseYAML([]byte(PolicyYAML))
+	th.AssertNoErr(t, err)
+
+	cases := []struct {
+		rule     string
+		expected bool
+	}{
+		{"role:member", true},
+		{"role:MEMBER", true},
+		{"role:admin", false},
+		{"not role:admin", true},
+		{"not not role:admin", false},
+		{"role:admin or role:member and project_id:263fd9", true},
+		{"(role:admin or role:member) and project_id:999999", false},
+		{"((role:member))", true},
+		{"role:reader AND NOT rule:admin_required", true},
+		{"rule:undefined", false},
+		{"user_id:%(missing)s", false},
+		{"roles:reader", true},
+		{"True:True", true},
+		{"'x':y", false},
+	}
+
+	for _, c := range cases {
+		actual, err := policy.Check(c.rule, nil, Member)
+		th.AssertNoErr(t, err)
+		if actual != c.expected {
+			t.Errorf("Check(%q) = %v, expected %v", c.rule, actual, c.expected)
+		}
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	for _, rule := range []string{
+		"role:admin or",
+		"(role:admin",
+		"role:admin)",
+		"and role:admin",
+		"admin",
+		"role:admin role:member",
+	} {
+		_, err := oslopolicy.Parse(map[string]string{"rule": rule})
+		if _, ok := err.(oslopolicy.ErrSyntax); !ok {
+			t.Errorf("Expected ErrSyntax for %q, got %v", rule, err)
+		}
+	}
+}
+
+func TestRuleCycle(t *testing.T) {
+	_, err := oslopolicy.Parse(map[string]string{
+		"a": "rule:b",
+		"b": "role:admin or rule:c",
+		"c": "rule:a",
+	})
+	if _, ok := err.(oslopolicy.ErrRuleCycle); !ok {
+		t.Fatalf("Expected ErrRuleCycle, got %v", err)
+	}
+}
+
+func TestParseLegacyJSON(t *testing.T) {
+	policy, err := oslopolicy.ParseJSON([]byte(PolicyJSON))
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, []string{"admin_and_owner", "admin_required", "anyone", "owner"}, policy.Names())
+
+	target := map[string]interface{}{"project_id": "7a8b9c"}
+
+	allowed, err := policy.Enforce("admin_and_owner", target, Admin)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, allowed)
+
+	allowed, err = policy.Enforce("admin_and_owner", target, Member)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, allowed)
+
+	allowed, err = policy.Enforce("anyone", nil, Member)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, allowed)
+}
+
+func TestFromPolicy(t *testing.T) {
+	policy, err := oslopolicy.FromPolicy(policies.Policy{
+		Type: "application/json",
+		Blob: PolicyJSON,
+	})
+	th.AssertNoErr(t, err)
+
+	allowed, err := policy.Enforce("admin_required", nil, Admin)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, allowed)
+
+	_, err = oslopolicy.FromPolicy(policies.Policy{Type: "text/plain"})
+	if _, ok := err.(oslopolicy.ErrUnsupportedType); !ok {
+		t.Fatalf("Expected ErrUnsupportedType, got %v", err)
+	}
+}
+
+func TestCredentialsFromToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Subject-Token", "abcdef")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`
+			{
+				"token": {
+					"expires_at": "2013-02-02T18:30:59.000000Z",
+					"user": {
+						"domain": { "id": "default" },
+						"id": "0ca8f6"
+					},
+					"project": {
+						"domain": { "id": "default" },
+						"id": "263fd9"
+					},
+					"roles": [
+						{ "id": "51cc68", "name": "member" },
+						{ "id": "9fe2ff", "name": "reader" }
+					]
+				}
+			}
+		`))
+	})
+
+	creds, err := oslopolicy.CredentialsFromToken(tokens.Get(client.ServiceClient(), "abcdef"))
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, Member, creds)
+}
+
+func TestCredentialsFromIdentity(t *testing.T) {
+	id := &authtoken.Identity{
+		ExpiresAt: time.Now().Add(time.Hour),
+		User: tokens.User{
+			ID:     "d4e5f6",
+			Domain: tokens.Domain{ID: "default"},
+		},
+		System: &tokens.System{All: true},
+		Roles:  []tokens.Role{{ID: "9fe2ff", Name: "reader"}},
+	}
+
+	th.AssertDeepEquals(t, SystemReader, oslopolicy.CredentialsFromIdentity(id))
+}
@@ -116,15 +116,17 @@ func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r Create
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(createURL(client), &b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Post(createURL(client), &b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{201},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Get retrieves details on a single policy, by ID.
 func Get(client *gophercloud.ServiceClient, policyID string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, policyID), &r.Body, nil)
+	resp, err := client.Get(getURL(client, policyID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -182,14 +184,16 @@ func Update(client *gophercloud.ServiceClient, policyID string, opts UpdateOptsB
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Patch(updateURL(client, policyID), &b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Patch(updateURL(client, policyID), &b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Delete deletes a policy.
 func Delete(client *gophercloud.ServiceClient, policyID string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, policyID), nil)
+	resp, err := client.Delete(deleteURL(client, policyID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
@@ -22,6 +22,34 @@ Example to List Projects
 		fmt.Printf("%+v\n", project)
 	}
 
+Example to List the Projects Available to the Current Token
+
+	allPages, err := projects.ListAvailable(identityClient).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allProjects, err := projects.ExtractProjects(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, project := range allProjects {
+		fmt.Printf("%+v\n", project)
+	}
+
+Example to List Projects with Tags
+
+	listOpts := projects.ListOpts{
+		Tags:    "production,web",
+		NotTags: "deprecated",
+	}
+
+	allPages, err := projects.List(identityClient, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
 Example to Create a Project
 
 	createOpts := projects.CreateOpts{
@@ -54,5 +82,36 @@ Example to Delete a Project
 	if err != nil {
 		panic(err)
 	}
+
+Example to Replace the Tags of a Project
+
+	projectID := "966b3c7d36a24facaf20b7e458bf2192"
+
+	modifyOpts := projects.ModifyTagsOpts{
+		Tags: []string{"production", "web"},
+	}
+
+	tags, err := projects.ModifyTags(identityClient, projectID, modifyOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Add a Tag to a Project
+
+	projectID := "966b3c7d36a24facaf20b7e458bf2192"
+	err := projects.AddTag(identityClient, projectID, "database").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Check whether a Project has a Tag
+
+	projectID := "966b3c7d36a24facaf20b7e458bf2192"
+	err := projects.CheckTag(identityClient, projectID, "database").ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault404); ok {
+		fmt.Println("the project isn't tagged with database")
+	} else if err != nil {
+		panic(err)
+	}
 */
 package projects
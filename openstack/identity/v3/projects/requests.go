@@ -32,6 +32,22 @@ type ListOpts struct {
 	// ParentID filters the response by projects of a given parent project.
 	ParentID string `q:"parent_id"`
 
+	// Tags filters on specific project tags. All tags must be present for the
+	// project. It is a comma-separated list of tags.
+	Tags string `q:"tags"`
+
+	// TagsAny filters on specific project tags. At least one of the tags must
+	// be present for the project. It is a comma-separated list of tags.
+	TagsAny string `q:"tags-any"`
+
+	// NotTags filters on specific project tags. All tags must be absent for
+	// the project. It is a comma-separated list of tags.
+	NotTags string `q:"not-tags"`
+
+	// NotTagsAny filters on specific project tags. At least one of the tags
+	// must be absent for the project. It is a comma-separated list of tags.
+	NotTagsAny string `q:"not-tags-any"`
+
 	// Filters filters the response by custom filters such as
 	// 'name__contains=foo'
 	Filters map[string]string `q:"-"`
@@ -73,9 +89,20 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 	})
 }
 
+// ListAvailable enumerates the projects which are available to a specific user,
+// that is, those that the current token can be rescoped to. It is typically
+// used after a federated login, which yields an unscoped token.
+func ListAvailable(client *gophercloud.ServiceClient) pagination.Pager {
+	url := listAvailableURL(client)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return ProjectPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
 // Get retrieves details on a single project, by ID.
 func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	resp, err := client.Get(getURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -104,6 +131,9 @@ type CreateOpts struct {
 
 	// Description is the description of the project.
 	Description string `json:"description,omitempty"`
+
+	// Tags is a list of tags to associate with the project.
+	Tags []string `json:"tags,omitempty"`
 }
 
 // ToProjectCreateMap formats a CreateOpts into a create request.
@@ -118,13 +148,15 @@ func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r Create
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(createURL(client), &b, &r.Body, nil)
+	resp, err := client.Post(createURL(client), &b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Delete deletes a project.
 func Delete(client *gophercloud.ServiceClient, projectID string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, projectID), nil)
+	resp, err := client.Delete(deleteURL(client, projectID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -153,6 +185,10 @@ type UpdateOpts struct {
 
 	// Description is the description of the project.
 	Description string `json:"description,omitempty"`
+
+	// Tags replaces the list of tags associated with the project. An empty
+	// list removes all of the tags.
+	Tags *[]string `json:"tags,omitempty"`
 }
 
 // ToUpdateCreateMap formats a UpdateOpts into an update request.
@@ -167,8 +203,80 @@ func Update(client *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Patch(updateURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Patch(updateURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListTags lists the tags of a project.
+func ListTags(client *gophercloud.ServiceClient, projectID string) (r ListTagsResult) {
+	resp, err := client.Get(tagsURL(client, projectID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ModifyTagsOptsBuilder allows extensions to add additional parameters to
+// the ModifyTags request.
+type ModifyTagsOptsBuilder interface {
+	ToModifyTagsMap() (map[string]interface{}, error)
+}
+
+// ModifyTagsOpts represents the tags that replace those of a project.
+type ModifyTagsOpts struct {
+	// Tags is the new list of tags of the project.
+	Tags []string `json:"tags" required:"true"`
+}
+
+// ToModifyTagsMap formats a ModifyTagsOpts into a request body.
+func (opts ModifyTagsOpts) ToModifyTagsMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "")
+}
+
+// ModifyTags replaces all of the tags of a project.
+func ModifyTags(client *gophercloud.ServiceClient, projectID string, opts ModifyTagsOptsBuilder) (r ModifyTagsResult) {
+	b, err := opts.ToModifyTagsMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(tagsURL(client, projectID), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteTags removes all of the tags of a project.
+func DeleteTags(client *gophercloud.ServiceClient, projectID string) (r DeleteTagsResult) {
+	resp, err := client.Delete(tagsURL(client, projectID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// AddTag adds a single tag to a project.
+func AddTag(client *gophercloud.ServiceClient, projectID, tag string) (r AddTagResult) {
+	resp, err := client.Put(tagURL(client, projectID, tag), nil, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CheckTag checks whether a project has a tag. A project without the tag
+// results in a gophercloud.ErrDefault404.
+func CheckTag(client *gophercloud.ServiceClient, projectID, tag string) (r CheckTagResult) {
+	resp, err := client.Head(tagURL(client, projectID, tag), &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteTag removes a single tag from a project.
+func DeleteTag(client *gophercloud.ServiceClient, projectID, tag string) (r DeleteTagResult) {
+	resp, err := client.Delete(tagURL(client, projectID, tag), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
@@ -55,6 +55,9 @@ type Project struct {
 
 	// ParentID is the parent_id of the project.
 	ParentID string `json:"parent_id"`
+
+	// Tags is the list of tags associated with the project.
+	Tags []string `json:"tags,omitempty"`
 }
 
 // ProjectPage is a single page of Project results.
@@ -101,3 +104,53 @@ func (r projectResult) Extract() (*Project, error) {
 	err := r.ExtractInto(&s)
 	return s.Project, err
 }
+
+type tagsResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a ListTagsResult or ModifyTagsResult as the list of tags
+// of a project.
+func (r tagsResult) Extract() ([]string, error) {
+	var s struct {
+		Tags []string `json:"tags"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Tags, err
+}
+
+// ListTagsResult is the result of a ListTags request. Call its Extract method
+// to interpret it as a list of tags.
+type ListTagsResult struct {
+	tagsResult
+}
+
+// ModifyTagsResult is the result of a ModifyTags request. Call its Extract
+// method to interpret it as a list of tags.
+type ModifyTagsResult struct {
+	tagsResult
+}
+
+// DeleteTagsResult is the result of a DeleteTags request. Call its ExtractErr
+// method to determine if the request succeeded or failed.
+type DeleteTagsResult struct {
+	gophercloud.ErrResult
+}
+
+// AddTagResult is the result of an AddTag request. Call its ExtractErr method
+// to determine if the request succeeded or failed.
+type AddTagResult struct {
+	gophercloud.ErrResult
+}
+
+// CheckTagResult is the result of a CheckTag request. Call its ExtractErr
+// method to determine if the project has the tag.
+type CheckTagResult struct {
+	gophercloud.ErrResult
+}
+
+// DeleteTagResult is the result of a DeleteTag request. Call its ExtractErr
+// method to determine if the request succeeded or failed.
+type DeleteTagResult struct {
+	gophercloud.ErrResult
+}
@@ -140,6 +140,21 @@ func HandleListProjectsSuccessfully(t *testing.T) {
 	})
 }
 
+// HandleListAvailableProjectsSuccessfully creates an HTTP handler at
+// `/auth/projects` on the test handler mux that responds with a list of two
+// projects.
+func HandleListAvailableProjectsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/auth/projects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListOutput)
+	})
+}
+
 // HandleGetProjectSuccessfully creates an HTTP handler at `/projects` on the
 // test handler mux that responds with a single project.
 func HandleGetProjectSuccessfully(t *testing.T) {
@@ -190,3 +205,89 @@ func HandleUpdateProjectSuccessfully(t *testing.T) {
 		fmt.Fprintf(w, UpdateOutput)
 	})
 }
+
+// ListTagsOutput provides the tags of a project.
+const ListTagsOutput = `
+{
+  "links": {
+    "self": "http://example.com/identity/v3/projects/1234/tags"
+  },
+  "tags": ["production", "web"]
+}
+`
+
+// ModifyTagsRequest provides the input to a ModifyTags request.
+const ModifyTagsRequest = `
+{
+  "tags": ["production", "web"]
+}
+`
+
+// ExpectedTags is the list of tags expected to be returned from
+// ListTagsOutput.
+var ExpectedTags = []string{"production", "web"}
+
+// HandleListTagsSuccessfully creates an HTTP handler at `/projects/1234/tags`
+// on the test handler mux that responds with the tags of a project.
+func HandleListTagsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/projects/1234/tags", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListTagsOutput)
+	})
+}
+
+// HandleModifyTagsSuccessfully creates an HTTP handler at
+// `/projects/1234/tags` on the test handler mux that tests replacing the
+// tags of a project.
+func HandleModifyTagsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/projects/1234/tags", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, ModifyTagsRequest)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListTagsOutput)
+	})
+}
+
+// HandleDeleteTagsSuccessfully creates an HTTP handler at
+// `/projects/1234/tags` on the test handler mux that tests removing all of
+// the tags of a project.
+func HandleDeleteTagsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/projects/1234/tags", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleTagSuccessfully creates an HTTP handler at
+// `/projects/1234/tags/web` on the test handler mux that tests adding,
+// checking and removing a single tag. Checking any other tag results in a
+// 404.
+func HandleTagSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/projects/1234/tags/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		if r.URL.Path != "/projects/1234/tags/web" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case "PUT":
+			w.WriteHeader(http.StatusCreated)
+		case "HEAD", "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
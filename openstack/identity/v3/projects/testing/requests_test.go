@@ -3,6 +3,7 @@ package testing
 import (
 	"testing"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
@@ -109,3 +110,109 @@ func TestUpdateProject(t *testing.T) {
 	th.AssertNoErr(t, err)
 	th.CheckDeepEquals(t, UpdatedRedTeam, *actual)
 }
+
+func TestListAvailableProjects(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListAvailableProjectsSuccessfully(t)
+
+	count := 0
+	err := projects.ListAvailable(client.ServiceClient()).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := projects.ExtractProjects(page)
+		th.AssertNoErr(t, err)
+
+		th.CheckDeepEquals(t, ExpectedProjectSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestListProjectsTagsQuery(t *testing.T) {
+	listOpts := projects.ListOpts{
+		Tags:       "production,web",
+		NotTagsAny: "deprecated",
+	}
+
+	query, err := listOpts.ToProjectListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?not-tags-any=deprecated&tags=production%2Cweb", query)
+}
+
+func TestCreateProjectWithTags(t *testing.T) {
+	createOpts := projects.CreateOpts{
+		Name: "Red Team",
+		Tags: []string{"production", "web"},
+	}
+
+	b, err := createOpts.ToProjectCreateMap()
+	th.AssertNoErr(t, err)
+	th.AssertJSONEquals(t, `{"project": {"name": "Red Team", "tags": ["production", "web"]}}`, b)
+}
+
+func TestUpdateProjectClearTags(t *testing.T) {
+	tags := []string{}
+	updateOpts := projects.UpdateOpts{
+		Tags: &tags,
+	}
+
+	b, err := updateOpts.ToProjectUpdateMap()
+	th.AssertNoErr(t, err)
+	th.AssertJSONEquals(t, `{"project": {"tags": []}}`, b)
+}
+
+func TestListTags(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListTagsSuccessfully(t)
+
+	actual, err := projects.ListTags(client.ServiceClient(), "1234").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedTags, actual)
+}
+
+func TestModifyTags(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleModifyTagsSuccessfully(t)
+
+	modifyOpts := projects.ModifyTagsOpts{
+		Tags: []string{"production", "web"},
+	}
+
+	actual, err := projects.ModifyTags(client.ServiceClient(), "1234", modifyOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedTags, actual)
+}
+
+func TestDeleteTags(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteTagsSuccessfully(t)
+
+	err := projects.DeleteTags(client.ServiceClient(), "1234").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestTag(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleTagSuccessfully(t)
+
+	err := projects.AddTag(client.ServiceClient(), "1234", "web").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = projects.CheckTag(client.ServiceClient(), "1234", "web").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = projects.CheckTag(client.ServiceClient(), "1234", "database").ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("Expected ErrDefault404, got %v", err)
+	}
+
+	err = projects.DeleteTag(client.ServiceClient(), "1234", "web").ExtractErr()
+	th.AssertNoErr(t, err)
+}
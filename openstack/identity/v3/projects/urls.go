@@ -1,11 +1,19 @@
 package projects
 
-import "github.com/gophercloud/gophercloud"
+import (
+	"net/url"
+
+	"github.com/gophercloud/gophercloud"
+)
 
 func listURL(client *gophercloud.ServiceClient) string {
 	return client.ServiceURL("projects")
 }
 
+func listAvailableURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL("auth", "projects")
+}
+
 func getURL(client *gophercloud.ServiceClient, projectID string) string {
 	return client.ServiceURL("projects", projectID)
 }
@@ -21,3 +29,11 @@ func deleteURL(client *gophercloud.ServiceClient, projectID string) string {
 func updateURL(client *gophercloud.ServiceClient, projectID string) string {
 	return client.ServiceURL("projects", projectID)
 }
+
+func tagsURL(client *gophercloud.ServiceClient, projectID string) string {
+	return client.ServiceURL("projects", projectID, "tags")
+}
+
+func tagURL(client *gophercloud.ServiceClient, projectID, tag string) string {
+	return client.ServiceURL("projects", projectID, "tags", url.PathEscape(tag))
+}
@@ -0,0 +1,67 @@
+/*
+Package registeredlimits manages the registered limits of the unified limits
+in the OpenStack Identity Service. A registered limit is the default limit of
+a resource of a service, which applies to every project that doesn't have a
+limit of its own. Managing registered limits requires a system-scoped token.
+
+Example to List Registered Limits
+
+	listOpts := registeredlimits.ListOpts{
+		ServiceID: "9408080f1970482aa0e38bc2d4ea34b7",
+	}
+
+	allPages, err := registeredlimits.List(identityClient, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allLimits, err := registeredlimits.ExtractRegisteredLimits(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, limit := range allLimits {
+		fmt.Printf("%+v\n", limit)
+	}
+
+Example to Create Registered Limits
+
+	batchCreateOpts := registeredlimits.BatchCreateOpts{
+		registeredlimits.CreateOpts{
+			ServiceID:    "9408080f1970482aa0e38bc2d4ea34b7",
+			ResourceName: "cores",
+			DefaultLimit: 10,
+		},
+		registeredlimits.CreateOpts{
+			ServiceID:    "9408080f1970482aa0e38bc2d4ea34b7",
+			RegionID:     "RegionOne",
+			ResourceName: "ram_mb",
+			DefaultLimit: 20480,
+		},
+	}
+
+	createdLimits, err := registeredlimits.BatchCreate(identityClient, batchCreateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Update a Registered Limit
+
+	defaultLimit := 20
+	updateOpts := registeredlimits.UpdateOpts{
+		DefaultLimit: &defaultLimit,
+	}
+
+	limit, err := registeredlimits.Update(identityClient, "registered_limit_id", updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Registered Limit
+
+	err := registeredlimits.Delete(identityClient, "registered_limit_id").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package registeredlimits
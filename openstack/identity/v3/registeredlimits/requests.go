@@ -0,0 +1,161 @@
+package registeredlimits
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to
+// the List request
+type ListOptsBuilder interface {
+	ToRegisteredLimitListQuery() (string, error)
+}
+
+// ListOpts provides options to filter the List results.
+type ListOpts struct {
+	// ServiceID filters the response by a service ID.
+	ServiceID string `q:"service_id"`
+
+	// RegionID filters the response by a region ID.
+	RegionID string `q:"region_id"`
+
+	// ResourceName filters the response by a resource name.
+	ResourceName string `q:"resource_name"`
+}
+
+// ToRegisteredLimitListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToRegisteredLimitListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List enumerates the registered limits.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := rootURL(client)
+	if opts != nil {
+		query, err := opts.ToRegisteredLimitListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return RegisteredLimitPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// BatchCreateOptsBuilder allows extensions to add additional parameters to
+// the BatchCreate request.
+type BatchCreateOptsBuilder interface {
+	ToRegisteredLimitsCreateMap() (map[string]interface{}, error)
+}
+
+// CreateOpts provides options used to create a registered limit.
+type CreateOpts struct {
+	// ServiceID is the ID of the service the limit applies to.
+	ServiceID string `json:"service_id" required:"true"`
+
+	// RegionID is the ID of the region the limit applies to. If it is empty,
+	// the limit applies to all regions.
+	RegionID string `json:"region_id,omitempty"`
+
+	// ResourceName is the name of the limited resource, e.g. "cores".
+	ResourceName string `json:"resource_name" required:"true"`
+
+	// DefaultLimit is the limit that applies to projects without a limit of
+	// their own.
+	DefaultLimit int `json:"default_limit"`
+
+	// Description is a description of the registered limit.
+	Description string `json:"description,omitempty"`
+}
+
+// BatchCreateOpts provides the registered limits to create in a single
+// request.
+type BatchCreateOpts []CreateOpts
+
+// ToRegisteredLimitsCreateMap formats a BatchCreateOpts into a create
+// request.
+func (opts BatchCreateOpts) ToRegisteredLimitsCreateMap() (map[string]interface{}, error) {
+	registeredLimits := make([]map[string]interface{}, len(opts))
+	for i, limit := range opts {
+		b, err := gophercloud.BuildRequestBody(limit, "")
+		if err != nil {
+			return nil, err
+		}
+		registeredLimits[i] = b
+	}
+	return map[string]interface{}{"registered_limits": registeredLimits}, nil
+}
+
+// BatchCreate creates new registered limits.
+func BatchCreate(client *gophercloud.ServiceClient, opts BatchCreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToRegisteredLimitsCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Post(rootURL(client), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Get retrieves details on a single registered limit, by ID.
+func Get(client *gophercloud.ServiceClient, registeredLimitID string) (r GetResult) {
+	resp, err := client.Get(resourceURL(client, registeredLimitID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to
+// the Update request.
+type UpdateOptsBuilder interface {
+	ToRegisteredLimitUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateOpts provides options for updating a registered limit.
+type UpdateOpts struct {
+	// ServiceID is the ID of the service the limit applies to.
+	ServiceID string `json:"service_id,omitempty"`
+
+	// RegionID is the ID of the region the limit applies to.
+	RegionID string `json:"region_id,omitempty"`
+
+	// ResourceName is the name of the limited resource.
+	ResourceName string `json:"resource_name,omitempty"`
+
+	// DefaultLimit is the limit that applies to projects without a limit of
+	// their own.
+	DefaultLimit *int `json:"default_limit,omitempty"`
+
+	// Description is a description of the registered limit.
+	Description *string `json:"description,omitempty"`
+}
+
+// ToRegisteredLimitUpdateMap formats an UpdateOpts into an update request.
+func (opts UpdateOpts) ToRegisteredLimitUpdateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "registered_limit")
+}
+
+// Update updates an existing registered limit.
+func Update(client *gophercloud.ServiceClient, registeredLimitID string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToRegisteredLimitUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(resourceURL(client, registeredLimitID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete deletes a registered limit.
+func Delete(client *gophercloud.ServiceClient, registeredLimitID string) (r DeleteResult) {
+	resp, err := client.Delete(resourceURL(client, registeredLimitID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
@@ -0,0 +1,2 @@
+// registeredlimits unit tests
+package testing
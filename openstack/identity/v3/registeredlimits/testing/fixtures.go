@@ -0,0 +1,192 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/registeredlimits"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+// ListOutput provides a single page of RegisteredLimit results.
+const ListOutput = `
+{
+    "links": {
+        "self": "http://example.com/identity/v3/registered_limits",
+        "previous": null,
+        "next": null
+    },
+    "registered_limits": [
+        {
+            "id": "3229b3849f584faea483d6851f7aab05",
+            "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+            "region_id": null,
+            "resource_name": "snapshot",
+            "default_limit": 5,
+            "description": null,
+            "links": {
+                "self": "http://example.com/identity/v3/registered_limits/3229b3849f584faea483d6851f7aab05"
+            }
+        },
+        {
+            "id": "7d7d8b4aa2d84ea4a1d1e6d3b0a46a7d",
+            "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+            "region_id": "RegionOne",
+            "resource_name": "volume",
+            "default_limit": 10,
+            "description": "Number of volumes",
+            "links": {
+                "self": "http://example.com/identity/v3/registered_limits/7d7d8b4aa2d84ea4a1d1e6d3b0a46a7d"
+            }
+        }
+    ]
+}
+`
+
+// GetOutput provides a Get result.
+const GetOutput = `
+{
+    "registered_limit": {
+        "id": "3229b3849f584faea483d6851f7aab05",
+        "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+        "region_id": null,
+        "resource_name": "snapshot",
+        "default_limit": 5,
+        "description": null,
+        "links": {
+            "self": "http://example.com/identity/v3/registered_limits/3229b3849f584faea483d6851f7aab05"
+        }
+    }
+}
+`
+
+// CreateRequest provides the input to a BatchCreate request.
+const CreateRequest = `
+{
+    "registered_limits": [
+        {
+            "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+            "resource_name": "snapshot",
+            "default_limit": 5
+        },
+        {
+            "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+            "region_id": "RegionOne",
+            "resource_name": "volume",
+            "default_limit": 10,
+            "description": "Number of volumes"
+        }
+    ]
+}
+`
+
+// UpdateRequest provides the input to an Update request.
+const UpdateRequest = `
+{
+    "registered_limit": {
+        "default_limit": 15
+    }
+}
+`
+
+// UpdateOutput provides an Update result.
+const UpdateOutput = `
+{
+    "registered_limit": {
+        "id": "3229b3849f584faea483d6851f7aab05",
+        "service_id": "9408080f1970482aa0e38bc2d4ea34b7",
+        "region_id": null,
+        "resource_name": "snapshot",
+        "default_limit": 15,
+        "description": null,
+        "links": {
+            "self": "http://example.com/identity/v3/registered_limits/3229b3849f584faea483d6851f7aab05"
+        }
+    }
+}
+`
+
+// FirstRegisteredLimit is the first registered limit in the List request.
+var FirstRegisteredLimit = registeredlimits.RegisteredLimit{
+	ID:           "3229b3849f584faea483d6851f7aab05",
+	ServiceID:    "9408080f1970482aa0e38bc2d4ea34b7",
+	ResourceName: "snapshot",
+	DefaultLimit: 5,
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/registered_limits/3229b3849f584faea483d6851f7aab05",
+	},
+}
+
+// SecondRegisteredLimit is the second registered limit in the List request.
+var SecondRegisteredLimit = registeredlimits.RegisteredLimit{
+	ID:           "7d7d8b4aa2d84ea4a1d1e6d3b0a46a7d",
+	ServiceID:    "9408080f1970482aa0e38bc2d4ea34b7",
+	RegionID:     "RegionOne",
+	ResourceName: "volume",
+	DefaultLimit: 10,
+	Description:  "Number of volumes",
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/registered_limits/7d7d8b4aa2d84ea4a1d1e6d3b0a46a7d",
+	},
+}
+
+// ExpectedRegisteredLimitsSlice is the slice of registered limits expected to
+// be returned from ListOutput.
+var ExpectedRegisteredLimitsSlice = []registeredlimits.RegisteredLimit{FirstRegisteredLimit, SecondRegisteredLimit}
+
+// HandleListRegisteredLimitsSuccessfully creates an HTTP handler at
+// `/registered_limits` on the test handler mux that responds with a list of
+// two registered limits.
+func HandleListRegisteredLimitsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/registered_limits", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{"service_id": "9408080f1970482aa0e38bc2d4ea34b7"})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListOutput)
+	})
+}
+
+// HandleBatchCreateRegisteredLimitsSuccessfully creates an HTTP handler at
+// `/registered_limits` on the test handler mux that tests registered limit
+// creation.
+func HandleBatchCreateRegisteredLimitsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/registered_limits", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, CreateRequest)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, ListOutput)
+	})
+}
+
+// HandleRegisteredLimitSuccessfully creates an HTTP handler at
+// `/registered_limits/{id}` on the test handler mux that gets, updates and
+// deletes a registered limit.
+func HandleRegisteredLimitSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/registered_limits/3229b3849f584faea483d6851f7aab05", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, GetOutput)
+		case "PATCH":
+			th.TestJSONRequest(t, r, UpdateRequest)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, UpdateOutput)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
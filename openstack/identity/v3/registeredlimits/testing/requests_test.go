@@ -0,0 +1,93 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/registeredlimits"
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestListRegisteredLimits(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListRegisteredLimitsSuccessfully(t)
+
+	listOpts := registeredlimits.ListOpts{
+		ServiceID: "9408080f1970482aa0e38bc2d4ea34b7",
+	}
+
+	count := 0
+	err := registeredlimits.List(client.ServiceClient(), listOpts).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := registeredlimits.ExtractRegisteredLimits(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, ExpectedRegisteredLimitsSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestBatchCreateRegisteredLimits(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleBatchCreateRegisteredLimitsSuccessfully(t)
+
+	createOpts := registeredlimits.BatchCreateOpts{
+		registeredlimits.CreateOpts{
+			ServiceID:    "9408080f1970482aa0e38bc2d4ea34b7",
+			ResourceName: "snapshot",
+			DefaultLimit: 5,
+		},
+		registeredlimits.CreateOpts{
+			ServiceID:    "9408080f1970482aa0e38bc2d4ea34b7",
+			RegionID:     "RegionOne",
+			ResourceName: "volume",
+			DefaultLimit: 10,
+			Description:  "Number of volumes",
+		},
+	}
+
+	actual, err := registeredlimits.BatchCreate(client.ServiceClient(), createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedRegisteredLimitsSlice, actual)
+}
+
+func TestBatchCreateRegisteredLimitsRequiresResourceName(t *testing.T) {
+	createOpts := registeredlimits.BatchCreateOpts{
+		registeredlimits.CreateOpts{
+			ServiceID:    "9408080f1970482aa0e38bc2d4ea34b7",
+			DefaultLimit: 5,
+		},
+	}
+
+	res := registeredlimits.BatchCreate(client.ServiceClient(), createOpts)
+	if res.Err == nil {
+		t.Fatal("BatchCreate without a resource name should fail")
+	}
+}
+
+func TestGetUpdateDeleteRegisteredLimit(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleRegisteredLimitSuccessfully(t)
+
+	actual, err := registeredlimits.Get(client.ServiceClient(), "3229b3849f584faea483d6851f7aab05").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FirstRegisteredLimit, *actual)
+
+	defaultLimit := 15
+	updateOpts := registeredlimits.UpdateOpts{
+		DefaultLimit: &defaultLimit,
+	}
+	actual, err = registeredlimits.Update(client.ServiceClient(), "3229b3849f584faea483d6851f7aab05", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 15, actual.DefaultLimit)
+
+	err = registeredlimits.Delete(client.ServiceClient(), "3229b3849f584faea483d6851f7aab05").ExtractErr()
+	th.AssertNoErr(t, err)
+}
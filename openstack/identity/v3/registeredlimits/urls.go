@@ -0,0 +1,13 @@
+package registeredlimits
+
+import "github.com/gophercloud/gophercloud"
+
+const rootPath = "registered_limits"
+
+func rootURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL(rootPath)
+}
+
+func resourceURL(client *gophercloud.ServiceClient, registeredLimitID string) string {
+	return client.ServiceURL(rootPath, registeredLimitID)
+}
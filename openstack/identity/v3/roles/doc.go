@@ -131,5 +131,57 @@ Example to Unassign a Role From a User in a Project
 	if err != nil {
 		panic(err)
 	}
+
+Example to Assign a Role to a User on the System
+
+	userID := "9df1a02f5eb2416a9781e8b0c022d3ae"
+	roleID := "9fe2ff9ee4384b1894a90878d3e92bab"
+
+	err := roles.AssignSystem(identityClient, roleID, roles.SystemAssignmentOpts{
+		UserID: userID,
+	}).ExtractErr()
+
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the System Role Assignments
+
+	listOpts := roles.ListAssignmentsOpts{
+		ScopeSystem: "all",
+	}
+
+	allPages, err := roles.ListAssignments(identityClient, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allRoleAssignments, err := roles.ExtractRoleAssignments(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Make a Role Imply Another Role
+
+	priorRoleID := "9fe2ff9ee4384b1894a90878d3e92bab"
+	impliedRoleID := "b1e5bbd5bdab4dd1b7ec7a9ec9e1e0a0"
+
+	rule, err := roles.CreateRoleInferenceRule(identityClient, priorRoleID, impliedRoleID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the Role Inference Rules
+
+	allImpliedRoles, err := roles.ListRoleInferenceRules(identityClient).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, implied := range allImpliedRoles {
+		for _, role := range implied.ImpliedRoles {
+			fmt.Printf("%s implies %s\n", implied.PriorRole.Name, role.Name)
+		}
+	}
 */
 package roles
@@ -66,7 +66,8 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 
 // Get retrieves details on a single role, by ID.
 func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	resp, err := client.Get(getURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -113,9 +114,10 @@ func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r Create
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(createURL(client), &b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Post(createURL(client), &b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{201},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -159,15 +161,17 @@ func Update(client *gophercloud.ServiceClient, roleID string, opts UpdateOptsBui
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Patch(updateURL(client, roleID), &b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Patch(updateURL(client, roleID), &b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Delete deletes a role.
 func Delete(client *gophercloud.ServiceClient, roleID string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, roleID), nil)
+	resp, err := client.Delete(deleteURL(client, roleID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -194,6 +198,10 @@ type ListAssignmentsOpts struct {
 	// ScopeProjectID filters the results by the given Project ID.
 	ScopeProjectID string `q:"scope.project.id"`
 
+	// ScopeSystem filters the results by the system scope. The only
+	// supported value is "all".
+	ScopeSystem string `q:"scope.system"`
+
 	// UserID filterst he results by the given User ID.
 	UserID string `q:"user.id"`
 
@@ -348,9 +356,10 @@ func Assign(client *gophercloud.ServiceClient, roleID string, opts AssignOpts) (
 		actorType = "groups"
 	}
 
-	_, r.Err = client.Put(assignURL(client, targetType, targetID, actorType, actorID, roleID), nil, nil, &gophercloud.RequestOpts{
+	resp, err := client.Put(assignURL(client, targetType, targetID, actorType, actorID, roleID), nil, nil, &gophercloud.RequestOpts{
 		OkCodes: []int{204},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -385,8 +394,150 @@ func Unassign(client *gophercloud.ServiceClient, roleID string, opts UnassignOpt
 		actorType = "groups"
 	}
 
-	_, r.Err = client.Delete(assignURL(client, targetType, targetID, actorType, actorID, roleID), &gophercloud.RequestOpts{
+	resp, err := client.Delete(assignURL(client, targetType, targetID, actorType, actorID, roleID), &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// SystemAssignmentOpts identifies the user or group of a role assignment on
+// the system.
+type SystemAssignmentOpts struct {
+	// UserID is the ID of a user.
+	// Note: exactly one of UserID or GroupID must be provided
+	UserID string `xor:"GroupID"`
+
+	// GroupID is the ID of a group.
+	// Note: exactly one of UserID or GroupID must be provided
+	GroupID string `xor:"UserID"`
+}
+
+// actor returns the URL path segment and the ID of the user or group.
+func (opts SystemAssignmentOpts) actor() (string, string, error) {
+	// Check xor conditions
+	_, err := gophercloud.BuildRequestBody(opts, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	if opts.UserID != "" {
+		return "users", opts.UserID, nil
+	}
+	return "groups", opts.GroupID, nil
+}
+
+// ListSystemAssignments is the operation responsible for listing the roles
+// a user/group has on the system.
+func ListSystemAssignments(client *gophercloud.ServiceClient, opts SystemAssignmentOpts) pagination.Pager {
+	actorType, actorID, err := opts.actor()
+	if err != nil {
+		return pagination.Pager{Err: err}
+	}
+
+	url := systemRolesURL(client, actorType, actorID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return RolePage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// AssignSystem is the operation responsible for assigning a role
+// to a user/group on the system.
+func AssignSystem(client *gophercloud.ServiceClient, roleID string, opts SystemAssignmentOpts) (r AssignmentResult) {
+	actorType, actorID, err := opts.actor()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	resp, err := client.Put(systemRoleURL(client, actorType, actorID, roleID), nil, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CheckSystem is the operation responsible for checking whether a user/group
+// has a role on the system. The result's ExtractErr returns a
+// gophercloud.ErrDefault404 if it doesn't.
+func CheckSystem(client *gophercloud.ServiceClient, roleID string, opts SystemAssignmentOpts) (r CheckSystemResult) {
+	actorType, actorID, err := opts.actor()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	resp, err := client.Head(systemRoleURL(client, actorType, actorID, roleID), &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UnassignSystem is the operation responsible for unassigning a role
+// from a user/group on the system.
+func UnassignSystem(client *gophercloud.ServiceClient, roleID string, opts SystemAssignmentOpts) (r UnassignmentResult) {
+	actorType, actorID, err := opts.actor()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	resp, err := client.Delete(systemRoleURL(client, actorType, actorID, roleID), &gophercloud.RequestOpts{
 		OkCodes: []int{204},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateRoleInferenceRule creates a rule by which a user who is assigned the
+// prior role is implicitly granted the implied role as well.
+func CreateRoleInferenceRule(client *gophercloud.ServiceClient, priorRoleID, impliedRoleID string) (r CreateRoleInferenceRuleResult) {
+	resp, err := client.Put(roleInferenceRuleURL(client, priorRoleID, impliedRoleID), nil, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetRoleInferenceRule retrieves the rule by which the prior role implies
+// the implied role.
+func GetRoleInferenceRule(client *gophercloud.ServiceClient, priorRoleID, impliedRoleID string) (r GetRoleInferenceRuleResult) {
+	resp, err := client.Get(roleInferenceRuleURL(client, priorRoleID, impliedRoleID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CheckRoleInferenceRule checks whether the prior role implies the implied
+// role. The result's ExtractErr returns a gophercloud.ErrDefault404 if it
+// doesn't.
+func CheckRoleInferenceRule(client *gophercloud.ServiceClient, priorRoleID, impliedRoleID string) (r CheckRoleInferenceRuleResult) {
+	resp, err := client.Head(roleInferenceRuleURL(client, priorRoleID, impliedRoleID), &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteRoleInferenceRule deletes the rule by which the prior role implies
+// the implied role.
+func DeleteRoleInferenceRule(client *gophercloud.ServiceClient, priorRoleID, impliedRoleID string) (r DeleteRoleInferenceRuleResult) {
+	resp, err := client.Delete(roleInferenceRuleURL(client, priorRoleID, impliedRoleID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListImpliedRoles lists the roles that are implied by a prior role.
+func ListImpliedRoles(client *gophercloud.ServiceClient, priorRoleID string) (r ListImpliedRolesResult) {
+	resp, err := client.Get(impliedRolesURL(client, priorRoleID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListRoleInferenceRules lists all of the role inference rules, grouped by
+// prior role.
+func ListRoleInferenceRules(client *gophercloud.ServiceClient) (r ListRoleInferenceRulesResult) {
+	resp, err := client.Get(listRoleInferenceRulesURL(client), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
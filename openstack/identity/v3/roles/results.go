@@ -146,6 +146,12 @@ type AssignedRole struct {
 type Scope struct {
 	Domain  Domain  `json:"domain,omitempty"`
 	Project Project `json:"project,omitempty"`
+	System  System  `json:"system,omitempty"`
+}
+
+// System represents the system in a role assignment scope.
+type System struct {
+	All bool `json:"all,omitempty"`
 }
 
 // Domain represents a domain in a role assignment scope.
@@ -212,3 +218,112 @@ type AssignmentResult struct {
 type UnassignmentResult struct {
 	gophercloud.ErrResult
 }
+
+// CheckSystemResult represents the result of a system role check.
+// Call ExtractErr method to determine if the request succeeded or failed.
+type CheckSystemResult struct {
+	gophercloud.ErrResult
+}
+
+// InferenceRole represents a Role in a role inference rule.
+type InferenceRole struct {
+	// ID is the unique ID of the role.
+	ID string `json:"id"`
+
+	// Name is the role name.
+	Name string `json:"name"`
+
+	// Links contains referencing links to the role.
+	Links map[string]interface{} `json:"links"`
+}
+
+// RoleInferenceRule is a rule by which the prior role implies another role.
+type RoleInferenceRule struct {
+	// PriorRole is the role which implies ImpliedRole.
+	PriorRole InferenceRole `json:"prior_role"`
+
+	// ImpliedRole is the role which is implied by PriorRole.
+	ImpliedRole InferenceRole `json:"implies"`
+}
+
+// ImpliedRoles are the roles implied by a prior role.
+type ImpliedRoles struct {
+	// PriorRole is the role which implies ImpliedRoles.
+	PriorRole InferenceRole `json:"prior_role"`
+
+	// ImpliedRoles are the roles which are implied by PriorRole.
+	ImpliedRoles []InferenceRole `json:"implies"`
+}
+
+type roleInferenceRuleResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a CreateRoleInferenceRuleResult or
+// GetRoleInferenceRuleResult as a RoleInferenceRule.
+func (r roleInferenceRuleResult) Extract() (*RoleInferenceRule, error) {
+	var s struct {
+		RoleInference *RoleInferenceRule `json:"role_inference"`
+	}
+	err := r.ExtractInto(&s)
+	return s.RoleInference, err
+}
+
+// CreateRoleInferenceRuleResult is the response from a
+// CreateRoleInferenceRule operation. Call its Extract method to interpret it
+// as a RoleInferenceRule.
+type CreateRoleInferenceRuleResult struct {
+	roleInferenceRuleResult
+}
+
+// GetRoleInferenceRuleResult is the response from a GetRoleInferenceRule
+// operation. Call its Extract method to interpret it as a RoleInferenceRule.
+type GetRoleInferenceRuleResult struct {
+	roleInferenceRuleResult
+}
+
+// CheckRoleInferenceRuleResult represents the result of a role inference
+// rule check. Call ExtractErr method to determine if the request succeeded
+// or failed.
+type CheckRoleInferenceRuleResult struct {
+	gophercloud.ErrResult
+}
+
+// DeleteRoleInferenceRuleResult is the response from a
+// DeleteRoleInferenceRule operation. Call its ExtractErr method to determine
+// if the request succeeded or failed.
+type DeleteRoleInferenceRuleResult struct {
+	gophercloud.ErrResult
+}
+
+// ListImpliedRolesResult is the response from a ListImpliedRoles operation.
+// Call its Extract method to interpret it as ImpliedRoles.
+type ListImpliedRolesResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a ListImpliedRolesResult as ImpliedRoles.
+func (r ListImpliedRolesResult) Extract() (*ImpliedRoles, error) {
+	var s struct {
+		RoleInference *ImpliedRoles `json:"role_inference"`
+	}
+	err := r.ExtractInto(&s)
+	return s.RoleInference, err
+}
+
+// ListRoleInferenceRulesResult is the response from a ListRoleInferenceRules
+// operation. Call its Extract method to interpret it as a slice of
+// ImpliedRoles.
+type ListRoleInferenceRulesResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a ListRoleInferenceRulesResult as a slice of
+// ImpliedRoles, one for each prior role.
+func (r ListRoleInferenceRulesResult) Extract() ([]ImpliedRoles, error) {
+	var s struct {
+		RoleInferences []ImpliedRoles `json:"role_inferences"`
+	}
+	err := r.ExtractInto(&s)
+	return s.RoleInferences, err
+}
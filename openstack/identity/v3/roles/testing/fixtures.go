@@ -387,3 +387,255 @@ func HandleListAssignmentsOnResourceSuccessfully(t *testing.T) {
 	th.Mux.HandleFunc("/domains/{domain_id}/users/{user_id}/roles", fn)
 	th.Mux.HandleFunc("/domains/{domain_id}/groups/{group_id}/roles", fn)
 }
+
+// ListSystemAssignmentOutput provides a result of a ListAssignments request
+// filtered by the system scope.
+const ListSystemAssignmentOutput = `
+{
+    "role_assignments": [
+        {
+            "links": {
+                "assignment": "http://example.com/identity/v3/system/users/313233/roles/123456"
+            },
+            "role": {
+                "id": "123456"
+            },
+            "scope": {
+                "system": {
+                    "all": true
+                }
+            },
+            "user": {
+                "id": "313233"
+            }
+        }
+    ],
+    "links": {
+        "self": "http://example.com/identity/v3/role_assignments?scope.system=all",
+        "previous": null,
+        "next": null
+    }
+}
+`
+
+// SystemRoleAssignment is the role assignment in the ListSystemAssignmentOutput.
+var SystemRoleAssignment = roles.RoleAssignment{
+	Role:  roles.AssignedRole{ID: "123456"},
+	Scope: roles.Scope{System: roles.System{All: true}},
+	User:  roles.User{ID: "313233"},
+}
+
+// HandleListSystemRoleAssignmentsSuccessfully creates an HTTP handler at
+// `/role_assignments` on the test handler mux that responds with a system
+// role assignment.
+func HandleListSystemRoleAssignmentsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/role_assignments", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"scope.system": "all"})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListSystemAssignmentOutput)
+	})
+}
+
+// HandleSystemAssignmentsSuccessfully creates HTTP handlers on the test
+// handler mux that list, assign, check and unassign system roles of users
+// and groups.
+func HandleSystemAssignmentsSuccessfully(t *testing.T) {
+	list := func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListAssignmentsOnResourceOutput)
+	}
+
+	assignment := func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		switch r.Method {
+		case "PUT", "HEAD", "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	}
+
+	th.Mux.HandleFunc("/system/users/{user_id}/roles", list)
+	th.Mux.HandleFunc("/system/groups/{group_id}/roles", list)
+	th.Mux.HandleFunc("/system/users/{user_id}/roles/{role_id}", assignment)
+	th.Mux.HandleFunc("/system/groups/{group_id}/roles/{role_id}", assignment)
+	th.Mux.HandleFunc("/system/users/{user_id}/roles/{other_role_id}", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "HEAD")
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+// RoleInferenceRuleOutput provides the result of CreateRoleInferenceRule and
+// GetRoleInferenceRule requests.
+const RoleInferenceRuleOutput = `
+{
+    "role_inference": {
+        "prior_role": {
+            "id": "7ceab6192ea34a548cc71b24f72e762c",
+            "links": {
+                "self": "http://example.com/identity/v3/roles/7ceab6192ea34a548cc71b24f72e762c"
+            },
+            "name": "prior role name"
+        },
+        "implies": {
+            "id": "97e2f5d38bc94842bc3da818c16762ed",
+            "links": {
+                "self": "http://example.com/identity/v3/roles/97e2f5d38bc94842bc3da818c16762ed"
+            },
+            "name": "implied role name"
+        }
+    },
+    "links": {
+        "self": "http://example.com/identity/v3/roles/7ceab6192ea34a548cc71b24f72e762c/implies/97e2f5d38bc94842bc3da818c16762ed"
+    }
+}
+`
+
+// ListImpliedRolesOutput provides the result of a ListImpliedRoles request.
+const ListImpliedRolesOutput = `
+{
+    "role_inference": {
+        "prior_role": {
+            "id": "7ceab6192ea34a548cc71b24f72e762c",
+            "links": {
+                "self": "http://example.com/identity/v3/roles/7ceab6192ea34a548cc71b24f72e762c"
+            },
+            "name": "prior role name"
+        },
+        "implies": [
+            {
+                "id": "97e2f5d38bc94842bc3da818c16762ed",
+                "links": {
+                    "self": "http://example.com/identity/v3/roles/97e2f5d38bc94842bc3da818c16762ed"
+                },
+                "name": "implied role name"
+            }
+        ]
+    },
+    "links": {
+        "self": "http://example.com/identity/v3/roles/7ceab6192ea34a548cc71b24f72e762c/implies"
+    }
+}
+`
+
+// ListRoleInferenceRulesOutput provides the result of a
+// ListRoleInferenceRules request.
+const ListRoleInferenceRulesOutput = `
+{
+    "role_inferences": [
+        {
+            "prior_role": {
+                "id": "7ceab6192ea34a548cc71b24f72e762c",
+                "links": {
+                    "self": "http://example.com/identity/v3/roles/7ceab6192ea34a548cc71b24f72e762c"
+                },
+                "name": "prior role name"
+            },
+            "implies": [
+                {
+                    "id": "97e2f5d38bc94842bc3da818c16762ed",
+                    "links": {
+                        "self": "http://example.com/identity/v3/roles/97e2f5d38bc94842bc3da818c16762ed"
+                    },
+                    "name": "implied role name"
+                }
+            ]
+        }
+    ],
+    "links": {
+        "self": "http://example.com/identity/v3/role_inferences"
+    }
+}
+`
+
+// PriorRole is the prior role of the role inference rule fixtures.
+var PriorRole = roles.InferenceRole{
+	ID:   "7ceab6192ea34a548cc71b24f72e762c",
+	Name: "prior role name",
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/roles/7ceab6192ea34a548cc71b24f72e762c",
+	},
+}
+
+// ImpliedRole is the implied role of the role inference rule fixtures.
+var ImpliedRole = roles.InferenceRole{
+	ID:   "97e2f5d38bc94842bc3da818c16762ed",
+	Name: "implied role name",
+	Links: map[string]interface{}{
+		"self": "http://example.com/identity/v3/roles/97e2f5d38bc94842bc3da818c16762ed",
+	},
+}
+
+// ExpectedRoleInferenceRule is the RoleInferenceRule expected from
+// RoleInferenceRuleOutput.
+var ExpectedRoleInferenceRule = roles.RoleInferenceRule{
+	PriorRole:   PriorRole,
+	ImpliedRole: ImpliedRole,
+}
+
+// ExpectedImpliedRoles is the ImpliedRoles expected from
+// ListImpliedRolesOutput.
+var ExpectedImpliedRoles = roles.ImpliedRoles{
+	PriorRole:    PriorRole,
+	ImpliedRoles: []roles.InferenceRole{ImpliedRole},
+}
+
+// HandleRoleInferenceRuleSuccessfully creates an HTTP handler on the test
+// handler mux that creates, gets, checks and deletes a role inference rule.
+func HandleRoleInferenceRuleSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/roles/7ceab6192ea34a548cc71b24f72e762c/implies/97e2f5d38bc94842bc3da818c16762ed", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		switch r.Method {
+		case "PUT":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, RoleInferenceRuleOutput)
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, RoleInferenceRuleOutput)
+		case "HEAD", "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
+
+// HandleListImpliedRolesSuccessfully creates an HTTP handler at
+// `/roles/7ceab6192ea34a548cc71b24f72e762c/implies` on the test handler mux
+// that responds with the roles implied by a prior role.
+func HandleListImpliedRolesSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/roles/7ceab6192ea34a548cc71b24f72e762c/implies", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListImpliedRolesOutput)
+	})
+}
+
+// HandleListRoleInferenceRulesSuccessfully creates an HTTP handler at
+// `/role_inferences` on the test handler mux that responds with all of the
+// role inference rules.
+func HandleListRoleInferenceRulesSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/role_inferences", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ListRoleInferenceRulesOutput)
+	})
+}
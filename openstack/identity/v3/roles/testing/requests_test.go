@@ -3,6 +3,7 @@ package testing
 import (
 	"testing"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/roles"
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
@@ -276,3 +277,102 @@ func TestUnassign(t *testing.T) {
 	}).ExtractErr()
 	th.AssertNoErr(t, err)
 }
+
+func TestListSystemRoleAssignments(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListSystemRoleAssignmentsSuccessfully(t)
+
+	allPages, err := roles.ListAssignments(client.ServiceClient(), roles.ListAssignmentsOpts{
+		ScopeSystem: "all",
+	}).AllPages()
+	th.AssertNoErr(t, err)
+
+	actual, err := roles.ExtractRoleAssignments(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []roles.RoleAssignment{SystemRoleAssignment}, actual)
+}
+
+func TestSystemAssignments(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleSystemAssignmentsSuccessfully(t)
+
+	for _, opts := range []roles.SystemAssignmentOpts{
+		{UserID: "{user_id}"},
+		{GroupID: "{group_id}"},
+	} {
+		allPages, err := roles.ListSystemAssignments(client.ServiceClient(), opts).AllPages()
+		th.AssertNoErr(t, err)
+		actual, err := roles.ExtractRoles(allPages)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, ExpectedRolesOnResourceSlice, actual)
+
+		err = roles.AssignSystem(client.ServiceClient(), "{role_id}", opts).ExtractErr()
+		th.AssertNoErr(t, err)
+
+		err = roles.CheckSystem(client.ServiceClient(), "{role_id}", opts).ExtractErr()
+		th.AssertNoErr(t, err)
+
+		err = roles.UnassignSystem(client.ServiceClient(), "{role_id}", opts).ExtractErr()
+		th.AssertNoErr(t, err)
+	}
+
+	err := roles.CheckSystem(client.ServiceClient(), "{other_role_id}", roles.SystemAssignmentOpts{
+		UserID: "{user_id}",
+	}).ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("Expected a 404 for an unassigned role, got %v", err)
+	}
+
+	err = roles.AssignSystem(client.ServiceClient(), "{role_id}", roles.SystemAssignmentOpts{
+		UserID:  "{user_id}",
+		GroupID: "{group_id}",
+	}).ExtractErr()
+	if err == nil {
+		t.Fatal("AssignSystem with both a user and a group should fail")
+	}
+}
+
+func TestRoleInferenceRule(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleRoleInferenceRuleSuccessfully(t)
+
+	priorRoleID := "7ceab6192ea34a548cc71b24f72e762c"
+	impliedRoleID := "97e2f5d38bc94842bc3da818c16762ed"
+
+	actual, err := roles.CreateRoleInferenceRule(client.ServiceClient(), priorRoleID, impliedRoleID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedRoleInferenceRule, *actual)
+
+	actual, err = roles.GetRoleInferenceRule(client.ServiceClient(), priorRoleID, impliedRoleID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedRoleInferenceRule, *actual)
+
+	err = roles.CheckRoleInferenceRule(client.ServiceClient(), priorRoleID, impliedRoleID).ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = roles.DeleteRoleInferenceRule(client.ServiceClient(), priorRoleID, impliedRoleID).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestListImpliedRoles(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListImpliedRolesSuccessfully(t)
+
+	actual, err := roles.ListImpliedRoles(client.ServiceClient(), "7ceab6192ea34a548cc71b24f72e762c").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedImpliedRoles, *actual)
+}
+
+func TestListRoleInferenceRules(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListRoleInferenceRulesSuccessfully(t)
+
+	actual, err := roles.ListRoleInferenceRules(client.ServiceClient()).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []roles.ImpliedRoles{ExpectedImpliedRoles}, actual)
+}
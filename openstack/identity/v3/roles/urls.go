@@ -34,6 +34,26 @@ func listAssignmentsOnResourceURL(client *gophercloud.ServiceClient, targetType,
 	return client.ServiceURL(targetType, targetID, actorType, actorID, rolePath)
 }
 
+func systemRolesURL(client *gophercloud.ServiceClient, actorType, actorID string) string {
+	return client.ServiceURL("system", actorType, actorID, rolePath)
+}
+
+func systemRoleURL(client *gophercloud.ServiceClient, actorType, actorID, roleID string) string {
+	return client.ServiceURL("system", actorType, actorID, rolePath, roleID)
+}
+
 func assignURL(client *gophercloud.ServiceClient, targetType, targetID, actorType, actorID, roleID string) string {
 	return client.ServiceURL(targetType, targetID, actorType, actorID, rolePath, roleID)
 }
+
+func impliedRolesURL(client *gophercloud.ServiceClient, priorRoleID string) string {
+	return client.ServiceURL(rolePath, priorRoleID, "implies")
+}
+
+func roleInferenceRuleURL(client *gophercloud.ServiceClient, priorRoleID, impliedRoleID string) string {
+	return client.ServiceURL(rolePath, priorRoleID, "implies", impliedRoleID)
+}
+
+func listRoleInferenceRulesURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL("role_inferences")
+}
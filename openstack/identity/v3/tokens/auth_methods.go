@@ -0,0 +1,272 @@
+package tokens
+
+import (
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// AuthMethod is a single authentication method of a MultiMethodAuthOptions
+// request. Additional methods can be supported by implementing it.
+type AuthMethod interface {
+	// AuthMethodName returns the name under which the method is listed in
+	// the "methods" of the request, such as "password" or "totp".
+	AuthMethodName() string
+
+	// ToAuthMethodMap returns the body of the method, which is sent under
+	// its name in the "identity" section of the request.
+	ToAuthMethodMap() (map[string]interface{}, error)
+}
+
+// authUser builds the "user" object shared by the password and totp methods.
+// The user is identified either by ID or by name and domain.
+func authUser(userID, username, domainID, domainName string) (map[string]interface{}, error) {
+	if userID != "" {
+		if username != "" {
+			return nil, gophercloud.ErrUsernameOrUserID{}
+		}
+		if domainID != "" {
+			return nil, gophercloud.ErrDomainIDWithUserID{}
+		}
+		if domainName != "" {
+			return nil, gophercloud.ErrDomainNameWithUserID{}
+		}
+		return map[string]interface{}{"id": userID}, nil
+	}
+
+	if username == "" {
+		return nil, gophercloud.ErrUsernameOrUserID{}
+	}
+	if (domainID == "") == (domainName == "") {
+		return nil, gophercloud.ErrDomainIDOrDomainName{}
+	}
+
+	domain := map[string]interface{}{"id": domainID}
+	if domainName != "" {
+		domain = map[string]interface{}{"name": domainName}
+	}
+	return map[string]interface{}{"name": username, "domain": domain}, nil
+}
+
+// PasswordMethod authenticates a user with their password.
+type PasswordMethod struct {
+	// The user is identified either by UserID, or by Username together with
+	// exactly one of DomainID and DomainName.
+	UserID     string
+	Username   string
+	DomainID   string
+	DomainName string
+
+	Password string
+}
+
+// AuthMethodName implements AuthMethod.
+func (m *PasswordMethod) AuthMethodName() string {
+	return "password"
+}
+
+// ToAuthMethodMap implements AuthMethod.
+func (m *PasswordMethod) ToAuthMethodMap() (map[string]interface{}, error) {
+	if m.Password == "" {
+		return nil, gophercloud.ErrMissingPassword{}
+	}
+	user, err := authUser(m.UserID, m.Username, m.DomainID, m.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	user["password"] = m.Password
+	return map[string]interface{}{"user": user}, nil
+}
+
+// TokenMethod authenticates with an existing token.
+type TokenMethod struct {
+	ID string
+}
+
+// AuthMethodName implements AuthMethod.
+func (m *TokenMethod) AuthMethodName() string {
+	return "token"
+}
+
+// ToAuthMethodMap implements AuthMethod.
+func (m *TokenMethod) ToAuthMethodMap() (map[string]interface{}, error) {
+	if m.ID == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "ID"}
+	}
+	return map[string]interface{}{"id": m.ID}, nil
+}
+
+// TOTPMethod authenticates a user with a time-based one-time password.
+type TOTPMethod struct {
+	// The user is identified either by UserID, or by Username together with
+	// exactly one of DomainID and DomainName.
+	UserID     string
+	Username   string
+	DomainID   string
+	DomainName string
+
+	// Passcode is the current one-time password, as shown by the user's
+	// authenticator app.
+	Passcode string
+
+	// Secret is the base32 encoded shared secret of the user's TOTP
+	// credential. If Passcode is empty, a passcode is generated from it
+	// for every request, which allows an unattended client to
+	// reauthenticate.
+	Secret string
+}
+
+// AuthMethodName implements AuthMethod.
+func (m *TOTPMethod) AuthMethodName() string {
+	return "totp"
+}
+
+// ToAuthMethodMap implements AuthMethod.
+func (m *TOTPMethod) ToAuthMethodMap() (map[string]interface{}, error) {
+	passcode := m.Passcode
+	if passcode == "" {
+		if m.Secret == "" {
+			return nil, gophercloud.ErrMissingInput{Argument: "Passcode"}
+		}
+		var err error
+		passcode, err = GenerateTOTPPasscode(m.Secret, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := authUser(m.UserID, m.Username, m.DomainID, m.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	user["passcode"] = passcode
+	return map[string]interface{}{"user": user}, nil
+}
+
+// ApplicationCredentialMethod authenticates with an application credential,
+// identified either by ID, or by name and the UserID or Username and domain
+// of its owner.
+type ApplicationCredentialMethod struct {
+	ID     string
+	Name   string
+	Secret string
+
+	UserID     string
+	Username   string
+	DomainID   string
+	DomainName string
+}
+
+// AuthMethodName implements AuthMethod.
+func (m *ApplicationCredentialMethod) AuthMethodName() string {
+	return "application_credential"
+}
+
+// ToAuthMethodMap implements AuthMethod.
+func (m *ApplicationCredentialMethod) ToAuthMethodMap() (map[string]interface{}, error) {
+	if m.Secret == "" {
+		return nil, gophercloud.ErrAppCredMissingSecret{}
+	}
+	if m.ID != "" {
+		return map[string]interface{}{"id": m.ID, "secret": m.Secret}, nil
+	}
+	if m.Name == "" {
+		return nil, gophercloud.ErrMissingInput{Argument: "ID"}
+	}
+	user, err := authUser(m.UserID, m.Username, m.DomainID, m.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"name": m.Name, "secret": m.Secret, "user": user}, nil
+}
+
+// AuthReceiptBuilder is implemented by an AuthOptionsBuilder that continues
+// an authentication with the receipt Keystone returned for the methods
+// already satisfied. Create sends the receipt in the Openstack-Auth-Receipt
+// header.
+type AuthReceiptBuilder interface {
+	AuthReceipt() string
+}
+
+// AuthHeadersBuilder is implemented by an AuthOptionsBuilder whose
+// credentials are sent in request headers rather than in the body, such as
+// the signed requests of OAuth1. Create passes it the URL the request is
+// sent to and adds the returned headers.
+type AuthHeadersBuilder interface {
+	ToTokenV3HeadersMap(url string) (map[string]string, error)
+}
+
+// MultiMethodAuthOptions composes several authentication methods into one
+// request, as required for users whose accounts have multi-factor auth
+// rules, such as password and totp.
+//
+// The methods may also be provided over several requests. Keystone then
+// answers each incomplete request with an ErrAdditionalAuthRequired, whose
+// Receipt must be passed on with the remaining methods.
+type MultiMethodAuthOptions struct {
+	// Methods are the authentication methods to use, in order.
+	Methods []AuthMethod
+
+	// Receipt is the auth receipt of an earlier request that satisfied
+	// some of the required methods.
+	Receipt string
+
+	// Scope determines the scoping of the authentication request.
+	Scope Scope
+
+	// AllowReauth allows Gophercloud to reauthenticate with these methods
+	// when the token expires. A TOTPMethod can only be reused if it
+	// generates passcodes from its Secret.
+	AllowReauth bool
+}
+
+// ToTokenV3CreateMap builds a request body from MultiMethodAuthOptions.
+func (opts *MultiMethodAuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[string]interface{}, error) {
+	if len(opts.Methods) == 0 {
+		return nil, gophercloud.ErrMissingInput{Argument: "Methods"}
+	}
+
+	var methods []string
+	identity := map[string]interface{}{}
+	for _, m := range opts.Methods {
+		name := m.AuthMethodName()
+		if _, ok := identity[name]; ok {
+			return nil, gophercloud.ErrInvalidInput{
+				ErrMissingInput: gophercloud.ErrMissingInput{Argument: "Methods"},
+				Value:           name,
+			}
+		}
+		body, err := m.ToAuthMethodMap()
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, name)
+		identity[name] = body
+	}
+	identity["methods"] = methods
+
+	auth := map[string]interface{}{"identity": identity}
+	if len(scope) != 0 {
+		auth["scope"] = scope
+	}
+	return map[string]interface{}{"auth": auth}, nil
+}
+
+// ToTokenV3ScopeMap builds a scope request body from MultiMethodAuthOptions.
+func (opts *MultiMethodAuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
+	scope := gophercloud.AuthScope(opts.Scope)
+	gophercloudAuthOpts := gophercloud.AuthOptions{
+		Scope: &scope,
+	}
+	return gophercloudAuthOpts.ToTokenV3ScopeMap()
+}
+
+// CanReauth reports whether the options may be reused to reauthenticate.
+func (opts *MultiMethodAuthOptions) CanReauth() bool {
+	return opts.AllowReauth
+}
+
+// AuthReceipt implements AuthReceiptBuilder.
+func (opts *MultiMethodAuthOptions) AuthReceipt() string {
+	return opts.Receipt
+}
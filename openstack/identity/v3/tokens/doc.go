@@ -86,6 +86,19 @@ Example to Create a Token from a Username and Password with Domain ID Scope
 		panic(err)
 	}
 
+Example to Create a System-Scoped Token from a Username and Password
+
+	authOptions := tokens.AuthOptions{
+		Scope:    tokens.Scope{System: true},
+		UserID:   "username",
+		Password: "password",
+	}
+
+	system, err := tokens.Create(identityClient, authOptions).ExtractSystem()
+	if err != nil {
+		panic(err)
+	}
+
 Example to Create a Token from a Username and Password with Project Name Scope
 
 	scope := tokens.Scope{
@@ -104,5 +117,56 @@ Example to Create a Token from a Username and Password with Project Name Scope
 		panic(err)
 	}
 
+Example to Create a Token from an Application Credential
+
+	authOptions := tokens.AuthOptions{
+		ApplicationCredentialID:     "appcred_id",
+		ApplicationCredentialSecret: "appcred_secret",
+	}
+
+	token, err = tokens.Create(identityClient, authOptions).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create a Token with a Password and a TOTP Passcode
+
+	authOptions := &tokens.MultiMethodAuthOptions{
+		Methods: []tokens.AuthMethod{
+			&tokens.PasswordMethod{UserID: "user_id", Password: "password"},
+			&tokens.TOTPMethod{UserID: "user_id", Passcode: "123456"},
+		},
+		Scope: tokens.Scope{ProjectID: "project_id"},
+	}
+
+	token, err = tokens.Create(identityClient, authOptions).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Continue an Authentication With an Auth Receipt
+
+	authOptions := &tokens.MultiMethodAuthOptions{
+		Methods: []tokens.AuthMethod{
+			&tokens.PasswordMethod{UserID: "user_id", Password: "password"},
+		},
+	}
+
+	token, err = tokens.Create(identityClient, authOptions).ExtractToken()
+	if receiptErr, ok := err.(tokens.ErrAdditionalAuthRequired); ok {
+		fmt.Printf("missing methods: %v\n", receiptErr.MissingMethods())
+
+		authOptions = &tokens.MultiMethodAuthOptions{
+			Methods: []tokens.AuthMethod{
+				&tokens.TOTPMethod{UserID: "user_id", Passcode: "123456"},
+			},
+			Receipt: receiptErr.Receipt,
+		}
+		token, err = tokens.Create(identityClient, authOptions).ExtractToken()
+	}
+	if err != nil {
+		panic(err)
+	}
+
 */
 package tokens
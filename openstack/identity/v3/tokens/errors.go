@@ -0,0 +1,60 @@
+package tokens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrAdditionalAuthRequired is returned by Create when Keystone accepted the
+// provided authentication methods, but the user's multi-factor auth rules
+// require further ones. Repeat the request with the missing methods and
+// Receipt, for example by using MultiMethodAuthOptions.
+type ErrAdditionalAuthRequired struct {
+	gophercloud.ErrDefault401
+
+	// Receipt is the value of the Openstack-Auth-Receipt header. It proves
+	// that the methods in Methods were satisfied.
+	Receipt string
+
+	// Methods are the methods that were satisfied.
+	Methods []string
+
+	// ExpiresAt is the time at which the receipt expires.
+	ExpiresAt time.Time
+
+	// RequiredAuthMethods lists the combinations of methods that would be
+	// accepted. One of them must be satisfied in full.
+	RequiredAuthMethods [][]string
+}
+
+func (e ErrAdditionalAuthRequired) Error() string {
+	rules := make([]string, len(e.RequiredAuthMethods))
+	for i, rule := range e.RequiredAuthMethods {
+		rules[i] = "[" + strings.Join(rule, ", ") + "]"
+	}
+	return fmt.Sprintf("Additional authentication is required: one of %s must be satisfied", strings.Join(rules, " or "))
+}
+
+// MissingMethods returns, for each entry of RequiredAuthMethods, the methods
+// that haven't been satisfied yet.
+func (e ErrAdditionalAuthRequired) MissingMethods() [][]string {
+	satisfied := make(map[string]bool, len(e.Methods))
+	for _, m := range e.Methods {
+		satisfied[m] = true
+	}
+
+	missing := make([][]string, 0, len(e.RequiredAuthMethods))
+	for _, rule := range e.RequiredAuthMethods {
+		var m []string
+		for _, method := range rule {
+			if !satisfied[method] {
+				m = append(m, method)
+			}
+		}
+		missing = append(missing, m)
+	}
+	return missing
+}
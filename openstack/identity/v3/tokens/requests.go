@@ -1,13 +1,24 @@
 package tokens
 
-import "github.com/gophercloud/gophercloud"
+import (
+	"encoding/json"
+	"time"
 
-// Scope allows a created token to be limited to a specific domain or project.
+	"github.com/gophercloud/gophercloud"
+)
+
+// Scope allows a created token to be limited to a specific domain or project,
+// or to the system.
 type Scope struct {
 	ProjectID   string
 	ProjectName string
 	DomainID    string
 	DomainName  string
+
+	// System requests a token scoped to the deployment as a whole. Keystone
+	// policies require it for cloud-wide operations such as managing
+	// endpoints or registered limits.
+	System bool
 }
 
 // AuthOptionsBuilder provides the ability for extensions to add additional
@@ -53,6 +64,14 @@ type AuthOptions struct {
 	TokenID string `json:"-"`
 
 	Scope Scope `json:"-"`
+
+	// Authentication through Application Credentials requires supplying
+	// either ApplicationCredentialID, or ApplicationCredentialName together
+	// with the UserID or the Username and DomainID or DomainName of the
+	// credential's owner. ApplicationCredentialSecret is always required.
+	ApplicationCredentialID     string `json:"-"`
+	ApplicationCredentialName   string `json:"-"`
+	ApplicationCredentialSecret string `json:"-"`
 }
 
 // ToTokenV3CreateMap builds a request body from AuthOptions.
@@ -65,6 +84,10 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 		DomainName:  opts.DomainName,
 		AllowReauth: opts.AllowReauth,
 		TokenID:     opts.TokenID,
+
+		ApplicationCredentialID:     opts.ApplicationCredentialID,
+		ApplicationCredentialName:   opts.ApplicationCredentialName,
+		ApplicationCredentialSecret: opts.ApplicationCredentialSecret,
 	}
 
 	return gophercloudAuthOpts.ToTokenV3CreateMap(scope)
@@ -72,6 +95,12 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 
 // ToTokenV3CreateMap builds a scope request body from AuthOptions.
 func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
+	// Application credentials are bound to a project, so they can't be scoped.
+	if opts.Scope == (Scope{}) && opts.Password == "" && opts.TokenID == "" &&
+		(opts.ApplicationCredentialID != "" || opts.ApplicationCredentialName != "") {
+		return nil, nil
+	}
+
 	scope := gophercloud.AuthScope(opts.Scope)
 
 	gophercloudAuthOpts := gophercloud.AuthOptions{
@@ -108,26 +137,69 @@ func Create(c *gophercloud.ServiceClient, opts AuthOptionsBuilder) (r CreateResu
 		return
 	}
 
+	headers := map[string]string{"X-Auth-Token": ""}
+	if rb, ok := opts.(AuthReceiptBuilder); ok && rb.AuthReceipt() != "" {
+		headers["Openstack-Auth-Receipt"] = rb.AuthReceipt()
+	}
+	if hb, ok := opts.(AuthHeadersBuilder); ok {
+		h, err := hb.ToTokenV3HeadersMap(tokenURL(c))
+		if err != nil {
+			r.Err = err
+			return
+		}
+		for k, v := range h {
+			headers[k] = v
+		}
+	}
+
 	resp, err := c.Post(tokenURL(c), b, &r.Body, &gophercloud.RequestOpts{
-		MoreHeaders: map[string]string{"X-Auth-Token": ""},
+		MoreHeaders: headers,
 	})
-	r.Err = err
+	r.Err = additionalAuthRequired(err)
 	if resp != nil {
 		r.Header = resp.Header
 	}
 	return
 }
 
+// additionalAuthRequired turns a 401 response that carries an auth receipt
+// into an ErrAdditionalAuthRequired. Other errors are returned unchanged.
+func additionalAuthRequired(err error) error {
+	e, ok := err.(gophercloud.ErrDefault401)
+	if !ok {
+		return err
+	}
+	receipt := e.ResponseHeader.Get("Openstack-Auth-Receipt")
+	if receipt == "" {
+		return err
+	}
+
+	var body struct {
+		Receipt struct {
+			Methods   []string                     `json:"methods"`
+			ExpiresAt gophercloud.JSONRFC3339Milli `json:"expires_at"`
+		} `json:"receipt"`
+		RequiredAuthMethods [][]string `json:"required_auth_methods"`
+	}
+	// A body that can't be parsed still leaves the receipt usable.
+	json.Unmarshal(e.Body, &body)
+
+	return ErrAdditionalAuthRequired{
+		ErrDefault401:       e,
+		Receipt:             receipt,
+		Methods:             body.Receipt.Methods,
+		ExpiresAt:           time.Time(body.Receipt.ExpiresAt),
+		RequiredAuthMethods: body.RequiredAuthMethods,
+	}
+}
+
 // Get validates and retrieves information about another token.
 func Get(c *gophercloud.ServiceClient, token string) (r GetResult) {
 	resp, err := c.Get(tokenURL(c), &r.Body, &gophercloud.RequestOpts{
 		MoreHeaders: subjectTokenHeaders(c, token),
 		OkCodes:     []int{200, 203},
 	})
-	if resp != nil {
-		r.Err = err
-		r.Header = resp.Header
-	}
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -146,8 +218,9 @@ func Validate(c *gophercloud.ServiceClient, token string) (bool, error) {
 
 // Revoke immediately makes specified token invalid.
 func Revoke(c *gophercloud.ServiceClient, token string) (r RevokeResult) {
-	_, r.Err = c.Delete(tokenURL(c), &gophercloud.RequestOpts{
+	resp, err := c.Delete(tokenURL(c), &gophercloud.RequestOpts{
 		MoreHeaders: subjectTokenHeaders(c, token),
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
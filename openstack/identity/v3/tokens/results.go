@@ -76,6 +76,11 @@ type Project struct {
 	Name   string `json:"name"`
 }
 
+// System provides information about the system a token is scoped to.
+type System struct {
+	All bool `json:"all"`
+}
+
 // commonResult is the response from a request. A commonResult has various
 // methods which can be used to extract different details about the result.
 type commonResult struct {
@@ -137,6 +142,26 @@ func (r commonResult) ExtractProject() (*Project, error) {
 	return s.Project, err
 }
 
+// ExtractDomain returns Domain to which User is authorized. It is nil unless
+// the token is domain-scoped.
+func (r commonResult) ExtractDomain() (*Domain, error) {
+	var s struct {
+		Domain *Domain `json:"domain"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Domain, err
+}
+
+// ExtractSystem returns the system scope of the token. It is nil unless the
+// token is system-scoped.
+func (r commonResult) ExtractSystem() (*System, error) {
+	var s struct {
+		System *System `json:"system"`
+	}
+	err := r.ExtractInto(&s)
+	return s.System, err
+}
+
 // CreateResult is the response from a Create request. Use ExtractToken()
 // to interpret it as a Token, or ExtractServiceCatalog() to interpret it
 // as a service catalog.
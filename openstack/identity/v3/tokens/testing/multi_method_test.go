@@ -0,0 +1,205 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestCreateMultiMethod(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `
+			{
+				"auth": {
+					"identity": {
+						"methods": ["password", "totp"],
+						"password": {
+							"user": {
+								"name": "fenris",
+								"domain": {"name": "default"},
+								"password": "g0t0h311"
+							}
+						},
+						"totp": {
+							"user": {
+								"name": "fenris",
+								"domain": {"name": "default"},
+								"passcode": "123456"
+							}
+						}
+					},
+					"scope": {
+						"project": {"id": "123456"}
+					}
+				}
+			}
+		`)
+
+		w.Header().Add("X-Subject-Token", "mfa-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": {"expires_at": "2014-10-02T13:45:00.000000Z"}}`)
+	})
+
+	client := gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint(),
+	}
+	options := &tokens.MultiMethodAuthOptions{
+		Methods: []tokens.AuthMethod{
+			&tokens.PasswordMethod{Username: "fenris", DomainName: "default", Password: "g0t0h311"},
+			&tokens.TOTPMethod{Username: "fenris", DomainName: "default", Passcode: "123456"},
+		},
+		Scope: tokens.Scope{ProjectID: "123456"},
+	}
+
+	token, err := tokens.Create(&client, options).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "mfa-token", token.ID)
+}
+
+func TestCreateMultiMethodFailures(t *testing.T) {
+	options := &tokens.MultiMethodAuthOptions{}
+	_, err := options.ToTokenV3CreateMap(nil)
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Errorf("expected ErrMissingInput for no methods, got %T: %v", err, err)
+	}
+
+	options.Methods = []tokens.AuthMethod{
+		&tokens.TokenMethod{ID: "a"},
+		&tokens.TokenMethod{ID: "b"},
+	}
+	_, err = options.ToTokenV3CreateMap(nil)
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Errorf("expected ErrInvalidInput for a duplicate method, got %T: %v", err, err)
+	}
+
+	options.Methods = []tokens.AuthMethod{
+		&tokens.TOTPMethod{UserID: "me"},
+	}
+	_, err = options.ToTokenV3CreateMap(nil)
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Errorf("expected ErrMissingInput for a missing passcode, got %T: %v", err, err)
+	}
+}
+
+func TestGenerateTOTPPasscode(t *testing.T) {
+	// Test vectors from RFC 6238, appendix B, truncated to six digits. The
+	// secret is the ASCII string "12345678901234567890".
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, expected := range cases {
+		actual, err := tokens.GenerateTOTPPasscode(secret, time.Unix(unix, 0))
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, expected, actual)
+	}
+
+	// Lowercase and spaced secrets, as shown by some apps, are accepted.
+	actual, err := tokens.GenerateTOTPPasscode("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "287082", actual)
+
+	_, err = tokens.GenerateTOTPPasscode("not base32!", time.Unix(59, 0))
+	if err == nil {
+		t.Error("expected an error for an invalid secret")
+	}
+}
+
+func TestCreateWithAuthReceipt(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+
+		if r.Header.Get("Openstack-Auth-Receipt") == "" {
+			th.TestJSONRequest(t, r, `
+				{
+					"auth": {
+						"identity": {
+							"methods": ["password"],
+							"password": {
+								"user": {"id": "me", "password": "squirrel!"}
+							}
+						}
+					}
+				}
+			`)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Openstack-Auth-Receipt", "receipt-1234")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, `
+				{
+					"receipt": {
+						"methods": ["password"],
+						"expires_at": "2018-07-24T12:44:52.000000Z",
+						"issued_at": "2018-07-24T12:39:52.000000Z",
+						"user": {"id": "me"}
+					},
+					"required_auth_methods": [["password", "totp"]]
+				}
+			`)
+			return
+		}
+
+		th.TestHeader(t, r, "Openstack-Auth-Receipt", "receipt-1234")
+		th.TestJSONRequest(t, r, `
+			{
+				"auth": {
+					"identity": {
+						"methods": ["totp"],
+						"totp": {
+							"user": {"id": "me", "passcode": "654321"}
+						}
+					}
+				}
+			}
+		`)
+		w.Header().Add("X-Subject-Token", "mfa-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": {"expires_at": "2014-10-02T13:45:00.000000Z"}}`)
+	})
+
+	client := gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint(),
+	}
+
+	_, err := tokens.Create(&client, &tokens.MultiMethodAuthOptions{
+		Methods: []tokens.AuthMethod{
+			&tokens.PasswordMethod{UserID: "me", Password: "squirrel!"},
+		},
+	}).Extract()
+
+	receiptErr, ok := err.(tokens.ErrAdditionalAuthRequired)
+	if !ok {
+		t.Fatalf("expected ErrAdditionalAuthRequired, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, "receipt-1234", receiptErr.Receipt)
+	th.AssertDeepEquals(t, []string{"password"}, receiptErr.Methods)
+	th.AssertDeepEquals(t, [][]string{{"password", "totp"}}, receiptErr.RequiredAuthMethods)
+	th.AssertDeepEquals(t, [][]string{{"totp"}}, receiptErr.MissingMethods())
+	th.AssertEquals(t, true, receiptErr.ExpiresAt.Equal(time.Date(2018, 7, 24, 12, 44, 52, 0, time.UTC)))
+
+	token, err := tokens.Create(&client, &tokens.MultiMethodAuthOptions{
+		Methods: []tokens.AuthMethod{
+			&tokens.TOTPMethod{UserID: "me", Passcode: "654321"},
+		},
+		Receipt: receiptErr.Receipt,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "mfa-token", token.ID)
+}
@@ -144,6 +144,61 @@ func TestCreateTokenID(t *testing.T) {
 	`)
 }
 
+func TestCreateApplicationCredentialIDAndSecret(t *testing.T) {
+	authTokenPost(t, tokens.AuthOptions{ApplicationCredentialID: "12345abcdef", ApplicationCredentialSecret: "mysecret"}, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["application_credential"],
+					"application_credential": {
+						"id": "12345abcdef",
+						"secret": "mysecret"
+					}
+				}
+			}
+		}
+	`)
+}
+
+func TestCreateApplicationCredentialNameAndSecret(t *testing.T) {
+	authTokenPost(t, tokens.AuthOptions{ApplicationCredentialName: "myappcred", ApplicationCredentialSecret: "mysecret", Username: "fenris", DomainName: "default"}, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["application_credential"],
+					"application_credential": {
+						"name": "myappcred",
+						"secret": "mysecret",
+						"user": {
+							"name": "fenris",
+							"domain": {
+								"name": "default"
+							}
+						}
+					}
+				}
+			}
+		}
+	`)
+
+	authTokenPost(t, tokens.AuthOptions{ApplicationCredentialName: "myappcred", ApplicationCredentialSecret: "mysecret", UserID: "myid"}, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["application_credential"],
+					"application_credential": {
+						"name": "myappcred",
+						"secret": "mysecret",
+						"user": {
+							"id": "myid"
+						}
+					}
+				}
+			}
+		}
+	`)
+}
+
 func TestCreateProjectIDScope(t *testing.T) {
 	options := tokens.AuthOptions{UserID: "fenris", Password: "g0t0h311"}
 	scope := &tokens.Scope{ProjectID: "123456"}
@@ -275,6 +330,58 @@ func TestCreateProjectNameAndDomainNameScope(t *testing.T) {
 	`)
 }
 
+func TestCreateSystemScope(t *testing.T) {
+	options := tokens.AuthOptions{UserID: "fenris", Password: "g0t0h311"}
+	scope := &tokens.Scope{System: true}
+	authTokenPost(t, options, scope, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["password"],
+					"password": {
+						"user": {
+							"id": "fenris",
+							"password": "g0t0h311"
+						}
+					}
+				},
+				"scope": {
+					"system": {
+						"all": true
+					}
+				}
+			}
+		}
+	`)
+}
+
+func TestCreateExtractsSystemFromResponse(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+
+	client := gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       testhelper.Endpoint(),
+	}
+
+	testhelper.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{
+			"token": {
+				"expires_at": "2014-10-02T13:45:00.000000Z",
+				"system": {
+					"all": true
+				}
+			}
+		}`)
+	})
+
+	options := tokens.AuthOptions{UserID: "me", Password: "shhh", Scope: tokens.Scope{System: true}}
+	system, err := tokens.Create(&client, &options).ExtractSystem()
+	testhelper.AssertNoErr(t, err)
+	testhelper.CheckDeepEquals(t, &tokens.System{All: true}, system)
+}
+
 func TestCreateExtractsTokenFromResponse(t *testing.T) {
 	testhelper.SetupHTTP()
 	defer testhelper.TeardownHTTP()
@@ -326,6 +433,16 @@ func TestCreateFailureTokenIDDomainName(t *testing.T) {
 	authTokenPostErr(t, tokens.AuthOptions{DomainName: "something", TokenID: "12345"}, nil, true, gophercloud.ErrDomainNameWithToken{})
 }
 
+func TestCreateFailureAppCredMissingSecret(t *testing.T) {
+	options := tokens.AuthOptions{ApplicationCredentialID: "12345abcdef"}
+	authTokenPostErr(t, options, nil, false, gophercloud.ErrAppCredMissingSecret{})
+}
+
+func TestCreateFailureAppCredNameMissingUser(t *testing.T) {
+	options := tokens.AuthOptions{ApplicationCredentialName: "myappcred", ApplicationCredentialSecret: "mysecret"}
+	authTokenPostErr(t, options, nil, false, gophercloud.ErrUsernameOrUserID{})
+}
+
 func TestCreateFailureMissingUser(t *testing.T) {
 	options := tokens.AuthOptions{Password: "supersecure"}
 	authTokenPostErr(t, options, nil, false, gophercloud.ErrUsernameOrUserID{})
@@ -406,6 +523,12 @@ func TestCreateFailureScopeDomainIDAndDomainName(t *testing.T) {
 	authTokenPostErr(t, options, scope, false, gophercloud.ErrScopeDomainIDOrDomainName{})
 }
 
+func TestCreateFailureScopeSystemAndProjectID(t *testing.T) {
+	options := tokens.AuthOptions{UserID: "myself", Password: "swordfish"}
+	scope := &tokens.Scope{System: true, ProjectID: "notneeded"}
+	authTokenPostErr(t, options, scope, false, gophercloud.ErrScopeSystemAlone{})
+}
+
 /*
 func TestCreateFailureEmptyScope(t *testing.T) {
 	options := tokens.AuthOptions{UserID: "myself", Password: "swordfish"}
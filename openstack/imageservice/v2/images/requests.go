@@ -1,10 +1,6 @@
 package images
 
 import (
-	"fmt"
-	"net/url"
-	"time"
-
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
 )
@@ -70,14 +66,18 @@ type ListOpts struct {
 	// SortDir will sort the list results either ascending or decending.
 	SortDir string `q:"sort_dir"`
 
+	// SortKeys sorts the results by several attributes using the new style
+	// of sorting. It can't be used with Sort, SortKey or SortDir.
+	SortKeys []gophercloud.SortKey `q:"sort"`
+
 	// Tags filters on specific image tags.
 	Tags []string `q:"tag"`
 
 	// CreatedAtQuery filters images based on their creation date.
-	CreatedAtQuery *ImageDateQuery
+	CreatedAtQuery *ImageDateQuery `q:"created_at"`
 
 	// UpdatedAtQuery filters images based on their updated date.
-	UpdatedAtQuery *ImageDateQuery
+	UpdatedAtQuery *ImageDateQuery `q:"updated_at"`
 
 	// ContainerFormat filters images based on the container_format.
 	// Multiple container formats can be specified by constructing a
@@ -92,29 +92,13 @@ type ListOpts struct {
 
 // ToImageListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToImageListQuery() (string, error) {
-	q, err := gophercloud.BuildQueryString(opts)
-	params := q.Query()
-
-	if opts.CreatedAtQuery != nil {
-		createdAt := opts.CreatedAtQuery.Date.Format(time.RFC3339)
-		if v := opts.CreatedAtQuery.Filter; v != "" {
-			createdAt = fmt.Sprintf("%s:%s", v, createdAt)
-		}
-
-		params.Add("created_at", createdAt)
+	if len(opts.SortKeys) > 0 && (opts.Sort != "" || opts.SortKey != "" || opts.SortDir != "") {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "images.ListOpts.SortKeys"
+		err.Info = "SortKeys can't be used together with Sort, SortKey or SortDir"
+		return "", err
 	}
-
-	if opts.UpdatedAtQuery != nil {
-		updatedAt := opts.UpdatedAtQuery.Date.Format(time.RFC3339)
-		if v := opts.UpdatedAtQuery.Filter; v != "" {
-			updatedAt = fmt.Sprintf("%s:%s", v, updatedAt)
-		}
-
-		params.Add("updated_at", updatedAt)
-	}
-
-	q = &url.URL{RawQuery: params.Encode()}
-
+	q, err := gophercloud.BuildQueryString(opts)
 	return q.String(), err
 }
 
@@ -201,19 +185,22 @@ func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r Create
 		r.Err = err
 		return r
 	}
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{OkCodes: []int{201}})
+	resp, err := client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{OkCodes: []int{201}})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Delete implements image delete request.
 func Delete(client *gophercloud.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	resp, err := client.Delete(deleteURL(client, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Get implements image get request.
 func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	resp, err := client.Get(getURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -224,10 +211,11 @@ func Update(client *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder
 		r.Err = err
 		return r
 	}
-	_, r.Err = client.Patch(updateURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Patch(updateURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes:     []int{200},
 		MoreHeaders: map[string]string{"Content-Type": "application/openstack-images-v2.1-json-patch"},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
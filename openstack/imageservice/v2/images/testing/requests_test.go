@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
@@ -321,6 +322,40 @@ func TestImageDateQuery(t *testing.T) {
 	th.AssertEquals(t, expectedQueryString, actualQueryString)
 }
 
+func TestImageListSortKeys(t *testing.T) {
+	listOpts := images.ListOpts{
+		SortKeys: []gophercloud.SortKey{
+			{Key: "name", Direction: gophercloud.SortAsc},
+			{Key: "status", Direction: gophercloud.SortDesc},
+		},
+	}
+
+	expectedQueryString := "?sort=name%3Aasc%2Cstatus%3Adesc"
+	actualQueryString, err := listOpts.ToImageListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, expectedQueryString, actualQueryString)
+}
+
+func TestImageListBothSortStyles(t *testing.T) {
+	listOpts := images.ListOpts{
+		Sort: "name:asc",
+		SortKeys: []gophercloud.SortKey{
+			{Key: "status", Direction: gophercloud.SortDesc},
+		},
+	}
+	_, err := listOpts.ToImageListQuery()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("expected ErrInvalidInput, got %T: %v", err, err)
+	}
+
+	listOpts.Sort = ""
+	listOpts.SortDir = "asc"
+	_, err = listOpts.ToImageListQuery()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("expected ErrInvalidInput, got %T: %v", err, err)
+	}
+}
+
 func TestImageListByTags(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
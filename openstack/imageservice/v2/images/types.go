@@ -2,6 +2,8 @@ package images
 
 import (
 	"time"
+
+	"github.com/gophercloud/gophercloud"
 )
 
 // ImageStatus image statuses
@@ -84,15 +86,15 @@ const (
 
 // ImageDateFilter represents a valid filter to use for filtering
 // images by their date during a List.
-type ImageDateFilter string
+type ImageDateFilter = gophercloud.FilterOperator
 
 const (
-	FilterGT  ImageDateFilter = "gt"
-	FilterGTE ImageDateFilter = "gte"
-	FilterLT  ImageDateFilter = "lt"
-	FilterLTE ImageDateFilter = "lte"
-	FilterNEQ ImageDateFilter = "neq"
-	FilterEQ  ImageDateFilter = "eq"
+	FilterGT  ImageDateFilter = gophercloud.FilterGT
+	FilterGTE ImageDateFilter = gophercloud.FilterGTE
+	FilterLT  ImageDateFilter = gophercloud.FilterLT
+	FilterLTE ImageDateFilter = gophercloud.FilterLTE
+	FilterNEQ ImageDateFilter = gophercloud.FilterNEQ
+	FilterEQ  ImageDateFilter = gophercloud.FilterEQ
 )
 
 // ImageDateQuery represents a date field to be used for listing images.
@@ -102,3 +104,13 @@ type ImageDateQuery struct {
 	Date   time.Time
 	Filter ImageDateFilter
 }
+
+// TimeFilter returns the gophercloud.TimeFilter that the query stands for.
+func (q ImageDateQuery) TimeFilter() gophercloud.TimeFilter {
+	return gophercloud.TimeFilter{Operator: q.Filter, Time: q.Date}
+}
+
+// MarshalText renders the query as its TimeFilter does.
+func (q ImageDateQuery) MarshalText() ([]byte, error) {
+	return q.TimeFilter().MarshalText()
+}
@@ -6,13 +6,15 @@ import (
 
 // GetContainerACL retrieves the ACL of a container.
 func GetContainerACL(client *gophercloud.ServiceClient, containerID string) (r ACLResult) {
-	_, r.Err = client.Get(containerURL(client, containerID), &r.Body, nil)
+	resp, err := client.Get(containerURL(client, containerID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // GetSecretACL retrieves the ACL of a secret.
 func GetSecretACL(client *gophercloud.ServiceClient, secretID string) (r ACLResult) {
-	_, r.Err = client.Get(secretURL(client, secretID), &r.Body, nil)
+	resp, err := client.Get(secretURL(client, secretID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -47,9 +49,10 @@ func SetContainerACL(client *gophercloud.ServiceClient, containerID string, opts
 		return
 	}
 
-	_, r.Err = client.Put(containerURL(client, containerID), &b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Put(containerURL(client, containerID), &b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -61,9 +64,10 @@ func SetSecretACL(client *gophercloud.ServiceClient, secretID string, opts SetOp
 		return
 	}
 
-	_, r.Err = client.Put(secretURL(client, secretID), &b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Put(secretURL(client, secretID), &b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -75,9 +79,10 @@ func UpdateContainerACL(client *gophercloud.ServiceClient, containerID string, o
 		return
 	}
 
-	_, r.Err = client.Patch(containerURL(client, containerID), &b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Patch(containerURL(client, containerID), &b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -89,24 +94,27 @@ func UpdateSecretACL(client *gophercloud.ServiceClient, secretID string, opts Se
 		return
 	}
 
-	_, r.Err = client.Patch(secretURL(client, secretID), &b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Patch(secretURL(client, secretID), &b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // DeleteContainerACL will delete an ACL from a conatiner.
 func DeleteContainerACL(client *gophercloud.ServiceClient, containerID string) (r DeleteResult) {
-	_, r.Err = client.Delete(containerURL(client, containerID), &gophercloud.RequestOpts{
+	resp, err := client.Delete(containerURL(client, containerID), &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // DeleteSecretACL will delete an ACL from a secret.
 func DeleteSecretACL(client *gophercloud.ServiceClient, secretID string) (r DeleteResult) {
-	_, r.Err = client.Delete(secretURL(client, secretID), &gophercloud.RequestOpts{
+	resp, err := client.Delete(secretURL(client, secretID), &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
@@ -1,8 +1,6 @@
 package secrets
 
 import (
-	"fmt"
-	"net/url"
 	"strings"
 	"time"
 
@@ -12,13 +10,13 @@ import (
 
 // DateFilter represents a valid filter to use for filtering
 // secrets by their date during a list.
-type DateFilter string
+type DateFilter = gophercloud.FilterOperator
 
 const (
-	DateFilterGT  DateFilter = "gt"
-	DateFilterGTE DateFilter = "gte"
-	DateFilterLT  DateFilter = "lt"
-	DateFilterLTE DateFilter = "lte"
+	DateFilterGT  DateFilter = gophercloud.FilterGT
+	DateFilterGTE DateFilter = gophercloud.FilterGTE
+	DateFilterLT  DateFilter = gophercloud.FilterLT
+	DateFilterLTE DateFilter = gophercloud.FilterLTE
 )
 
 // DateQuery represents a date field to be used for listing secrets.
@@ -28,6 +26,16 @@ type DateQuery struct {
 	Filter DateFilter
 }
 
+// TimeFilter returns the gophercloud.TimeFilter that the query stands for.
+func (q DateQuery) TimeFilter() gophercloud.TimeFilter {
+	return gophercloud.TimeFilter{Operator: q.Filter, Time: q.Date}
+}
+
+// MarshalText renders the query as its TimeFilter does.
+func (q DateQuery) MarshalText() ([]byte, error) {
+	return q.TimeFilter().MarshalText()
+}
+
 // SecretType represents a valid secret type.
 type SecretType string
 
@@ -75,15 +83,15 @@ type ListOpts struct {
 
 	// CreatedQuery will select all secrets with a created date matching
 	// the query.
-	CreatedQuery *DateQuery
+	CreatedQuery *DateQuery `q:"created"`
 
 	// UpdatedQuery will select all secrets with an updated date matching
 	// the query.
-	UpdatedQuery *DateQuery
+	UpdatedQuery *DateQuery `q:"updated"`
 
 	// ExpirationQuery will select all secrets with an expiration date
 	// matching the query.
-	ExpirationQuery *DateQuery
+	ExpirationQuery *DateQuery `q:"expiration"`
 
 	// Sort will sort the results in the requested order.
 	Sort string `q:"sort"`
@@ -92,37 +100,6 @@ type ListOpts struct {
 // ToSecretListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToSecretListQuery() (string, error) {
 	q, err := gophercloud.BuildQueryString(opts)
-	params := q.Query()
-
-	if opts.CreatedQuery != nil {
-		created := opts.CreatedQuery.Date.Format(time.RFC3339)
-		if v := opts.CreatedQuery.Filter; v != "" {
-			created = fmt.Sprintf("%s:%s", v, created)
-		}
-
-		params.Add("created", created)
-	}
-
-	if opts.UpdatedQuery != nil {
-		updated := opts.UpdatedQuery.Date.Format(time.RFC3339)
-		if v := opts.UpdatedQuery.Filter; v != "" {
-			updated = fmt.Sprintf("%s:%s", v, updated)
-		}
-
-		params.Add("updated", updated)
-	}
-
-	if opts.ExpirationQuery != nil {
-		expiration := opts.ExpirationQuery.Date.Format(time.RFC3339)
-		if v := opts.ExpirationQuery.Filter; v != "" {
-			expiration = fmt.Sprintf("%s:%s", v, expiration)
-		}
-
-		params.Add("expiration", expiration)
-	}
-
-	q = &url.URL{RawQuery: params.Encode()}
-
 	return q.String(), err
 }
 
@@ -143,7 +120,8 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 
 // Get retrieves details of a secrets.
 func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	resp, err := client.Get(getURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -237,15 +215,17 @@ func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r Create
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(createURL(client), &b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Post(createURL(client), &b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{201},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Delete deletes a secrets.
 func Delete(client *gophercloud.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	resp, err := client.Delete(deleteURL(client, id), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -313,7 +293,8 @@ func Update(client *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder
 
 // GetMetadata will list metadata for a given secret.
 func GetMetadata(client *gophercloud.ServiceClient, secretID string) (r MetadataResult) {
-	_, r.Err = client.Get(metadataURL(client, secretID), &r.Body, nil)
+	resp, err := client.Get(metadataURL(client, secretID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -338,15 +319,17 @@ func CreateMetadata(client *gophercloud.ServiceClient, secretID string, opts Cre
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Put(metadataURL(client, secretID), b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Put(metadataURL(client, secretID), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{201},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // GetMetadatum will get a single key/value metadata from a secret.
 func GetMetadatum(client *gophercloud.ServiceClient, secretID string, key string) (r MetadatumResult) {
-	_, r.Err = client.Get(metadatumURL(client, secretID, key), &r.Body, nil)
+	resp, err := client.Get(metadatumURL(client, secretID, key), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -374,9 +357,10 @@ func CreateMetadatum(client *gophercloud.ServiceClient, secretID string, opts Cr
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(metadataURL(client, secretID), b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Post(metadataURL(client, secretID), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{201},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -399,14 +383,16 @@ func UpdateMetadatum(client *gophercloud.ServiceClient, secretID string, opts Up
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Put(metadatumURL(client, secretID, key), b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Put(metadatumURL(client, secretID, key), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // DeleteMetadatum will delete an individual metadatum from a secret.
 func DeleteMetadatum(client *gophercloud.ServiceClient, secretID string, key string) (r MetadatumDeleteResult) {
-	_, r.Err = client.Delete(metadatumURL(client, secretID, key), nil)
+	resp, err := client.Delete(metadatumURL(client, secretID, key), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
@@ -67,9 +67,10 @@ func Create(client *gophercloud.ServiceClient, queueName string, opts CreateOpts
 
 // Get queries the specified claim for the specified queue.
 func Get(client *gophercloud.ServiceClient, queueName string, claimID string) (r GetResult) {
-	_, r.Err = client.Get(getURL(client, queueName, claimID), &r.Body, &gophercloud.RequestOpts{
+	resp, err := client.Get(getURL(client, queueName, claimID), &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -105,8 +106,9 @@ func Update(client *gophercloud.ServiceClient, queueName string, claimID string,
 		r.Err = err
 		return r
 	}
-	_, r.Err = client.Patch(updateURL(client, queueName, claimID), &b, nil, &gophercloud.RequestOpts{
+	resp, err := client.Patch(updateURL(client, queueName, claimID), &b, nil, &gophercloud.RequestOpts{
 		OkCodes: []int{204},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
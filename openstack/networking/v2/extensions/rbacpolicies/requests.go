@@ -27,10 +27,23 @@ type ListOpts struct {
 	Limit        int          `q:"limit"`
 	SortKey      string       `q:"sort_key"`
 	SortDir      string       `q:"sort_dir"`
+
+	// SortKeys sorts the RBAC policies by several attributes. It can't be used
+	// together with SortKey or SortDir.
+	SortKeys []gophercloud.SortKey `q:"sort_key,sort_dir"`
+
+	// Fields selects the attributes of the RBAC policies that are returned.
+	Fields []string `q:"fields"`
 }
 
 // ToRBACPolicyListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToRBACPolicyListQuery() (string, error) {
+	if len(opts.SortKeys) > 0 && (opts.SortKey != "" || opts.SortDir != "") {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "rbacpolicies.ListOpts.SortKeys"
+		err.Info = "SortKeys can't be used together with SortKey or SortDir"
+		return "", err
+	}
 	q, err := gophercloud.BuildQueryString(opts)
 	return q.String(), err
 }
@@ -55,7 +68,8 @@ func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
 
 // Get retrieves a specific rbac policy based on its unique ID.
 func Get(c *gophercloud.ServiceClient, id string) (r GetResult) {
-	_, r.Err = c.Get(getURL(c, id), &r.Body, nil)
+	resp, err := c.Get(getURL(c, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -101,13 +115,15 @@ func Create(c *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResul
 		r.Err = err
 		return
 	}
-	_, r.Err = c.Post(createURL(c), b, &r.Body, nil)
+	resp, err := c.Post(createURL(c), b, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Delete accepts a unique ID and deletes the rbac-policy associated with it.
 func Delete(c *gophercloud.ServiceClient, rbacPolicyID string) (r DeleteResult) {
-	_, r.Err = c.Delete(deleteURL(c, rbacPolicyID), nil)
+	resp, err := c.Delete(deleteURL(c, rbacPolicyID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -135,8 +151,9 @@ func Update(c *gophercloud.ServiceClient, rbacPolicyID string, opts UpdateOptsBu
 		r.Err = err
 		return
 	}
-	_, r.Err = c.Put(updateURL(c, rbacPolicyID), b, &r.Body, &gophercloud.RequestOpts{
+	resp, err := c.Put(updateURL(c, rbacPolicyID), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{200, 201},
 	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/gophercloud/gophercloud"
 	fake "github.com/gophercloud/gophercloud/openstack/networking/v2/common"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
 	"github.com/gophercloud/gophercloud/pagination"
@@ -46,6 +47,21 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestListWithBothSortStyles(t *testing.T) {
+	listOpts := groups.ListOpts{
+		SortDir: "desc",
+		SortKeys: []gophercloud.SortKey{
+			{Key: "name", Direction: gophercloud.SortAsc},
+		},
+	}
+	err := groups.List(fake.ServiceClient(), listOpts).EachPage(func(pagination.Page) (bool, error) {
+		return false, nil
+	})
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("expected ErrInvalidInput, got %T: %v", err, err)
+	}
+}
+
 func TestCreate(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
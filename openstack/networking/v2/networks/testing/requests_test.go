@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/gophercloud/gophercloud"
 	fake "github.com/gophercloud/gophercloud/openstack/networking/v2/common"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/portsecurity"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
@@ -80,6 +81,64 @@ func TestListWithExtensions(t *testing.T) {
 	th.AssertEquals(t, allNetworks[0].PortSecurityEnabled, true)
 }
 
+func TestListWithFieldsAndSortKeys(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/networks", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		q := r.URL.Query()
+		th.CheckDeepEquals(t, []string{"id", "name"}, q["fields"])
+		th.CheckDeepEquals(t, []string{"name", "id"}, q["sort_key"])
+		th.CheckDeepEquals(t, []string{"asc", "desc"}, q["sort_dir"])
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, `
+{
+    "networks": [
+        {
+            "id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+            "name": "public"
+        }
+    ]
+}
+`)
+	})
+
+	listOpts := networks.ListOpts{
+		Fields: []string{"id", "name"},
+		SortKeys: []gophercloud.SortKey{
+			{Key: "name", Direction: gophercloud.SortAsc},
+			{Key: "id", Direction: gophercloud.SortDesc},
+		},
+	}
+	allPages, err := networks.List(fake.ServiceClient(), listOpts).AllPages()
+	th.AssertNoErr(t, err)
+
+	actual, err := networks.ExtractNetworks(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []networks.Network{
+		{ID: "d32019d3-bc6e-4319-9c1d-6722fc136a22", Name: "public"},
+	}, actual)
+}
+
+func TestListWithBothSortStyles(t *testing.T) {
+	listOpts := networks.ListOpts{
+		SortKey: "name",
+		SortKeys: []gophercloud.SortKey{
+			{Key: "id", Direction: gophercloud.SortDesc},
+		},
+	}
+	_, err := listOpts.ToNetworkListQuery()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("expected ErrInvalidInput, got %T: %v", err, err)
+	}
+}
+
 func TestGet(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -115,7 +115,8 @@ func Create(c *gophercloud.ServiceClient, containerName string, opts CreateOptsB
 
 // Delete is a function that deletes a container.
 func Delete(c *gophercloud.ServiceClient, containerName string) (r DeleteResult) {
-	_, r.Err = c.Delete(deleteURL(c, containerName), nil)
+	resp, err := c.Delete(deleteURL(c, containerName), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -4,6 +4,7 @@ import "github.com/gophercloud/gophercloud"
 
 // Get retreives data for the given stack template.
 func Get(c *gophercloud.ServiceClient) (r GetResult) {
-	_, r.Err = c.Get(getURL(c), &r.Body, nil)
+	resp, err := c.Get(getURL(c), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
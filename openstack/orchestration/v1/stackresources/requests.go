@@ -7,7 +7,8 @@ import (
 
 // Find retrieves stack resources for the given stack name.
 func Find(c *gophercloud.ServiceClient, stackName string) (r FindResult) {
-	_, r.Err = c.Get(findURL(c, stackName), &r.Body, nil)
+	resp, err := c.Get(findURL(c, stackName), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -47,13 +48,15 @@ func List(client *gophercloud.ServiceClient, stackName, stackID string, opts Lis
 
 // Get retreives data for the given stack resource.
 func Get(c *gophercloud.ServiceClient, stackName, stackID, resourceName string) (r GetResult) {
-	_, r.Err = c.Get(getURL(c, stackName, stackID, resourceName), &r.Body, nil)
+	resp, err := c.Get(getURL(c, stackName, stackID, resourceName), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Metadata retreives the metadata for the given stack resource.
 func Metadata(c *gophercloud.ServiceClient, stackName, stackID, resourceName string) (r MetadataResult) {
-	_, r.Err = c.Get(metadataURL(c, stackName, stackID, resourceName), &r.Body, nil)
+	resp, err := c.Get(metadataURL(c, stackName, stackID, resourceName), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
@@ -66,12 +69,14 @@ func ListTypes(client *gophercloud.ServiceClient) pagination.Pager {
 
 // Schema retreives the schema for the given resource type.
 func Schema(c *gophercloud.ServiceClient, resourceType string) (r SchemaResult) {
-	_, r.Err = c.Get(schemaURL(c, resourceType), &r.Body, nil)
+	resp, err := c.Get(schemaURL(c, resourceType), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
 
 // Template retreives the template representation for the given resource type.
 func Template(c *gophercloud.ServiceClient, resourceType string) (r TemplateResult) {
-	_, r.Err = c.Get(templateURL(c, resourceType), &r.Body, nil)
+	resp, err := c.Get(templateURL(c, resourceType), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
@@ -2,11 +2,17 @@ package testing
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	th "github.com/gophercloud/gophercloud/testhelper"
 )
 
@@ -58,6 +64,7 @@ func TestAuthenticatedClientV3(t *testing.T) {
 	client, err := openstack.AuthenticatedClient(options)
 	th.AssertNoErr(t, err)
 	th.CheckEquals(t, ID, client.TokenID)
+	th.CheckEquals(t, time.Date(2013, 2, 2, 18, 30, 59, 0, time.UTC), client.TokenExpiry())
 }
 
 func TestAuthenticatedClientV2(t *testing.T) {
@@ -313,3 +320,166 @@ func TestAuthenticatedClientV3Fails(t *testing.T) {
 func TestAuthenticatedClientV2Fails(t *testing.T) {
 	testAuthenticatedClientFails(t, "http://bad-address.example.com/v2.0")
 }
+
+func TestAuthenticatedClientV3TokenCache(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var versionRequests, tokenRequests int
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		versionRequests++
+		fmt.Fprintf(w, `
+			{
+				"versions": {
+					"values": [
+						{
+							"status": "stable",
+							"id": "v3.0",
+							"links": [
+								{ "href": "%s", "rel": "self" }
+							]
+						}
+					]
+				}
+			}
+		`, th.Endpoint()+"v3/")
+	})
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		w.Header().Add("X-Subject-Token", ID)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `
+			{
+				"token": {
+					"expires_at": "%s",
+					"catalog": [
+						{
+							"type": "compute",
+							"name": "nova",
+							"endpoints": [
+								{ "interface": "public", "region": "RegionOne", "url": "https://compute.example.com/v2.1/" }
+							]
+						}
+					]
+				}
+			}
+		`, expiresAt.Format("2006-01-02T15:04:05.000000Z"))
+	})
+
+	dir, err := ioutil.TempDir("", "gophercloud-tokens")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+	cache, err := gophercloud.NewFileTokenCache(filepath.Join(dir, "tokens"))
+	th.AssertNoErr(t, err)
+
+	options := gophercloud.AuthOptions{
+		Username:         "me",
+		Password:         "secret",
+		DomainName:       "default",
+		TenantName:       "project",
+		IdentityEndpoint: th.Endpoint(),
+	}
+
+	authenticate := func() *gophercloud.ProviderClient {
+		client, err := openstack.NewClient(options.IdentityEndpoint)
+		th.AssertNoErr(t, err)
+		client.TokenCache = cache
+		th.AssertNoErr(t, openstack.Authenticate(client, options))
+		return client
+	}
+
+	first := authenticate()
+	th.AssertEquals(t, 1, versionRequests)
+	th.AssertEquals(t, 1, tokenRequests)
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "tokens"))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(files))
+	th.AssertEquals(t, os.FileMode(0600), files[0].Mode().Perm())
+
+	second := authenticate()
+	th.AssertEquals(t, 1, versionRequests)
+	th.AssertEquals(t, 1, tokenRequests)
+	th.AssertEquals(t, first.TokenID, second.TokenID)
+	th.AssertEquals(t, first.TokenCacheKey, second.TokenCacheKey)
+	th.AssertEquals(t, true, second.TokenExpiry().Equal(expiresAt))
+
+	compute, err := openstack.NewComputeV2(second, gophercloud.EndpointOpts{Region: "RegionOne"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://compute.example.com/v2.1/", compute.Endpoint)
+
+	// Another user on the same cloud gets their own token.
+	options.Username = "someone-else"
+	authenticate()
+	th.AssertEquals(t, 2, tokenRequests)
+}
+
+func TestAuthenticatedClientV3TrustReauth(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var tokenRequests int
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `
+			{
+				"auth": {
+					"identity": {
+						"methods": ["password"],
+						"password": {
+							"user": { "id": "trustee", "password": "secret" }
+						}
+					},
+					"scope": {
+						"OS-TRUST:trust": { "id": "de0945a" }
+					}
+				}
+			}
+		`)
+
+		tokenRequests++
+		w.Header().Add("X-Subject-Token", fmt.Sprintf("token-%d", tokenRequests))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `
+			{
+				"token": {
+					"expires_at": "2013-02-02T18:30:59.000000Z",
+					"OS-TRUST:trust": { "id": "de0945a" }
+				}
+			}
+		`)
+	})
+
+	// The first token expires while the job is running.
+	th.Mux.HandleFunc("/v3/job", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != "token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client, err := openstack.NewClient(th.Endpoint() + "v3/")
+	th.AssertNoErr(t, err)
+
+	options := trusts.AuthOptsExt{
+		TrustID: "de0945a",
+		AuthOptionsBuilder: &tokens.AuthOptions{
+			UserID:      "trustee",
+			Password:    "secret",
+			AllowReauth: true,
+		},
+	}
+	err = openstack.AuthenticateV3(client, options, gophercloud.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-1", client.TokenID)
+
+	_, err = client.Request("GET", th.Endpoint()+"v3/job", &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, tokenRequests)
+	th.AssertEquals(t, "token-2", client.TokenID)
+}
@@ -0,0 +1,183 @@
+package openstack
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	tokens2 "github.com/gophercloud/gophercloud/openstack/identity/v2/tokens"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
+	tokens3 "github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+)
+
+// minCachedTokenLifetime is the least remaining lifetime a cached token must
+// have to be reused. It keeps a token from expiring right after it was
+// loaded from the cache.
+const minCachedTokenLifetime = time.Minute
+
+// tokenCacheIdentity describes whom a token was issued to. It is used as the
+// TokenCache key and deliberately excludes secrets such as the password.
+type tokenCacheIdentity struct {
+	AuthURL         string `json:"auth_url"`
+	UserID          string `json:"user_id,omitempty"`
+	Username        string `json:"username,omitempty"`
+	UserDomainID    string `json:"user_domain_id,omitempty"`
+	UserDomainName  string `json:"user_domain_name,omitempty"`
+	TenantID        string `json:"tenant_id,omitempty"`
+	TenantName      string `json:"tenant_name,omitempty"`
+	ProjectID       string `json:"project_id,omitempty"`
+	ProjectName     string `json:"project_name,omitempty"`
+	ScopeDomainID   string `json:"scope_domain_id,omitempty"`
+	ScopeDomainName string `json:"scope_domain_name,omitempty"`
+	ScopeSystem     bool   `json:"scope_system,omitempty"`
+	AppCredID       string `json:"application_credential_id,omitempty"`
+	AppCredName     string `json:"application_credential_name,omitempty"`
+	TrustID         string `json:"trust_id,omitempty"`
+}
+
+// tokenCacheKey returns the TokenCache key for the given auth options, or ""
+// if tokens obtained with them shouldn't be cached. That is the case when no
+// cache is configured, when authenticating with an existing token, and for
+// AuthOptionsBuilder implementations whose identity can't be determined.
+func tokenCacheKey(client *gophercloud.ProviderClient, opts interface{}) string {
+	if client.TokenCache == nil {
+		return ""
+	}
+
+	id := tokenCacheIdentity{AuthURL: client.IdentityEndpoint}
+	switch o := opts.(type) {
+	case gophercloud.AuthOptions:
+		return tokenCacheKey(client, &o)
+	case *trusts.AuthOptsExt:
+		return tokenCacheKey(client, *o)
+	case trusts.AuthOptsExt:
+		// A trust-scoped token belongs to the wrapped identity and the trust.
+		key := tokenCacheKey(client, o.AuthOptionsBuilder)
+		if key == "" || o.TrustID == "" {
+			return key
+		}
+		if err := json.Unmarshal([]byte(key), &id); err != nil {
+			return ""
+		}
+		id.TrustID = o.TrustID
+	case *gophercloud.AuthOptions:
+		id.UserID, id.Username = o.UserID, o.Username
+		id.UserDomainID, id.UserDomainName = o.DomainID, o.DomainName
+		id.TenantID, id.TenantName = o.TenantID, o.TenantName
+		if o.Scope != nil {
+			id.ProjectID, id.ProjectName = o.Scope.ProjectID, o.Scope.ProjectName
+			id.ScopeDomainID, id.ScopeDomainName = o.Scope.DomainID, o.Scope.DomainName
+			id.ScopeSystem = o.Scope.System
+		}
+		id.AppCredID, id.AppCredName = o.ApplicationCredentialID, o.ApplicationCredentialName
+	case *tokens3.AuthOptions:
+		id.UserID, id.Username = o.UserID, o.Username
+		id.UserDomainID, id.UserDomainName = o.DomainID, o.DomainName
+		id.ProjectID, id.ProjectName = o.Scope.ProjectID, o.Scope.ProjectName
+		id.ScopeDomainID, id.ScopeDomainName = o.Scope.DomainID, o.Scope.DomainName
+		id.ScopeSystem = o.Scope.System
+		id.AppCredID, id.AppCredName = o.ApplicationCredentialID, o.ApplicationCredentialName
+	case *tokens3.MultiMethodAuthOptions:
+		for _, m := range o.Methods {
+			switch m := m.(type) {
+			case *tokens3.PasswordMethod:
+				id.UserID, id.Username = m.UserID, m.Username
+				id.UserDomainID, id.UserDomainName = m.DomainID, m.DomainName
+			case *tokens3.TOTPMethod:
+				id.UserID, id.Username = m.UserID, m.Username
+				id.UserDomainID, id.UserDomainName = m.DomainID, m.DomainName
+			case *tokens3.ApplicationCredentialMethod:
+				id.AppCredID, id.AppCredName = m.ID, m.Name
+				id.UserID, id.Username = m.UserID, m.Username
+				id.UserDomainID, id.UserDomainName = m.DomainID, m.DomainName
+			default:
+				// The identity behind other methods is unknown.
+				return ""
+			}
+		}
+		id.ProjectID, id.ProjectName = o.Scope.ProjectID, o.Scope.ProjectName
+		id.ScopeDomainID, id.ScopeDomainName = o.Scope.DomainID, o.Scope.DomainName
+		id.ScopeSystem = o.Scope.System
+	default:
+		return ""
+	}
+
+	if id.UserID == "" && id.Username == "" && id.AppCredID == "" {
+		return ""
+	}
+
+	b, err := json.Marshal(id)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// cachedToken returns the token stored under key if it was issued by the
+// given identity version and remains valid for long enough to be used.
+// Cache errors are ignored; the caller simply authenticates instead.
+func cachedToken(client *gophercloud.ProviderClient, key, version string) *gophercloud.CachedToken {
+	if key == "" {
+		return nil
+	}
+	cached, err := client.TokenCache.Get(key)
+	if err != nil || cached == nil || cached.TokenID == "" || cached.IdentityVersion != version {
+		return nil
+	}
+
+	lifetime := minCachedTokenLifetime
+	if client.TokenRenewalWindow > lifetime {
+		lifetime = client.TokenRenewalWindow
+	}
+	if time.Until(cached.ExpiresAt) <= lifetime {
+		return nil
+	}
+	return cached
+}
+
+// cachedV2Token returns a token and service catalog from the cache, or nil
+// if there is no usable v2 token stored under key.
+func cachedV2Token(client *gophercloud.ProviderClient, key string) (*tokens2.Token, *tokens2.ServiceCatalog) {
+	cached := cachedToken(client, key, v2)
+	if cached == nil {
+		return nil, nil
+	}
+	var catalog tokens2.ServiceCatalog
+	if err := json.Unmarshal(cached.Catalog, &catalog); err != nil {
+		return nil, nil
+	}
+	return &tokens2.Token{ID: cached.TokenID, ExpiresAt: cached.ExpiresAt}, &catalog
+}
+
+// cachedV3Token returns a token and service catalog from the cache, or nil
+// if there is no usable v3 token stored under key.
+func cachedV3Token(client *gophercloud.ProviderClient, key string) (*tokens3.Token, *tokens3.ServiceCatalog) {
+	cached := cachedToken(client, key, v3)
+	if cached == nil {
+		return nil, nil
+	}
+	var catalog tokens3.ServiceCatalog
+	if err := json.Unmarshal(cached.Catalog, &catalog); err != nil {
+		return nil, nil
+	}
+	return &tokens3.Token{ID: cached.TokenID, ExpiresAt: cached.ExpiresAt}, &catalog
+}
+
+// storeToken saves a freshly issued token under key. Failing to do so only
+// means the next client has to authenticate, so errors are ignored.
+func storeToken(client *gophercloud.ProviderClient, key, version, endpoint, tokenID string, expiresAt time.Time, catalog interface{}) {
+	if key == "" || expiresAt.IsZero() {
+		return
+	}
+	b, err := json.Marshal(catalog)
+	if err != nil {
+		return
+	}
+	client.TokenCache.Set(key, &gophercloud.CachedToken{
+		TokenID:          tokenID,
+		ExpiresAt:        expiresAt,
+		IdentityVersion:  version,
+		IdentityEndpoint: endpoint,
+		Catalog:          b,
+	})
+}
@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// SupportedMicroversions is the range of microversions supported by a
+// service endpoint.
+type SupportedMicroversions struct {
+	MinMajor int
+	MinMinor int
+	MaxMajor int
+	MaxMinor int
+}
+
+// Min returns the lowest supported microversion, e.g. "2.1".
+func (s SupportedMicroversions) Min() string {
+	return fmt.Sprintf("%d.%d", s.MinMajor, s.MinMinor)
+}
+
+// Max returns the highest supported microversion, e.g. "2.65".
+func (s SupportedMicroversions) Max() string {
+	return fmt.Sprintf("%d.%d", s.MaxMajor, s.MaxMinor)
+}
+
+// IsSupported reports whether version lies within the supported range.
+func (s SupportedMicroversions) IsSupported(version string) (bool, error) {
+	lower, err := gophercloud.CompareMicroversions(version, s.Min())
+	if err != nil {
+		return false, err
+	}
+	upper, err := gophercloud.CompareMicroversions(version, s.Max())
+	if err != nil {
+		return false, err
+	}
+	return lower >= 0 && upper <= 0, nil
+}
+
+var versionSegment = regexp.MustCompile(`^v\d+(\.\d+)?$`)
+
+// versionDocumentURL returns the URL of the version document describing the
+// client's endpoint. Endpoints such as "https://nova/v2.1/{project_id}/" are
+// truncated after their version segment.
+func versionDocumentURL(client *gophercloud.ServiceClient) (string, string) {
+	endpoint := client.Endpoint
+	scheme := ""
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		scheme, endpoint = endpoint[:i+3], endpoint[i+3:]
+	}
+	segments := strings.Split(strings.TrimSuffix(endpoint, "/"), "/")
+	for i, segment := range segments {
+		if i > 0 && versionSegment.MatchString(segment) {
+			return scheme + strings.Join(segments[:i+1], "/") + "/", segment
+		}
+	}
+	return client.Endpoint, ""
+}
+
+// GetSupportedMicroversions reads the version document of the client's
+// endpoint and returns the range of microversions the service supports.
+//
+// Compute, block storage and shared file systems describe that range with
+// the min_version and version fields of their version document. Services
+// such as the load balancer, which publish one entry per minor version
+// instead, are handled by taking the lowest and highest supported entries.
+func GetSupportedMicroversions(client *gophercloud.ServiceClient) (SupportedMicroversions, error) {
+	type version struct {
+		ID         string `json:"id"`
+		Status     string `json:"status"`
+		Version    string `json:"version"`
+		MinVersion string `json:"min_version"`
+	}
+
+	type response struct {
+		Version  *version  `json:"version"`
+		Versions []version `json:"versions"`
+	}
+
+	var supported SupportedMicroversions
+
+	url, segment := versionDocumentURL(client)
+	var resp response
+	_, err := client.Get(url, &resp, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 300},
+	})
+	if err != nil {
+		return supported, err
+	}
+
+	major := -1
+	if segment != "" {
+		if m, _, err := gophercloud.ParseMicroversion(segment + ".0"); err == nil {
+			major = m
+		} else if m, _, err := gophercloud.ParseMicroversion(segment); err == nil {
+			major = m
+		}
+	}
+
+	var doc *version
+	if resp.Version != nil {
+		doc = resp.Version
+	} else {
+		var ids []string
+		for i, v := range resp.Versions {
+			if !goodStatus[strings.ToLower(v.Status)] {
+				continue
+			}
+			m, _, err := gophercloud.ParseMicroversion(v.ID)
+			if err != nil || (major >= 0 && m != major) {
+				continue
+			}
+			if v.Version != "" {
+				doc = &resp.Versions[i]
+				break
+			}
+			ids = append(ids, v.ID)
+		}
+		if doc == nil && len(ids) > 0 {
+			min, max := ids[0], ids[0]
+			for _, id := range ids[1:] {
+				if c, _ := gophercloud.CompareMicroversions(id, min); c < 0 {
+					min = id
+				}
+				if c, _ := gophercloud.CompareMicroversions(id, max); c > 0 {
+					max = id
+				}
+			}
+			doc = &version{MinVersion: min, Version: max}
+		}
+	}
+
+	if doc == nil || doc.Version == "" {
+		return supported, fmt.Errorf("microversions are not supported by %s", url)
+	}
+
+	supported.MaxMajor, supported.MaxMinor, err = gophercloud.ParseMicroversion(doc.Version)
+	if err != nil {
+		return supported, err
+	}
+	minVersion := doc.MinVersion
+	if minVersion == "" {
+		minVersion = doc.Version
+	}
+	supported.MinMajor, supported.MinMinor, err = gophercloud.ParseMicroversion(minVersion)
+	return supported, err
+}
+
+// NegotiateMicroversion selects the highest microversion supported by both
+// the service behind client and the caller, sets it as client.Microversion
+// and returns it. The caller supports the microversions from min to max;
+// either may be empty to leave that end unbounded.
+//
+// For example, to use up to microversion 2.65 of the compute API:
+//
+//	client, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
+//	_, err = utils.NegotiateMicroversion(client, "2.1", "2.65")
+func NegotiateMicroversion(client *gophercloud.ServiceClient, min, max string) (string, error) {
+	supported, err := GetSupportedMicroversions(client)
+	if err != nil {
+		return "", err
+	}
+
+	chosen := supported.Max()
+	if max != "" {
+		c, err := gophercloud.CompareMicroversions(max, chosen)
+		if err != nil {
+			return "", err
+		}
+		if c < 0 {
+			chosen = max
+		}
+	}
+
+	lowest := supported.Min()
+	if min != "" {
+		c, err := gophercloud.CompareMicroversions(min, lowest)
+		if err != nil {
+			return "", err
+		}
+		if c > 0 {
+			lowest = min
+		}
+	}
+
+	if c, _ := gophercloud.CompareMicroversions(chosen, lowest); c < 0 {
+		return "", fmt.Errorf(
+			"no common microversion: the client supports %s to %s, the server supports %s to %s",
+			bound(min), bound(max), supported.Min(), supported.Max(),
+		)
+	}
+
+	client.Microversion = chosen
+	return chosen, nil
+}
+
+func bound(v string) string {
+	if v == "" {
+		return "any"
+	}
+	return v
+}
@@ -0,0 +1,120 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/utils"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestGetSupportedMicroversionsCompute(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.1/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+			{
+				"version": {
+					"id": "v2.1",
+					"status": "CURRENT",
+					"version": "2.65",
+					"min_version": "2.1"
+				}
+			}
+		`)
+	})
+
+	c := &gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint() + "v2.1/0123456789abcdef/",
+	}
+
+	supported, err := utils.GetSupportedMicroversions(c)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "2.1", supported.Min())
+	th.AssertEquals(t, "2.65", supported.Max())
+
+	ok, err := supported.IsSupported("2.53")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, ok)
+
+	ok, err = supported.IsSupported("2.70")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, ok)
+}
+
+func TestNegotiateMicroversionBlockStorage(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+			{
+				"versions": [
+					{
+						"id": "v3.0",
+						"status": "CURRENT",
+						"version": "3.50",
+						"min_version": "3.0"
+					}
+				]
+			}
+		`)
+	})
+
+	c := &gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint() + "v3/",
+	}
+
+	v, err := utils.NegotiateMicroversion(c, "3.0", "3.60")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "3.50", v)
+	th.AssertEquals(t, "3.50", c.Microversion)
+
+	v, err = utils.NegotiateMicroversion(c, "", "3.27")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "3.27", v)
+
+	c.Microversion = ""
+	_, err = utils.NegotiateMicroversion(c, "3.55", "")
+	if err == nil {
+		t.Fatal("expected an error when the server is too old")
+	}
+	th.AssertEquals(t, "", c.Microversion)
+}
+
+func TestGetSupportedMicroversionsLoadBalancer(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `
+			{
+				"versions": [
+					{"id": "v2.0", "status": "SUPPORTED"},
+					{"id": "v2.1", "status": "SUPPORTED"},
+					{"id": "v2.2", "status": "CURRENT"},
+					{"id": "v3.0", "status": "EXPERIMENTAL"}
+				]
+			}
+		`)
+	})
+
+	c := &gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint(),
+	}
+
+	supported, err := utils.GetSupportedMicroversions(c)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "2.0", supported.Min())
+	th.AssertEquals(t, "2.2", supported.Max())
+}
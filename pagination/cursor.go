@@ -0,0 +1,79 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrInvalidCursor is returned by a Pager that was started from a cursor
+// that is malformed or points at a different service.
+type ErrInvalidCursor struct {
+	gophercloud.BaseError
+	Cursor string
+}
+
+func (e ErrInvalidCursor) Error() string {
+	return fmt.Sprintf("Invalid pagination cursor %q", e.Cursor)
+}
+
+// NextCursor returns an opaque cursor to the page after page, which
+// Pager.WithCursor resumes an iteration from. It returns "" if page is the
+// last page. It lets an EachPage handler save its progress.
+func NextCursor(page Page) (string, error) {
+	next, err := page.NextPageURL()
+	if err != nil || next == "" {
+		return "", err
+	}
+	return encodeCursor(next), nil
+}
+
+// WithCursor returns a new Pager that starts at the page a cursor points
+// to, as returned by NextCursor or Iterator.Cursor, rather than at the
+// first page. An empty cursor starts at the first page. The cursor must
+// have been returned by a Pager of the same list operation and service.
+func (p Pager) WithCursor(cursor string) Pager {
+	if cursor == "" || p.Err != nil {
+		return p
+	}
+
+	start, err := decodeCursor(cursor, p.initialURL)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	p.initialURL = start
+	return p
+}
+
+// encodeCursor turns the URL of a page into a cursor. The encoding keeps
+// callers from depending on the URL, which may change.
+func encodeCursor(pageURL string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pageURL))
+}
+
+// decodeCursor returns the URL of the page a cursor points to. It must be on
+// the same host as initialURL, so that a tampered cursor can't send the
+// token elsewhere.
+func decodeCursor(cursor, initialURL string) (string, error) {
+	invalid := ErrInvalidCursor{Cursor: cursor}
+
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", invalid
+	}
+	start, err := url.Parse(string(b))
+	if err != nil {
+		return "", invalid
+	}
+	initial, err := url.Parse(initialURL)
+	if err != nil {
+		return "", invalid
+	}
+	if start.Scheme != initial.Scheme || start.Host != initial.Host {
+		return "", invalid
+	}
+	return start.String(), nil
+}
@@ -0,0 +1,236 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// Iterator streams the pages of a Pager. A page is requested only when Next
+// is called, unless the Pager prefetches, and only the current page is held
+// in memory. Use it as follows:
+//
+//	it := servers.List(client, nil).Iterator()
+//	for it.Next() {
+//		page := it.Page()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator struct {
+	pager   Pager
+	ctx     context.Context
+	started bool
+	page    Page
+	nextURL string
+	nextErr error
+	pending chan fetchResult
+	err     error
+	done    bool
+}
+
+// fetchResult is a page requested in the background by a prefetching
+// Iterator.
+type fetchResult struct {
+	page Page
+	err  error
+}
+
+// Iterator returns an Iterator over the pages of p. Iteration stops at the
+// first empty page, or when the context of p is cancelled.
+func (p Pager) Iterator() *Iterator {
+	return &Iterator{
+		pager:   p,
+		nextURL: p.initialURL,
+		err:     p.Err,
+	}
+}
+
+// Next requests the next page and reports whether there is one. It returns
+// false at the end of the collection and after an error, which Err returns.
+func (it *Iterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	if it.started {
+		it.page = nil
+		if it.nextErr != nil {
+			return it.fail(it.nextErr)
+		}
+		if it.nextURL == "" {
+			it.done = true
+			return false
+		}
+	}
+	it.started = true
+
+	if it.ctx == nil {
+		it.ctx = it.pager.client.RequestContext()
+	}
+	if err := it.ctx.Err(); err != nil {
+		return it.fail(err)
+	}
+
+	var page Page
+	var err error
+	if it.pending != nil {
+		r := <-it.pending
+		it.pending = nil
+		page, err = r.page, r.err
+	} else {
+		page, err = it.pager.fetchNextPage(it.nextURL)
+	}
+	if err != nil {
+		return it.fail(err)
+	}
+
+	empty, err := page.IsEmpty()
+	if err != nil {
+		return it.fail(err)
+	}
+	if empty {
+		it.done = true
+		return false
+	}
+
+	it.page = page
+	it.nextURL, it.nextErr = page.NextPageURL()
+	if it.pager.prefetch && it.nextErr == nil && it.nextURL != "" {
+		it.pending = make(chan fetchResult, 1)
+		go func(p Pager, url string, pending chan<- fetchResult) {
+			page, err := p.fetchNextPage(url)
+			pending <- fetchResult{page: page, err: err}
+		}(it.pager, it.nextURL, it.pending)
+	}
+	return true
+}
+
+func (it *Iterator) fail(err error) bool {
+	it.page = nil
+	it.err = err
+	return false
+}
+
+// Cursor returns an opaque cursor to the page after the current one, which
+// Pager.WithCursor resumes the iteration from. It returns "" once the
+// current page is the last one. Save the cursor after the current page is
+// processed.
+func (it *Iterator) Cursor() string {
+	if it.page == nil || it.nextErr != nil || it.nextURL == "" {
+		return ""
+	}
+	return encodeCursor(it.nextURL)
+}
+
+// Page returns the current page. It is nil before the first call to Next
+// and once Next has returned false.
+func (it *Iterator) Page() Page {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// ItemIterator streams the items of a Pager, one at a time. Use it as
+// follows:
+//
+//	it := servers.List(client, nil).Items(servers.ExtractServers)
+//	for it.Next() {
+//		server := it.Item().(servers.Server)
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ItemIterator struct {
+	// MaxItems, if greater than zero, is the number of items after which the
+	// iteration stops, without requesting any further page. It must be set
+	// before the first call to Next.
+	MaxItems int
+
+	pages   *Iterator
+	extract reflect.Value
+	items   reflect.Value
+	index   int
+	count   int
+	item    interface{}
+	err     error
+}
+
+var (
+	pageType  = reflect.TypeOf((*Page)(nil)).Elem()
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Items returns an ItemIterator over the items of p. extract is the Extract
+// function of the resource, such as servers.ExtractServers: it must have the
+// signature func(pagination.Page) ([]T, error). Item returns values of type
+// T.
+func (p Pager) Items(extract interface{}) *ItemIterator {
+	it := &ItemIterator{pages: p.Iterator()}
+
+	v := reflect.ValueOf(extract)
+	if v.Kind() != reflect.Func || v.IsNil() || !isExtractFunc(v.Type()) {
+		err := gophercloud.ErrUnexpectedType{}
+		err.Expected = "func(pagination.Page) ([]T, error)"
+		err.Actual = fmt.Sprintf("%T", extract)
+		it.err = err
+		return it
+	}
+	it.extract = v
+	return it
+}
+
+// isExtractFunc reports whether t is func(pagination.Page) ([]T, error).
+func isExtractFunc(t reflect.Type) bool {
+	return t.NumIn() == 1 && t.In(0) == pageType &&
+		t.NumOut() == 2 && t.Out(0).Kind() == reflect.Slice && t.Out(1) == errorType
+}
+
+// Next advances to the next item and reports whether there is one. A page
+// is requested once the items of the previous one are used up. It returns
+// false at the end of the collection, after MaxItems items and after an
+// error, which Err returns.
+func (it *ItemIterator) Next() bool {
+	it.item = nil
+	if it.err != nil || (it.MaxItems > 0 && it.count >= it.MaxItems) {
+		return false
+	}
+
+	for !it.items.IsValid() || it.index >= it.items.Len() {
+		if !it.pages.Next() {
+			it.err = it.pages.Err()
+			return false
+		}
+
+		out := it.extract.Call([]reflect.Value{reflect.ValueOf(it.pages.Page())})
+		if err, _ := out[1].Interface().(error); err != nil {
+			it.err = err
+			return false
+		}
+		it.items = out[0]
+		it.index = 0
+	}
+
+	it.item = it.items.Index(it.index).Interface()
+	it.index++
+	it.count++
+	return true
+}
+
+// Item returns the current item. It is nil before the first call to Next
+// and once Next has returned false.
+func (it *ItemIterator) Item() interface{} {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ItemIterator) Err() error {
+	return it.err
+}
@@ -1,6 +1,7 @@
 package pagination
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -45,6 +46,8 @@ type Pager struct {
 
 	// Headers supplies additional HTTP headers to populate on each paged request.
 	Headers map[string]string
+
+	prefetch bool
 }
 
 // NewPager constructs a manually-configured pager.
@@ -67,6 +70,23 @@ func (p Pager) WithPageCreator(createPage func(r PageResult) Page) Pager {
 	}
 }
 
+// WithContext returns a new Pager whose page requests carry ctx. Cancelling
+// ctx stops EachPage and AllPages before the next page is requested and
+// aborts a page request that is already in flight.
+func (p Pager) WithContext(ctx context.Context) Pager {
+	p.client = p.client.WithContext(ctx)
+	return p
+}
+
+// WithPrefetch returns a new Pager that requests the next page in the
+// background while the current one is processed, so that the latency of
+// each request overlaps with the work done on the previous page. A page may
+// be requested that is never used, when the iteration stops early.
+func (p Pager) WithPrefetch() Pager {
+	p.prefetch = true
+	return p
+}
+
 func (p Pager) fetchNextPage(url string) (Page, error) {
 	resp, err := Request(p.client, p.Headers, url)
 	if err != nil {
@@ -84,40 +104,17 @@ func (p Pager) fetchNextPage(url string) (Page, error) {
 // EachPage iterates over each page returned by a Pager, yielding one at a time to a handler function.
 // Return "false" from the handler to prematurely stop iterating.
 func (p Pager) EachPage(handler func(Page) (bool, error)) error {
-	if p.Err != nil {
-		return p.Err
-	}
-	currentURL := p.initialURL
-	for {
-		currentPage, err := p.fetchNextPage(currentURL)
-		if err != nil {
-			return err
-		}
-
-		empty, err := currentPage.IsEmpty()
-		if err != nil {
-			return err
-		}
-		if empty {
-			return nil
-		}
-
-		ok, err := handler(currentPage)
+	it := p.Iterator()
+	for it.Next() {
+		ok, err := handler(it.Page())
 		if err != nil {
 			return err
 		}
 		if !ok {
 			return nil
 		}
-
-		currentURL, err = currentPage.NextPageURL()
-		if err != nil {
-			return err
-		}
-		if currentURL == "" {
-			return nil
-		}
 	}
+	return it.Err()
 }
 
 // AllPages returns all the pages from a `List` operation in a single page,
@@ -224,11 +221,22 @@ func (p Pager) AllPages() (Page, error) {
 	page := reflect.New(pageType)
 	// Set the page body to be the concatenated pages.
 	page.Elem().FieldByName("Body").Set(body)
+	// Use the response headers of the first page, so that request IDs and
+	// similar metadata are available from the concatenated page as well.
+	h := make(http.Header)
+	if fh := reflect.Indirect(reflect.ValueOf(testPage)).FieldByName("Header"); fh.IsValid() {
+		if first, ok := fh.Interface().(http.Header); ok {
+			for k, v := range first {
+				h[k] = append([]string(nil), v...)
+			}
+		}
+	}
 	// Set any additional headers that were pass along. The `objectstorage` pacakge,
 	// for example, passes a Content-Type header.
-	h := make(http.Header)
 	for k, v := range p.Headers {
-		h.Add(k, v)
+		if h.Get(k) == "" {
+			h.Add(k, v)
+		}
 	}
 	page.Elem().FieldByName("Header").Set(reflect.ValueOf(h))
 	// Type assert the page to a Page interface so that the type assertion in the
@@ -0,0 +1,136 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/pagination"
+	"github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestResumeLinkedFromCursor(t *testing.T) {
+	pager := createLinked(t)
+	defer testhelper.TeardownHTTP()
+
+	it := pager.Iterator()
+	testhelper.AssertEquals(t, true, it.Next())
+	cursor := it.Cursor()
+	if cursor == "" {
+		t.Fatal("Expected a cursor to the second page")
+	}
+
+	var actual []int
+	items := pager.WithCursor(cursor).Items(ExtractLinkedInts)
+	for items.Next() {
+		actual = append(actual, items.Item().(int))
+	}
+	testhelper.AssertNoErr(t, items.Err())
+	testhelper.CheckDeepEquals(t, []int{4, 5, 6, 7, 8, 9}, actual)
+
+	// There is no page after the last one.
+	for it.Next() {
+	}
+	testhelper.AssertNoErr(t, it.Err())
+	testhelper.AssertEquals(t, "", it.Cursor())
+}
+
+func TestResumeMarkerFromCursor(t *testing.T) {
+	pager := createMarkerPaged(t)
+	defer testhelper.TeardownHTTP()
+
+	var cursor string
+	err := pager.EachPage(func(page pagination.Page) (bool, error) {
+		var err error
+		cursor, err = pagination.NextCursor(page)
+		// Stop after the first page, as if the job had crashed.
+		return false, err
+	})
+	testhelper.AssertNoErr(t, err)
+
+	var actual []string
+	err = pager.WithCursor(cursor).EachPage(func(page pagination.Page) (bool, error) {
+		strings, err := ExtractMarkerStrings(page)
+		actual = append(actual, strings...)
+		return true, err
+	})
+	testhelper.AssertNoErr(t, err)
+	testhelper.CheckDeepEquals(t, []string{"ddd", "eee", "fff", "ggg", "hhh", "iii"}, actual)
+
+	// An empty cursor starts at the first page.
+	allPages, err := pager.WithCursor("").AllPages()
+	testhelper.AssertNoErr(t, err)
+	all, err := ExtractMarkerStrings(allPages)
+	testhelper.AssertNoErr(t, err)
+	testhelper.AssertEquals(t, 9, len(all))
+}
+
+func TestInvalidCursor(t *testing.T) {
+	pager := createLinked(t)
+	defer testhelper.TeardownHTTP()
+
+	for _, cursor := range []string{
+		"not a cursor!",
+		// https://attacker.example.com/page2
+		"aHR0cHM6Ly9hdHRhY2tlci5leGFtcGxlLmNvbS9wYWdlMg",
+	} {
+		err := pager.WithCursor(cursor).EachPage(func(page pagination.Page) (bool, error) {
+			t.Errorf("Expected no page to be requested")
+			return false, nil
+		})
+		if _, ok := err.(pagination.ErrInvalidCursor); !ok {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", cursor, err)
+		}
+	}
+}
+
+func TestPrefetch(t *testing.T) {
+	testhelper.SetupHTTP()
+	defer testhelper.TeardownHTTP()
+
+	requested := make(chan string, 3)
+	for i := 1; i <= 3; i++ {
+		page, next := i, fmt.Sprintf(`"%s/page%d"`, testhelper.Server.URL, i+1)
+		if i == 3 {
+			next = "null"
+		}
+		testhelper.Mux.HandleFunc(fmt.Sprintf("/page%d", i), func(w http.ResponseWriter, r *http.Request) {
+			requested <- r.URL.Path
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{ "ints": [%d], "links": { "next": %s } }`, page, next)
+		})
+	}
+
+	createPage := func(r pagination.PageResult) pagination.Page {
+		return LinkedPageResult{pagination.LinkedPageBase{PageResult: r}}
+	}
+	pager := pagination.NewPager(createClient(), testhelper.Server.URL+"/page1", createPage).WithPrefetch()
+
+	// waitForRequests waits until n pages have been requested.
+	seen := 0
+	waitForRequests := func(n int) {
+		for seen < n {
+			select {
+			case <-requested:
+				seen++
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Page %d was not requested", seen+1)
+			}
+		}
+	}
+
+	var actual []int
+	err := pager.EachPage(func(page pagination.Page) (bool, error) {
+		ints, err := ExtractLinkedInts(page)
+		actual = append(actual, ints...)
+
+		// The next page is requested while this one is handled.
+		if len(actual) < 3 {
+			waitForRequests(len(actual) + 1)
+		}
+		return true, err
+	})
+	testhelper.AssertNoErr(t, err)
+	testhelper.CheckDeepEquals(t, []int{1, 2, 3}, actual)
+}
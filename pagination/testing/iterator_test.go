@@ -0,0 +1,128 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+	"github.com/gophercloud/gophercloud/testhelper"
+)
+
+// countPages makes pager count the pages it requests.
+func countPages(pager pagination.Pager, count *int) pagination.Pager {
+	return pager.WithPageCreator(func(r pagination.PageResult) pagination.Page {
+		*count++
+		return LinkedPageResult{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+func TestIteratorLinked(t *testing.T) {
+	pager := createLinked(t)
+	defer testhelper.TeardownHTTP()
+
+	var pages [][]int
+	it := pager.Iterator()
+	for it.Next() {
+		ints, err := ExtractLinkedInts(it.Page())
+		testhelper.AssertNoErr(t, err)
+		pages = append(pages, ints)
+	}
+	testhelper.AssertNoErr(t, it.Err())
+	testhelper.CheckDeepEquals(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, pages)
+
+	// The iterator stays exhausted.
+	testhelper.AssertEquals(t, false, it.Next())
+	testhelper.AssertEquals(t, nil, it.Page())
+}
+
+func TestItemIteratorLinked(t *testing.T) {
+	pager := createLinked(t)
+	defer testhelper.TeardownHTTP()
+
+	var actual []int
+	it := pager.Items(ExtractLinkedInts)
+	for it.Next() {
+		actual = append(actual, it.Item().(int))
+	}
+	testhelper.AssertNoErr(t, it.Err())
+	testhelper.CheckDeepEquals(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, actual)
+}
+
+func TestItemIteratorMarker(t *testing.T) {
+	pager := createMarkerPaged(t)
+	defer testhelper.TeardownHTTP()
+
+	var actual []string
+	it := pager.Items(ExtractMarkerStrings)
+	for it.Next() {
+		actual = append(actual, it.Item().(string))
+	}
+	testhelper.AssertNoErr(t, it.Err())
+	testhelper.CheckDeepEquals(t, []string{"aaa", "bbb", "ccc", "ddd", "eee", "fff", "ggg", "hhh", "iii"}, actual)
+}
+
+func TestItemIteratorMaxItems(t *testing.T) {
+	pager := createLinked(t)
+	defer testhelper.TeardownHTTP()
+
+	requested := 0
+	var actual []int
+	it := countPages(pager, &requested).Items(ExtractLinkedInts)
+	it.MaxItems = 4
+	for it.Next() {
+		actual = append(actual, it.Item().(int))
+	}
+	testhelper.AssertNoErr(t, it.Err())
+	testhelper.CheckDeepEquals(t, []int{1, 2, 3, 4}, actual)
+
+	// The third page is never requested.
+	testhelper.AssertEquals(t, 2, requested)
+}
+
+func TestItemIteratorWithContext(t *testing.T) {
+	pager := createLinked(t)
+	defer testhelper.TeardownHTTP()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requested := 0
+	var actual []int
+	it := countPages(pager.WithContext(ctx), &requested).Items(ExtractLinkedInts)
+	for it.Next() {
+		actual = append(actual, it.Item().(int))
+		cancel()
+	}
+	testhelper.AssertEquals(t, context.Canceled, it.Err())
+	testhelper.CheckDeepEquals(t, []int{1, 2, 3}, actual)
+	testhelper.AssertEquals(t, 1, requested)
+}
+
+func TestItemIteratorErrors(t *testing.T) {
+	pager := createLinked(t)
+	defer testhelper.TeardownHTTP()
+
+	it := pager.Items(func(pagination.Page) (int, error) { return 0, nil })
+	testhelper.AssertEquals(t, false, it.Next())
+	if _, ok := it.Err().(gophercloud.ErrUnexpectedType); !ok {
+		t.Errorf("Expected ErrUnexpectedType, got %v", it.Err())
+	}
+
+	it = pager.Items(nil)
+	testhelper.AssertEquals(t, false, it.Next())
+	if _, ok := it.Err().(gophercloud.ErrUnexpectedType); !ok {
+		t.Errorf("Expected ErrUnexpectedType, got %v", it.Err())
+	}
+
+	extractErr := errors.New("extract failed")
+	it = pager.Items(func(pagination.Page) ([]int, error) { return nil, extractErr })
+	testhelper.AssertEquals(t, false, it.Next())
+	testhelper.AssertEquals(t, extractErr, it.Err())
+
+	pager.Err = errors.New("invalid options")
+	it = pager.Items(ExtractLinkedInts)
+	testhelper.AssertEquals(t, false, it.Next())
+	testhelper.AssertEquals(t, pager.Err, it.Err())
+}
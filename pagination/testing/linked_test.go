@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -110,3 +111,20 @@ func TestAllPagesLinked(t *testing.T) {
 	testhelper.AssertNoErr(t, err)
 	testhelper.CheckDeepEquals(t, expected, actual)
 }
+
+func TestEnumerateLinkedWithContext(t *testing.T) {
+	pager := createLinked(t)
+	defer testhelper.TeardownHTTP()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	callCount := 0
+	err := pager.WithContext(ctx).EachPage(func(page pagination.Page) (bool, error) {
+		callCount++
+		cancel()
+		return true, nil
+	})
+	testhelper.AssertEquals(t, context.Canceled, err)
+	testhelper.AssertEquals(t, 1, callCount)
+}
@@ -1,10 +1,12 @@
 package gophercloud
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -31,6 +33,15 @@ The above example can be run as-is, however it is recommended to look at how
 BuildRequestBody is used within Gophercloud to more fully understand how it
 fits within the request process as a whole rather than use it directly as shown
 above.
+
+Fields are validated according to their tags before the body is built. A field
+with a `required:"true"` tag must be set, one with an `xor:"Other"` tag must be
+set if and only if the field named Other isn't, and one with an `or:"Other"`
+tag must be set unless Other is. The fields of nested structs, and of structs
+in slices, are validated as well. If a single field is invalid, its
+ErrMissingInput is returned. If several are, an ErrInvalidOptions holds an
+error for each of them, naming the path to the field, such as
+"Rules[0].Local".
 */
 func BuildRequestBody(opts interface{}, parent string) (map[string]interface{}, error) {
 	optsValue := reflect.ValueOf(opts)
@@ -38,139 +49,169 @@ func BuildRequestBody(opts interface{}, parent string) (map[string]interface{},
 		optsValue = optsValue.Elem()
 	}
 
-	optsType := reflect.TypeOf(opts)
-	if optsType.Kind() == reflect.Ptr {
-		optsType = optsType.Elem()
+	if optsValue.Kind() != reflect.Struct {
+		// Return an error if the underlying type of 'opts' isn't a struct.
+		return nil, fmt.Errorf("Options type is not a struct.")
+	}
+
+	var va validator
+	va.validateStruct(optsValue, "")
+	if err := va.err(); err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
 	}
 
 	optsMap := make(map[string]interface{})
-	if optsValue.Kind() == reflect.Struct {
-		//fmt.Printf("optsValue.Kind() is a reflect.Struct: %+v\n", optsValue.Kind())
-		for i := 0; i < optsValue.NumField(); i++ {
-			v := optsValue.Field(i)
-			f := optsType.Field(i)
+	err = json.Unmarshal(b, &optsMap)
+	if err != nil {
+		return nil, err
+	}
 
-			if f.Name != strings.Title(f.Name) {
-				//fmt.Printf("Skipping field: %s...\n", f.Name)
-				continue
-			}
+	if parent != "" {
+		optsMap = map[string]interface{}{parent: optsMap}
+	}
+	return optsMap, nil
+}
 
-			//fmt.Printf("Starting on field: %s...\n", f.Name)
+// validator collects the fields of an options struct, and of the structs
+// nested in it, that break their "required", "xor" and "or" tags.
+type validator struct {
+	// query restricts the validation to the fields with a "q" tag.
+	query bool
+	errs  []error
+}
 
-			zero := isZero(v)
-			//fmt.Printf("v is zero?: %v\n", zero)
+func (va *validator) validateStruct(v reflect.Value, path string) {
+	t := v.Type()
+	reported := make(map[string]bool)
+	for i := 0; i < v.NumField(); i++ {
+		f := t.Field(i)
+		if f.Name != strings.Title(f.Name) {
+			continue
+		}
 
-			// if the field has a required tag that's set to "true"
-			if requiredTag := f.Tag.Get("required"); requiredTag == "true" {
-				//fmt.Printf("Checking required field [%s]:\n\tv: %+v\n\tisZero:%v\n", f.Name, v.Interface(), zero)
-				// if the field's value is zero, return a missing-argument error
-				if zero {
-					// if the field has a 'required' tag, it can't have a zero-value
-					err := ErrMissingInput{}
-					err.Argument = f.Name
-					return nil, err
-				}
+		tag := f.Tag.Get("json")
+		if va.query {
+			tag = f.Tag.Get("q")
+			if tag == "" && !f.Anonymous {
+				continue
 			}
+		}
 
-			if xorTag := f.Tag.Get("xor"); xorTag != "" {
-				//fmt.Printf("Checking `xor` tag for field [%s] with value %+v:\n\txorTag: %s\n", f.Name, v, xorTag)
-				xorField := optsValue.FieldByName(xorTag)
-				var xorFieldIsZero bool
-				if reflect.ValueOf(xorField.Interface()) == reflect.Zero(xorField.Type()) {
-					xorFieldIsZero = true
-				} else {
-					if xorField.Kind() == reflect.Ptr {
-						xorField = xorField.Elem()
-					}
-					xorFieldIsZero = isZero(xorField)
-				}
-				if !(zero != xorFieldIsZero) {
-					err := ErrMissingInput{}
-					err.Argument = fmt.Sprintf("%s/%s", f.Name, xorTag)
-					err.Info = fmt.Sprintf("Exactly one of %s and %s must be provided", f.Name, xorTag)
-					return nil, err
-				}
-			}
+		fv := v.Field(i)
+		name := fieldPath(path, f.Name)
+		zero := isZero(fv)
 
-			if orTag := f.Tag.Get("or"); orTag != "" {
-				//fmt.Printf("Checking `or` tag for field with:\n\tname: %+v\n\torTag:%s\n", f.Name, orTag)
-				//fmt.Printf("field is zero?: %v\n", zero)
-				if zero {
-					orField := optsValue.FieldByName(orTag)
-					var orFieldIsZero bool
-					if reflect.ValueOf(orField.Interface()) == reflect.Zero(orField.Type()) {
-						orFieldIsZero = true
-					} else {
-						if orField.Kind() == reflect.Ptr {
-							orField = orField.Elem()
-						}
-						orFieldIsZero = isZero(orField)
-					}
-					if orFieldIsZero {
-						err := ErrMissingInput{}
-						err.Argument = fmt.Sprintf("%s/%s", f.Name, orTag)
-						err.Info = fmt.Sprintf("At least one of %s and %s must be provided", f.Name, orTag)
-						return nil, err
-					}
-				}
+		// if the field has a 'required' tag, it can't have a zero-value
+		if f.Tag.Get("required") == "true" && zero {
+			err := ErrMissingInput{}
+			err.Argument = name
+			if va.query {
+				err.Info = fmt.Sprintf("Required query parameter [%s] not set.", name)
 			}
+			va.errs = append(va.errs, err)
+		}
 
-			jsonTag := f.Tag.Get("json")
-			if jsonTag == "-" {
-				continue
+		// The other field of a pair usually has the reverse tag, so each
+		// pair is reported once.
+		if xorTag := f.Tag.Get("xor"); xorTag != "" && zero == isFieldZero(v, xorTag) {
+			if key := pairKey("xor", f.Name, xorTag); !reported[key] {
+				reported[key] = true
+				other := fieldPath(path, xorTag)
+				err := ErrMissingInput{}
+				err.Argument = fmt.Sprintf("%s/%s", name, other)
+				err.Info = fmt.Sprintf("Exactly one of %s and %s must be provided", name, other)
+				va.errs = append(va.errs, err)
 			}
+		}
 
-			if v.Kind() == reflect.Struct || (v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct) {
-				if zero {
-					//fmt.Printf("value before change: %+v\n", optsValue.Field(i))
-					if jsonTag != "" {
-						jsonTagPieces := strings.Split(jsonTag, ",")
-						if len(jsonTagPieces) > 1 && jsonTagPieces[1] == "omitempty" {
-							if v.CanSet() {
-								if !v.IsNil() {
-									if v.Kind() == reflect.Ptr {
-										v.Set(reflect.Zero(v.Type()))
-									}
-								}
-								//fmt.Printf("value after change: %+v\n", optsValue.Field(i))
-							}
-						}
-					}
-					continue
-				}
-
-				//fmt.Printf("Calling BuildRequestBody with:\n\tv: %+v\n\tf.Name:%s\n", v.Interface(), f.Name)
-				_, err := BuildRequestBody(v.Interface(), f.Name)
-				if err != nil {
-					return nil, err
-				}
+		if orTag := f.Tag.Get("or"); orTag != "" && zero && isFieldZero(v, orTag) {
+			if key := pairKey("or", f.Name, orTag); !reported[key] {
+				reported[key] = true
+				other := fieldPath(path, orTag)
+				err := ErrMissingInput{}
+				err.Argument = fmt.Sprintf("%s/%s", name, other)
+				err.Info = fmt.Sprintf("At least one of %s and %s must be provided", name, other)
+				va.errs = append(va.errs, err)
 			}
 		}
 
-		//fmt.Printf("opts: %+v \n", opts)
+		// A struct field that isn't set is left out of the request, but a
+		// pointer to a struct is sent even if the struct is empty.
+		if tag == "-" || (fv.Kind() == reflect.Struct && zero) {
+			continue
+		}
+		va.validateValue(fv, name)
+	}
+}
 
-		b, err := json.Marshal(opts)
-		if err != nil {
-			return nil, err
+// validateValue validates the fields of v if it's a struct or a non-nil
+// pointer to one, and those of its elements if it's a slice of structs.
+func (va *validator) validateValue(v reflect.Value, path string) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			va.validateValue(v.Elem(), path)
 		}
+	case reflect.Struct:
+		va.validateStruct(v, path)
+	case reflect.Slice, reflect.Array:
+		elem := v.Type().Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			va.validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
 
-		//fmt.Printf("string(b): %s\n", string(b))
+// err returns the error of a single invalid field as is, and those of
+// several fields as an ErrInvalidOptions.
+func (va *validator) err() error {
+	switch len(va.errs) {
+	case 0:
+		return nil
+	case 1:
+		return va.errs[0]
+	}
+	err := ErrInvalidOptions{}
+	err.Errors = va.errs
+	return err
+}
 
-		err = json.Unmarshal(b, &optsMap)
-		if err != nil {
-			return nil, err
-		}
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
 
-		//fmt.Printf("optsMap: %+v\n", optsMap)
+func pairKey(kind, a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return kind + ":" + a + "/" + b
+}
 
-		if parent != "" {
-			optsMap = map[string]interface{}{parent: optsMap}
-		}
-		//fmt.Printf("optsMap after parent added: %+v\n", optsMap)
-		return optsMap, nil
+// isFieldZero reports whether the named field of v is unset. A pointer to a
+// zero value counts as unset.
+func isFieldZero(v reflect.Value, name string) bool {
+	f := v.FieldByName(name)
+	if !f.IsValid() {
+		return true
 	}
-	// Return an error if the underlying type of 'opts' isn't a struct.
-	return nil, fmt.Errorf("Options type is not a struct.")
+	if f.Kind() == reflect.Ptr && !f.IsNil() {
+		f = f.Elem()
+	}
+	return isZero(f)
 }
 
 // EnabledState is a convenience type, mostly used in Create and Update
@@ -283,6 +324,103 @@ func isZero(v reflect.Value) bool {
 	return v.Interface() == z.Interface()
 }
 
+// SortDirection is the direction in which a list is sorted by a SortKey.
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// SortKey is an attribute to sort a list by, used in the SortKeys field of
+// list options. Lists sorted by several keys are sorted by the first one,
+// then by the next one among equal items and so on.
+type SortKey struct {
+	// Key is the attribute to sort by.
+	Key string
+
+	// Direction is the direction to sort in. If it's empty, the service
+	// sorts in its default direction, unless another key has a direction
+	// and the keys are sent in separate sort_key and sort_dir parameters:
+	// services pair those by position, so the key is sorted in SortAsc.
+	Direction SortDirection
+}
+
+// addSortKeys adds sort keys to params, in the style selected by tags.
+func addSortKeys(params url.Values, tags []string, keys []SortKey) {
+	if len(tags) > 1 {
+		directed := false
+		for _, k := range keys {
+			directed = directed || k.Direction != ""
+		}
+		for _, k := range keys {
+			params.Add(tags[0], k.Key)
+			switch {
+			case k.Direction != "":
+				params.Add(tags[1], string(k.Direction))
+			case directed:
+				params.Add(tags[1], string(SortAsc))
+			}
+		}
+		return
+	}
+
+	s := make([]string, len(keys))
+	for i, k := range keys {
+		s[i] = k.Key
+		if k.Direction != "" {
+			s[i] += ":" + string(k.Direction)
+		}
+	}
+	params.Add(tags[0], strings.Join(s, ","))
+}
+
+// FilterOperator compares the attribute a TimeFilter applies to with its
+// time.
+type FilterOperator string
+
+const (
+	FilterEQ  FilterOperator = "eq"
+	FilterNEQ FilterOperator = "neq"
+	FilterGT  FilterOperator = "gt"
+	FilterGTE FilterOperator = "gte"
+	FilterLT  FilterOperator = "lt"
+	FilterLTE FilterOperator = "lte"
+)
+
+// TimeFilter filters a list by comparing a time attribute, such as the time
+// a resource was created at, with Time.
+type TimeFilter struct {
+	// Operator is the comparison to make. If it's empty, the service
+	// matches the time exactly.
+	Operator FilterOperator
+
+	// Time is the time to compare with.
+	Time time.Time
+}
+
+// MarshalText renders the filter as the value of a query parameter, with its
+// time in RFC 3339 format.
+func (f TimeFilter) MarshalText() ([]byte, error) {
+	return []byte(f.format(time.RFC3339)), nil
+}
+
+func (f TimeFilter) format(layout string) string {
+	t := f.Time.Format(layout)
+	if f.Operator == "" {
+		return t
+	}
+	return string(f.Operator) + ":" + t
+}
+
+// timeLayout returns the layout in which a query parameter renders a time.
+func timeLayout(f reflect.StructField) string {
+	if layout := f.Tag.Get("format"); layout != "" {
+		return layout
+	}
+	return time.RFC3339
+}
+
 /*
 BuildQueryString is an internal function to be used by request methods in
 individual resource packages.
@@ -302,8 +440,29 @@ converted into query parameters based on a "q" tag. For example:
 
 will be converted into "?x_bar=AAA&lorem_ipsum=BBB".
 
-The struct's fields may be strings, integers, or boolean values. Fields left at
-their type's zero value will be omitted from the query.
+The struct's fields may be strings, numbers, boolean values, or values that
+implement encoding.TextMarshaler. Fields left at their type's zero value will
+be omitted from the query. A pointer that is set is rendered even if it points
+to a zero value, such as false, unless its "q" tag has the omitempty option, as
+in `q:"enabled,omitempty"`. Slices are rendered as a repeated parameter, such as
+"?fields=id&fields=name".
+
+A time.Time is rendered in RFC 3339 format, unless the field has a "format"
+tag holding another layout. A TimeFilter is rendered the same way, prefixed
+by its operator, as in "?created_at=gte:2020-01-01T00:00:00Z".
+
+A []SortKey is rendered as "?sort=name:asc,created_at:desc". If the "q" tag
+names two parameters, as in `q:"sort_key,sort_dir"`, each key is rendered as
+a pair of them instead: "?sort_key=name&sort_dir=asc&sort_key=created_at".
+
+A map is rendered as a single parameter of the form "{'key':'value'}", unless
+its "q" tag has the inline option, as in `q:",inline"`, in which case each of
+its entries is rendered as a parameter of its own.
+
+Any other struct is expanded into the parameters of its own fields, so that
+options shared by several requests can be embedded, or held in a field with a
+"q" tag. Their fields are validated as BuildRequestBody describes, except that
+only the fields with a "q" tag are checked.
 */
 func BuildQueryString(opts interface{}) (*url.URL, error) {
 	optsValue := reflect.ValueOf(opts)
@@ -311,70 +470,205 @@ func BuildQueryString(opts interface{}) (*url.URL, error) {
 		optsValue = optsValue.Elem()
 	}
 
-	optsType := reflect.TypeOf(opts)
-	if optsType.Kind() == reflect.Ptr {
-		optsType = optsType.Elem()
+	if optsValue.Kind() != reflect.Struct {
+		// Return an error if the underlying type of 'opts' isn't a struct.
+		return nil, fmt.Errorf("Options type is not a struct.")
+	}
+
+	va := validator{query: true}
+	va.validateStruct(optsValue, "")
+	if err := va.err(); err != nil {
+		return &url.URL{}, err
 	}
 
 	params := url.Values{}
+	if err := addQueryFields(params, optsValue); err != nil {
+		return &url.URL{}, err
+	}
+	return &url.URL{RawQuery: params.Encode()}, nil
+}
 
-	if optsValue.Kind() == reflect.Struct {
-		for i := 0; i < optsValue.NumField(); i++ {
-			v := optsValue.Field(i)
-			f := optsType.Field(i)
-			qTag := f.Tag.Get("q")
+// addQueryFields adds the fields of a struct that have a "q" tag to params,
+// along with those of its embedded structs.
+func addQueryFields(params url.Values, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f := t.Field(i)
+		fv := v.Field(i)
+		if !fv.CanInterface() {
+			continue
+		}
 
-			// if the field has a 'q' tag, it goes in the query string
-			if qTag != "" {
-				tags := strings.Split(qTag, ",")
+		qTag := f.Tag.Get("q")
+		if qTag == "" && f.Anonymous {
+			if k := fv.Kind(); k != reflect.Struct && !(k == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct) {
+				continue
+			}
+		} else if qTag == "" || qTag == "-" {
+			continue
+		}
 
-				// if the field is set, add it to the slice of query pieces
-				if !isZero(v) {
-				loop:
-					switch v.Kind() {
-					case reflect.Ptr:
-						v = v.Elem()
-						goto loop
-					case reflect.String:
-						params.Add(tags[0], v.String())
-					case reflect.Int:
-						params.Add(tags[0], strconv.FormatInt(v.Int(), 10))
-					case reflect.Bool:
-						params.Add(tags[0], strconv.FormatBool(v.Bool()))
-					case reflect.Slice:
-						switch v.Type().Elem() {
-						case reflect.TypeOf(0):
-							for i := 0; i < v.Len(); i++ {
-								params.Add(tags[0], strconv.FormatInt(v.Index(i).Int(), 10))
-							}
-						default:
-							for i := 0; i < v.Len(); i++ {
-								params.Add(tags[0], v.Index(i).String())
-							}
-						}
-					case reflect.Map:
-						if v.Type().Key().Kind() == reflect.String && v.Type().Elem().Kind() == reflect.String {
-							var s []string
-							for _, k := range v.MapKeys() {
-								value := v.MapIndex(k).String()
-								s = append(s, fmt.Sprintf("'%s':'%s'", k.String(), value))
-							}
-							params.Add(tags[0], fmt.Sprintf("{%s}", strings.Join(s, ", ")))
-						}
-					}
-				} else {
-					// if the field has a 'required' tag, it can't have a zero-value
-					if requiredTag := f.Tag.Get("required"); requiredTag == "true" {
-						return &url.URL{}, fmt.Errorf("Required query parameter [%s] not set.", f.Name)
-					}
-				}
+		// if the field is set, add it to the query parameters
+		if isZero(fv) {
+			continue
+		}
+		if err := addQueryField(params, f, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addQueryField adds the field f, whose value v is set, to params.
+func addQueryField(params url.Values, f reflect.StructField, v reflect.Value) error {
+	var names []string
+	var omitEmpty, inline bool
+	for _, tag := range strings.Split(f.Tag.Get("q"), ",") {
+		switch tag {
+		case "omitempty":
+			omitEmpty = true
+		case "inline":
+			inline = true
+		default:
+			names = append(names, tag)
+		}
+	}
+	if len(names) == 0 {
+		names = append(names, "")
+	}
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if omitEmpty && isZero(v) {
+		return nil
+	}
+
+	if keys, ok := v.Interface().([]SortKey); ok {
+		addSortKeys(params, names, keys)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			s, ok, err := queryValue(v.Index(i), f)
+			if err != nil {
+				return err
+			}
+			if ok {
+				params.Add(names[0], s)
 			}
 		}
+	case reflect.Map:
+		entries, err := queryEntries(v, f)
+		if err != nil {
+			return err
+		}
+		if inline {
+			for _, e := range entries {
+				params.Add(e[0], e[1])
+			}
+			return nil
+		}
+		s := make([]string, len(entries))
+		for i, e := range entries {
+			s[i] = fmt.Sprintf("'%s':'%s'", e[0], e[1])
+		}
+		params.Add(names[0], fmt.Sprintf("{%s}", strings.Join(s, ", ")))
+	default:
+		s, ok, err := queryValue(v, f)
+		if err != nil {
+			return err
+		}
+		if ok {
+			params.Add(names[0], s)
+		} else if v.Kind() == reflect.Struct {
+			return addQueryFields(params, v)
+		}
+	}
+	return nil
+}
 
-		return &url.URL{RawQuery: params.Encode()}, nil
+// queryEntries renders the keys and values of a map, sorted by key.
+func queryEntries(v reflect.Value, f reflect.StructField) ([][2]string, error) {
+	var entries [][2]string
+	for _, k := range v.MapKeys() {
+		key, ok, err := queryValue(k, f)
+		if err != nil {
+			return nil, err
+		}
+		value, valueOK, err := queryValue(v.MapIndex(k), f)
+		if err != nil {
+			return nil, err
+		}
+		if ok && valueOK {
+			entries = append(entries, [2]string{key, value})
+		}
 	}
-	// Return an error if the underlying type of 'opts' isn't a struct.
-	return nil, fmt.Errorf("Options type is not a struct.")
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i][0] < entries[j][0]
+	})
+	return entries, nil
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// queryValue renders a single value of the field f. It reports false if v is
+// nil or of a type that can't be rendered as a single parameter.
+func queryValue(v reflect.Value, f reflect.StructField) (string, bool, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false, nil
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value.Format(timeLayout(f)), true, nil
+	case TimeFilter:
+		return value.format(timeLayout(f)), true, nil
+	}
+
+	if m, ok := textMarshaler(v); ok {
+		b, err := m.MarshalText()
+		if err != nil {
+			return "", false, err
+		}
+		return string(b), true, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true, nil
+	}
+	return "", false, nil
+}
+
+// textMarshaler returns v as an encoding.TextMarshaler, if its type or a
+// pointer to it implements the interface.
+func textMarshaler(v reflect.Value) (encoding.TextMarshaler, bool) {
+	if v.Type().Implements(textMarshalerType) {
+		return v.Interface().(encoding.TextMarshaler), true
+	}
+	if reflect.PtrTo(v.Type()).Implements(textMarshalerType) {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return p.Interface().(encoding.TextMarshaler), true
+	}
+	return nil, false
 }
 
 /*
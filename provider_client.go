@@ -2,12 +2,14 @@ package gophercloud
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 )
 
 // DefaultUserAgent is the default User-Agent string set in the request header.
@@ -56,11 +58,36 @@ type ProviderClient struct {
 	// To safely read or write this value, call `Token` or `SetToken`, respectively
 	TokenID string
 
+	// TokenExpiresAt is the time at which the token in TokenID expires. The
+	// zero value means the expiry is unknown.
+	// NOTE: Aside from within a custom ReauthFunc, this field shouldn't be set by an application.
+	// To safely read or write this value, call `TokenExpiry` or `SetTokenExpiry`, respectively
+	TokenExpiresAt time.Time
+
+	// TokenRenewalWindow enables proactive reauthentication. If it is greater
+	// than zero and the token expires within that duration, ReauthFunc is
+	// called before the next request is sent, rather than after that request
+	// fails with a 401. This matters for requests whose body cannot be
+	// replayed, such as streamed object uploads.
+	TokenRenewalWindow time.Duration
+
+	// TokenCache, if set, lets the openstack package reuse a still-valid
+	// token and service catalog stored by an earlier ProviderClient, possibly
+	// in another process, instead of authenticating again. A cached token
+	// that is rejected with a 401 is removed from the cache.
+	TokenCache TokenCache
+
+	// TokenCacheKey identifies the current token in TokenCache.
+	// NOTE: Aside from within a custom ReauthFunc, this field shouldn't be set by an application.
+	TokenCacheKey string
+
 	// EndpointLocator describes how this provider discovers the endpoints for
 	// its constituent services.
 	EndpointLocator EndpointLocator
 
 	// HTTPClient allows users to interject arbitrary http, https, or other transit behaviors.
+	// Connections are kept alive and reused across requests; set DisableKeepAlives on the
+	// client's http.Transport to open a new connection for every request instead.
 	HTTPClient http.Client
 
 	// UserAgent represents the User-Agent header in the HTTP request.
@@ -71,6 +98,24 @@ type ProviderClient struct {
 	// authentication functions for different Identity service versions.
 	ReauthFunc func() error
 
+	// Context is the default context used for every HTTP request issued by
+	// this client, including reauthentication. A RequestOpts.Context or a
+	// context set with ServiceClient.WithContext takes precedence over it.
+	Context context.Context
+
+	// RetryPolicy, if set, makes Request retry requests that fail with a
+	// transient status code such as 429 or 503. Without it, such responses
+	// are returned to the caller as ErrDefault429, ErrDefault503 and so on.
+	RetryPolicy *RetryPolicy
+
+	// Logger, if set, receives a record of every HTTP request, with known
+	// secret headers and JSON fields masked.
+	Logger Logger
+
+	// LogBodies adds JSON request and response bodies to the records passed
+	// to Logger.
+	LogBodies bool
+
 	mut *sync.RWMutex
 
 	reauthmut *reauthlock
@@ -126,6 +171,55 @@ func (client *ProviderClient) SetToken(t string) {
 	client.TokenID = t
 }
 
+// TokenExpiry safely reads the expiry time of the auth token from the ProviderClient.
+func (client *ProviderClient) TokenExpiry() time.Time {
+	if client.mut != nil {
+		client.mut.RLock()
+		defer client.mut.RUnlock()
+	}
+	return client.TokenExpiresAt
+}
+
+// SetTokenExpiry safely sets the expiry time of the auth token in the ProviderClient.
+func (client *ProviderClient) SetTokenExpiry(t time.Time) {
+	if client.mut != nil {
+		client.mut.Lock()
+		defer client.mut.Unlock()
+	}
+	client.TokenExpiresAt = t
+}
+
+// renewTokenIfExpiring reauthenticates if the current token expires within
+// client.TokenRenewalWindow. A failed renewal is only reported if the token
+// has already expired; otherwise the current token is still usable and a
+// later request will try again.
+func (client *ProviderClient) renewTokenIfExpiring() error {
+	if client.ReauthFunc == nil || client.TokenRenewalWindow <= 0 {
+		return nil
+	}
+	var token string
+	var expiresAt time.Time
+	if client.mut != nil {
+		client.mut.RLock()
+		token, expiresAt = client.TokenID, client.TokenExpiresAt
+		client.mut.RUnlock()
+	} else {
+		token, expiresAt = client.TokenID, client.TokenExpiresAt
+	}
+
+	if expiresAt.IsZero() || time.Until(expiresAt) > client.TokenRenewalWindow {
+		return nil
+	}
+
+	// Concurrent callers all end up here; Reauthenticate serializes them and
+	// only the first one renews the token.
+	err := client.Reauthenticate(token)
+	if err != nil && !time.Now().Before(expiresAt) {
+		return ErrUnableToReauthenticate{ErrOriginal: err}
+	}
+	return nil
+}
+
 //Reauthenticate calls client.ReauthFunc in a thread-safe way. If this is
 //called because of a 401 response, the caller may pass the previous token. In
 //this case, the reauthentication can be skipped if another thread has already
@@ -166,7 +260,8 @@ type RequestOpts struct {
 	// will be set unless one is provided explicitly by MoreHeaders.
 	RawBody io.Reader
 	// JSONResponse, if provided, will be populated with the contents of the response body parsed as
-	// JSON.
+	// JSON. The response body is then drained and closed. Otherwise, the body of a successful response
+	// is left open and the caller must close it, so that the underlying connection can be reused.
 	JSONResponse interface{}
 	// OkCodes contains a list of numeric HTTP status codes that should be interpreted as success. If
 	// the response has a different code, an error will be returned.
@@ -178,6 +273,18 @@ type RequestOpts struct {
 	// ErrorContext specifies the resource error type to return if an error is encountered.
 	// This lets resources override default error messages based on the response status code.
 	ErrorContext error
+	// Context, if provided, is attached to the HTTP request. Cancelling it aborts the in-flight
+	// request and any subsequent retry. If it is nil, ProviderClient.Context is used instead.
+	Context context.Context
+	// RetryNonIdempotent allows the ProviderClient's RetryPolicy to retry this request even though its
+	// method is not considered idempotent, e.g. a POST. Only set it when repeating the request is safe.
+	RetryNonIdempotent bool
+}
+
+// requestState carries information across the attempts made for a single call to Request.
+type requestState struct {
+	// retries is the number of times the request has been retried by the RetryPolicy.
+	retries int
 }
 
 var applicationJSON = "application/json"
@@ -185,7 +292,16 @@ var applicationJSON = "application/json"
 // Request performs an HTTP request using the ProviderClient's current HTTPClient. An authentication
 // header will automatically be provided.
 func (client *ProviderClient) Request(method, url string, options *RequestOpts) (*http.Response, error) {
+	return client.doRequest(method, url, options, &requestState{})
+}
+
+func (client *ProviderClient) doRequest(method, url string, options *RequestOpts, state *requestState) (*http.Response, error) {
+	if err := client.renewTokenIfExpiring(); err != nil {
+		return nil, err
+	}
+
 	var body io.Reader
+	var jsonBody []byte
 	var contentType *string
 
 	// Derive the content body by either encoding an arbitrary object as JSON, or by taking a provided
@@ -201,6 +317,7 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 		}
 
 		body = bytes.NewReader(rendered)
+		jsonBody = rendered
 		contentType = &applicationJSON
 	}
 
@@ -214,6 +331,14 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 		return nil, err
 	}
 
+	ctx := options.Context
+	if ctx == nil {
+		ctx = client.Context
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
 	// Populate the request headers. Apply options.MoreHeaders last, to give the caller the chance to
 	// modify or omit any header.
 	if contentType != nil {
@@ -224,6 +349,12 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 	// Set the User-Agent header
 	req.Header.Set("User-Agent", client.UserAgent.Join())
 
+	if ctx != nil {
+		if id := GlobalRequestIDFromContext(ctx); id != "" {
+			req.Header.Set("X-Openstack-Request-Id", id)
+		}
+	}
+
 	if options.MoreHeaders != nil {
 		for k, v := range options.MoreHeaders {
 			if v != "" {
@@ -239,13 +370,14 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 		req.Header.Set(k, v)
 	}
 
-	// Set connection parameter to close the connection immediately when we've got the response
-	req.Close = true
-
 	prereqtok := req.Header.Get("X-Auth-Token")
 
 	// Issue the request.
+	start := time.Now()
 	resp, err := client.HTTPClient.Do(req)
+	if client.Logger != nil {
+		client.logRequest(req, jsonBody, resp, err, start)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -268,11 +400,23 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 		body, _ := ioutil.ReadAll(resp.Body)
 		resp.Body.Close()
 		respErr := ErrUnexpectedResponseCode{
-			URL:      url,
-			Method:   method,
-			Expected: options.OkCodes,
-			Actual:   resp.StatusCode,
-			Body:     body,
+			URL:            url,
+			Method:         method,
+			Expected:       options.OkCodes,
+			Actual:         resp.StatusCode,
+			Body:           body,
+			ResponseHeader: resp.Header,
+			RequestID:      RequestIDFromHeader(resp.Header),
+			Fault:          ParseFault(body),
+		}
+
+		if client.RetryPolicy != nil && client.RetryPolicy.shouldRetry(method, resp.StatusCode, state.retries+1, options) && rewindBody(options.RawBody) {
+			delay := client.RetryPolicy.delay(state.retries+1, resp)
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, err
+			}
+			state.retries++
+			return client.doRequest(method, url, options, state)
 		}
 
 		errType := options.ErrorContext
@@ -283,7 +427,11 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 				err = error400er.Error400(respErr)
 			}
 		case http.StatusUnauthorized:
+			client.evictCachedToken(prereqtok)
 			if client.ReauthFunc != nil {
+				if ctx != nil && ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
 				err = client.Reauthenticate(prereqtok)
 				if err != nil {
 					e := &ErrUnableToReauthenticate{}
@@ -295,7 +443,7 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 						seeker.Seek(0, 0)
 					}
 				}
-				resp, err = client.Request(method, url, options)
+				resp, err = client.doRequest(method, url, options, state)
 				if err != nil {
 					switch err.(type) {
 					case *ErrUnexpectedResponseCode:
@@ -360,7 +508,7 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 
 	// Parse the response body as JSON, if requested to do so.
 	if options.JSONResponse != nil {
-		defer resp.Body.Close()
+		defer drainBody(resp.Body)
 		if err := json.NewDecoder(resp.Body).Decode(options.JSONResponse); err != nil {
 			return nil, err
 		}
@@ -369,6 +517,87 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 	return resp, nil
 }
 
+// drainBody reads a response body to EOF and closes it, so that the
+// underlying connection can be reused for subsequent requests.
+func drainBody(body io.ReadCloser) {
+	io.Copy(ioutil.Discard, body)
+	body.Close()
+}
+
+// logRequest hands a record of a completed request to client.Logger. If
+// bodies are logged, a JSON response body is buffered and resp.Body is
+// replaced so that callers can still consume it.
+func (client *ProviderClient) logRequest(req *http.Request, reqBody []byte, resp *http.Response, err error, start time.Time) {
+	entry := &RequestLogEntry{
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHeader: redactHeader(req.Header),
+		Err:           err,
+	}
+	if client.LogBodies {
+		entry.RequestBody = redactJSON(reqBody)
+	}
+
+	if resp != nil {
+		entry.StatusCode = resp.StatusCode
+		entry.ResponseHeader = redactHeader(resp.Header)
+		if client.LogBodies && strings.HasPrefix(resp.Header.Get("Content-Type"), applicationJSON) {
+			b, readErr := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if readErr != nil {
+				// Replay the read error to the caller after the partial body.
+				resp.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(b), errReader{readErr}))
+			} else {
+				resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+				entry.ResponseBody = redactJSON(b)
+			}
+		}
+	}
+
+	entry.Duration = time.Since(start)
+	client.Logger.LogRequest(entry)
+}
+
+// errReader is an io.Reader that always fails with err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// rewindBody seeks a raw request body back to its start so that it can be
+// sent again. It reports false if the body cannot be replayed.
+func rewindBody(body io.Reader) bool {
+	if body == nil {
+		return true
+	}
+	seeker, ok := body.(io.Seeker)
+	if !ok {
+		return false
+	}
+	_, err := seeker.Seek(0, io.SeekStart)
+	return err == nil
+}
+
+// sleepContext waits for d to elapse, returning early with ctx.Err() if ctx
+// is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	var done <-chan struct{}
+	if ctx != nil {
+		done = ctx.Done()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-done:
+		return ctx.Err()
+	}
+}
+
 func defaultOkCodes(method string) []int {
 	switch {
 	case method == "GET":
@@ -0,0 +1,45 @@
+package gophercloud
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+// globalRequestIDKey is the context key under which WithGlobalRequestID
+// stores the global request ID.
+type globalRequestIDKey struct{}
+
+// WithGlobalRequestID returns a copy of ctx carrying a global request ID.
+// Every request made with the returned context, for instance through
+// ServiceClient.WithContext, sends it in the X-Openstack-Request-Id header.
+// Services that support global request IDs record it alongside their own
+// request ID, which allows a single workflow to be traced across Nova,
+// Neutron, Cinder and the other services it touches.
+//
+// OpenStack services only accept IDs of the form "req-<UUID>"; use
+// NewGlobalRequestID to generate one.
+func WithGlobalRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, globalRequestIDKey{}, id)
+}
+
+// GlobalRequestIDFromContext returns the global request ID stored in ctx by
+// WithGlobalRequestID, or "" if there is none.
+func GlobalRequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(globalRequestIDKey{}).(string)
+	return id
+}
+
+// NewGlobalRequestID returns a random global request ID of the form
+// "req-<UUID>".
+func NewGlobalRequestID() (string, error) {
+	var u [16]byte
+	if _, err := io.ReadFull(rand.Reader, u[:]); err != nil {
+		return "", err
+	}
+	// Set the version (4) and variant (RFC 4122) bits.
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return fmt.Sprintf("req-%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16]), nil
+}
@@ -38,6 +38,20 @@ type Result struct {
 	Err error
 }
 
+// ParseResponse is a helper function to parse an http.Response, as returned by
+// the ServiceClient request methods, into its status code and headers. It
+// passes err through unchanged. Resource packages use it to populate the
+// Header field of their Result types:
+//
+//	resp, err := client.Get(url, &r.Body, nil)
+//	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+func ParseResponse(resp *http.Response, err error) (int, http.Header, error) {
+	if resp != nil {
+		return resp.StatusCode, resp.Header, err
+	}
+	return 0, nil, err
+}
+
 // ExtractInto allows users to provide an object into which `Extract` will extract
 // the `Result.Body`. This would be useful for OpenStack providers that have
 // different fields in the response object than OpenStack proper.
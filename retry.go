@@ -0,0 +1,148 @@
+package gophercloud
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how a ProviderClient retries requests that fail with
+// a transient HTTP status code, such as 429 or 503.
+//
+// Assign a RetryPolicy to ProviderClient.RetryPolicy to enable retries. The
+// zero value of each field selects a sensible default, so
+// &gophercloud.RetryPolicy{} is a usable policy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is issued,
+	// including the first attempt. Defaults to 3.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry. Each subsequent retry
+	// doubles it. Defaults to 1 second.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts, both for the computed
+	// backoff and for a server-supplied Retry-After value. Defaults to 30
+	// seconds.
+	MaxDelay time.Duration
+
+	// StatusCodes lists the HTTP status codes that are considered transient.
+	// Defaults to 429, 502, 503 and 504.
+	StatusCodes []int
+
+	// Methods lists the HTTP methods that may be retried. Defaults to the
+	// idempotent methods GET, HEAD, PUT, DELETE and OPTIONS. Requests using
+	// other methods, such as POST, are only retried if they are listed here
+	// or if RequestOpts.RetryNonIdempotent is set.
+	Methods []string
+}
+
+var (
+	defaultRetryStatusCodes = []int{429, 502, 503, 504}
+	defaultRetryMethods     = []string{"GET", "HEAD", "PUT", "DELETE", "OPTIONS"}
+)
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts
+	}
+	return 3
+}
+
+func (p *RetryPolicy) baseDelay() time.Duration {
+	if p.BaseDelay > 0 {
+		return p.BaseDelay
+	}
+	return 1 * time.Second
+}
+
+func (p *RetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay > 0 {
+		return p.MaxDelay
+	}
+	return 30 * time.Second
+}
+
+// shouldRetry reports whether a request that has already been issued
+// attempts times and failed with the given status code may be issued again.
+func (p *RetryPolicy) shouldRetry(method string, status, attempts int, options *RequestOpts) bool {
+	if attempts >= p.maxAttempts() {
+		return false
+	}
+
+	codes := p.StatusCodes
+	if codes == nil {
+		codes = defaultRetryStatusCodes
+	}
+	var retryableCode bool
+	for _, code := range codes {
+		if code == status {
+			retryableCode = true
+			break
+		}
+	}
+	if !retryableCode {
+		return false
+	}
+
+	if options.RetryNonIdempotent {
+		return true
+	}
+	methods := p.Methods
+	if methods == nil {
+		methods = defaultRetryMethods
+	}
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt. A valid
+// Retry-After header takes precedence over the exponential backoff, which
+// is randomized between half and all of its nominal value.
+func (p *RetryPolicy) delay(attempts int, resp *http.Response) time.Duration {
+	max := p.maxDelay()
+
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		if d > max {
+			return max
+		}
+		return d
+	}
+
+	d := p.baseDelay()
+	for i := 1; i < attempts && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
@@ -1,6 +1,7 @@
 package gophercloud
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strings"
@@ -32,6 +33,37 @@ type ServiceClient struct {
 	// MoreHeaders allows users (or Gophercloud) to set service-wide headers on requests. Put another way,
 	// values set in this field will be set on all the HTTP requests the service client sends.
 	MoreHeaders map[string]string
+
+	// ctx is the context set by WithContext. It is attached to every request
+	// that doesn't carry a context of its own.
+	ctx context.Context
+}
+
+// WithContext returns a shallow copy of the service client that attaches ctx
+// to all of its requests, including the requests issued by a Pager and the
+// polling done by WaitForStatus helpers. The copy shares the underlying
+// ProviderClient, so tokens obtained through reauthentication are visible to
+// both clients.
+func (client *ServiceClient) WithContext(ctx context.Context) *ServiceClient {
+	if ctx == nil {
+		panic("nil context")
+	}
+	c := *client
+	c.ctx = ctx
+	return &c
+}
+
+// RequestContext returns the context used for the service client's requests:
+// the one set by WithContext if any, then ProviderClient.Context, and finally
+// context.Background().
+func (client *ServiceClient) RequestContext() context.Context {
+	if client.ctx != nil {
+		return client.ctx
+	}
+	if client.ProviderClient != nil && client.ProviderClient.Context != nil {
+		return client.ProviderClient.Context
+	}
+	return context.Background()
 }
 
 // ResourceBaseURL returns the base URL of any resources used by this service. It MUST end with a /.
@@ -138,13 +170,19 @@ func (client *ServiceClient) setMicroversionHeader(opts *RequestOpts) {
 
 // Request carries out the HTTP operation for the service client
 func (client *ServiceClient) Request(method, url string, options *RequestOpts) (*http.Response, error) {
+	if options == nil {
+		options = new(RequestOpts)
+	}
 	if len(client.MoreHeaders) > 0 {
-		if options == nil {
-			options = new(RequestOpts)
+		if options.MoreHeaders == nil {
+			options.MoreHeaders = make(map[string]string)
 		}
 		for k, v := range client.MoreHeaders {
 			options.MoreHeaders[k] = v
 		}
 	}
+	if options.Context == nil && client.ctx != nil {
+		options.Context = client.ctx
+	}
 	return client.ProviderClient.Request(method, url, options)
 }
@@ -0,0 +1,289 @@
+package fakecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type volume struct {
+	lifecycle
+
+	id               string
+	name             string
+	description      string
+	size             int
+	volumeType       string
+	availabilityZone string
+	metadata         map[string]string
+	createdAt        time.Time
+	updatedAt        time.Time
+
+	// attachments are the servers the volume is attached to, through the
+	// Compute API.
+	attachments []volumeAttachment
+}
+
+type volumeAttachment struct {
+	id         string
+	serverID   string
+	device     string
+	attachedAt time.Time
+}
+
+func (c *Cloud) volumeView(v *volume) map[string]interface{} {
+	attachments := []map[string]interface{}{}
+	for _, a := range v.attachments {
+		attachments = append(attachments, map[string]interface{}{
+			"id":            v.id,
+			"attachment_id": a.id,
+			"volume_id":     v.id,
+			"server_id":     a.serverID,
+			"device":        a.device,
+			"host_name":     nil,
+			"attached_at":   a.attachedAt.Format(microTimeLayout),
+		})
+	}
+
+	return map[string]interface{}{
+		"id":                           v.id,
+		"name":                         v.name,
+		"description":                  v.description,
+		"status":                       v.status,
+		"size":                         v.size,
+		"volume_type":                  v.volumeType,
+		"availability_zone":            v.availabilityZone,
+		"metadata":                     v.metadata,
+		"attachments":                  attachments,
+		"bootable":                     "false",
+		"encrypted":                    false,
+		"multiattach":                  false,
+		"snapshot_id":                  nil,
+		"source_volid":                 nil,
+		"consistencygroup_id":          nil,
+		"replication_status":           nil,
+		"user_id":                      UserID,
+		"os-vol-tenant-attr:tenant_id": ProjectID,
+		"created_at":                   v.createdAt.Format(microTimeLayout),
+		"updated_at":                   v.updatedAt.Format(microTimeLayout),
+		"links":                        c.volumeLinks(v.id),
+	}
+}
+
+func (c *Cloud) volumeLinks(id string) []map[string]string {
+	return []map[string]string{
+		{"rel": "self", "href": c.server.URL + "/volume/v3/" + ProjectID + "/volumes/" + id},
+		{"rel": "bookmark", "href": c.server.URL + "/volume/" + ProjectID + "/volumes/" + id},
+	}
+}
+
+func (c *Cloud) mustVolume(id string) *volume {
+	item, _ := c.volumes.get(id)
+	return item.(*volume)
+}
+
+func (c *Cloud) serveBlockStorage(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 3 || parts[0] != "v3" || parts[1] != ProjectID || parts[2] != "volumes" {
+		computeFault(w, http.StatusNotFound, "The resource could not be found.")
+		return
+	}
+	parts = parts[3:]
+
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		c.listVolumes(w, r, false)
+	case len(parts) == 0 && r.Method == "POST":
+		c.createVolume(w, r)
+	case len(parts) == 1 && parts[0] == "detail" && r.Method == "GET":
+		c.listVolumes(w, r, true)
+	case len(parts) == 1 || (len(parts) == 2 && parts[1] == "action" && r.Method == "POST"):
+		v, ok := c.readVolume(parts[0], r.Method == "GET")
+		if !ok {
+			computeFault(w, http.StatusNotFound, fmt.Sprintf("Volume %s could not be found.", parts[0]))
+			return
+		}
+		if len(parts) == 2 {
+			c.volumeAction(w, r, v)
+			return
+		}
+		c.serveVolume(w, r, v)
+	default:
+		computeFault(w, http.StatusNotFound, "The resource could not be found.")
+	}
+}
+
+// readVolume returns a volume, counting a read of it if read is set. A
+// volume that is deleted once it's read isn't found.
+func (c *Cloud) readVolume(id string, read bool) (*volume, bool) {
+	item, ok := c.volumes.get(id)
+	if !ok {
+		return nil, false
+	}
+	v := item.(*volume)
+	if read {
+		v.read()
+		if _, ok := c.volumes.get(id); !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func (c *Cloud) listVolumes(w http.ResponseWriter, r *http.Request, detail bool) {
+	items := c.volumes.all()
+	for _, item := range items {
+		item.(*volume).read()
+	}
+
+	// Volumes are listed from the newest to the oldest.
+	var views []interface{}
+	for i := len(items) - 1; i >= 0; i-- {
+		v := items[i].(*volume)
+		if _, ok := c.volumes.get(v.id); !ok {
+			continue
+		}
+		view := c.volumeView(v)
+		if !detail {
+			view = map[string]interface{}{"id": v.id, "name": v.name, "links": view["links"]}
+		}
+		views = append(views, view)
+	}
+
+	c.writeList(w, r, listQuery{
+		key:      "volumes",
+		filters:  map[string]string{"name": "name", "status": "status"},
+		sortKeys: joinedSortKeys(r.URL.Query()),
+		fault:    computeFault,
+	}, views)
+}
+
+type volumeRequest struct {
+	Volume *struct {
+		Name             *string           `json:"name"`
+		Description      *string           `json:"description"`
+		Size             int               `json:"size"`
+		VolumeType       string            `json:"volume_type"`
+		AvailabilityZone string            `json:"availability_zone"`
+		Metadata         map[string]string `json:"metadata"`
+	} `json:"volume"`
+}
+
+func (c *Cloud) createVolume(w http.ResponseWriter, r *http.Request) {
+	var req volumeRequest
+	if !readJSON(w, r, &req, computeFault) {
+		return
+	}
+	opts := req.Volume
+	if opts == nil {
+		computeFault(w, http.StatusBadRequest, "Missing required element 'volume' in request body.")
+		return
+	}
+	if opts.Size <= 0 {
+		computeFault(w, http.StatusBadRequest, fmt.Sprintf("Invalid input received: Volume size '%d' must be an integer and greater than 0", opts.Size))
+		return
+	}
+
+	t := now()
+	v := &volume{
+		id:               newID(),
+		size:             opts.Size,
+		volumeType:       opts.VolumeType,
+		availabilityZone: opts.AvailabilityZone,
+		metadata:         opts.Metadata,
+		createdAt:        t,
+		updatedAt:        t,
+	}
+	if opts.Name != nil {
+		v.name = *opts.Name
+	}
+	if opts.Description != nil {
+		v.description = *opts.Description
+	}
+	if v.volumeType == "" {
+		v.volumeType = "lvmdriver-1"
+	}
+	if v.availabilityZone == "" {
+		v.availabilityZone = "nova"
+	}
+	if v.metadata == nil {
+		v.metadata = map[string]string{}
+	}
+
+	c.volumes.add(v.id, v)
+	c.transition(&v.lifecycle, "creating", "available", nil)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"volume": c.volumeView(v)})
+}
+
+func (c *Cloud) serveVolume(w http.ResponseWriter, r *http.Request, v *volume) {
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"volume": c.volumeView(v)})
+	case "PUT":
+		var req volumeRequest
+		if !readJSON(w, r, &req, computeFault) {
+			return
+		}
+		if req.Volume == nil {
+			computeFault(w, http.StatusBadRequest, "Missing required element 'volume' in request body.")
+			return
+		}
+		if s := req.Volume.Name; s != nil {
+			v.name = *s
+		}
+		if s := req.Volume.Description; s != nil {
+			v.description = *s
+		}
+		if m := req.Volume.Metadata; m != nil {
+			v.metadata = m
+		}
+		v.updatedAt = now()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"volume": c.volumeView(v)})
+	case "DELETE":
+		if v.busy() || (v.status != "available" && v.status != "error") || len(v.attachments) > 0 {
+			computeFault(w, http.StatusBadRequest, fmt.Sprintf("Invalid volume: Volume status must be available or error or error_restoring or error_extending or error_managing and must not be migrating, attached, belong to a group, have snapshots or be disassociated from snapshots after volume transfer. Current status is: %s", v.status))
+			return
+		}
+		c.transition(&v.lifecycle, "deleting", "deleted", func() {
+			c.volumes.remove(v.id)
+		})
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		computeFault(w, http.StatusMethodNotAllowed, "The method is not allowed for the requested URL.")
+	}
+}
+
+func (c *Cloud) volumeAction(w http.ResponseWriter, r *http.Request, v *volume) {
+	var req map[string]json.RawMessage
+	if !readJSON(w, r, &req, computeFault) {
+		return
+	}
+
+	body, ok := req["os-extend"]
+	if len(req) != 1 || !ok {
+		computeFault(w, http.StatusBadRequest, "There is no such action in the request body.")
+		return
+	}
+
+	var opts struct {
+		NewSize int `json:"new_size"`
+	}
+	if err := json.Unmarshal(body, &opts); err != nil {
+		computeFault(w, http.StatusBadRequest, fmt.Sprintf("Invalid input for field/attribute new_size: %s", err))
+		return
+	}
+	if v.busy() || v.status != "available" {
+		computeFault(w, http.StatusBadRequest, fmt.Sprintf("Invalid volume: Volume %s status must be available to extend, but current status is: %s.", v.id, v.status))
+		return
+	}
+	if opts.NewSize <= v.size {
+		computeFault(w, http.StatusBadRequest, fmt.Sprintf("Invalid input received: New size for extend must be greater than current size. (current: %d, extended: %d).", v.size, opts.NewSize))
+		return
+	}
+
+	c.transition(&v.lifecycle, "extending", "available", func() {
+		v.size = opts.NewSize
+		v.updatedAt = now()
+	})
+	w.WriteHeader(http.StatusAccepted)
+}
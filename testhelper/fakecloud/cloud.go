@@ -0,0 +1,467 @@
+package fakecloud
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// The user and project that a Cloud authenticates. The user is a member of
+// the project, and of no other.
+const (
+	UserID      = "7a2ec1e5c3fd4fdb9a3c0e4df4b4e4c5"
+	Username    = "demo"
+	Password    = "secret"
+	ProjectID   = "0a8f5b0b6a5b4d0c9d6c0c3bb3a3d4ef"
+	ProjectName = "demo"
+	DomainID    = "default"
+	DomainName  = "Default"
+	Region      = "RegionOne"
+)
+
+// Cloud is an OpenStack cloud that keeps its resources in memory. It serves
+// the Identity v3, Compute v2.1, Networking v2.0, Block Storage v3 and Object
+// Storage v1 APIs over HTTP, on a local address, with a catalog that points
+// at itself.
+//
+// Resources move through the transitional statuses of the real services:
+// a server is in BUILD before it's ACTIVE, and a volume is creating before
+// it's available. A resource leaves its transitional status when it's read,
+// by a Get or a List request, so that polling for a status works as it does
+// against a real cloud.
+//
+// A Cloud is safe for concurrent use.
+type Cloud struct {
+	// TransitionReads is the number of reads for which a resource stays in
+	// a transitional status. It defaults to 0: the first read after a
+	// request that starts a transition shows the status it leads to.
+	TransitionReads int
+
+	// TokenLifetime is the time for which the tokens that are issued are
+	// valid. It defaults to an hour.
+	TokenLifetime time.Duration
+
+	server *httptest.Server
+
+	mu         sync.Mutex
+	tokens     map[string]*token
+	flavors    *collection
+	servers    *collection
+	networks   *collection
+	subnets    *collection
+	ports      *collection
+	volumes    *collection
+	containers map[string]*container
+}
+
+// New starts a Cloud with a few flavors and no other resources. Close it
+// when it's no longer used.
+func New() *Cloud {
+	c := &Cloud{
+		TokenLifetime: time.Hour,
+		tokens:        make(map[string]*token),
+		flavors:       newCollection(),
+		servers:       newCollection(),
+		networks:      newCollection(),
+		subnets:       newCollection(),
+		ports:         newCollection(),
+		volumes:       newCollection(),
+		containers:    make(map[string]*container),
+	}
+	for _, f := range defaultFlavors {
+		f := f
+		c.flavors.add(f.ID, &f)
+	}
+	c.server = httptest.NewServer(c)
+	return c
+}
+
+// Close shuts the Cloud down.
+func (c *Cloud) Close() {
+	c.server.Close()
+}
+
+// URL returns the base URL of the Cloud.
+func (c *Cloud) URL() string {
+	return c.server.URL
+}
+
+// IdentityEndpoint returns the URL of the Identity v3 API of the Cloud.
+func (c *Cloud) IdentityEndpoint() string {
+	return c.server.URL + "/identity/v3/"
+}
+
+// AuthOptions returns the options that authenticate as the user of the
+// Cloud, scoped to its project. They allow reauthentication.
+func (c *Cloud) AuthOptions() gophercloud.AuthOptions {
+	return gophercloud.AuthOptions{
+		IdentityEndpoint: c.IdentityEndpoint(),
+		Username:         Username,
+		Password:         Password,
+		DomainName:       DomainName,
+		TenantID:         ProjectID,
+		AllowReauth:      true,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (c *Cloud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w.Header().Set("X-Openstack-Request-Id", "req-"+newID())
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	service := path
+	rest := ""
+	if i := strings.Index(path, "/"); i >= 0 {
+		service, rest = path[:i], path[i+1:]
+	}
+
+	if service == "identity" {
+		c.serveIdentity(w, r, splitPath(rest))
+		return
+	}
+
+	if !c.authorized(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    http.StatusUnauthorized,
+				"title":   "Unauthorized",
+				"message": "The request you have made requires authentication.",
+			},
+		})
+		return
+	}
+
+	switch service {
+	case "compute":
+		c.serveCompute(w, r, splitPath(rest))
+	case "network":
+		c.serveNetwork(w, r, splitPath(rest))
+	case "volume":
+		c.serveBlockStorage(w, r, splitPath(rest))
+	case "object-store":
+		c.serveObjectStorage(w, r, rest)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// splitPath returns the non-empty segments of path.
+func splitPath(path string) []string {
+	var parts []string
+	for _, p := range strings.Split(path, "/") {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// newID returns a random ID in the format of a UUID.
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// now returns the current time, rounded to the microsecond that the APIs
+// render.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// The layouts in which the APIs render times.
+const (
+	timeLayout      = "2006-01-02T15:04:05Z"
+	microTimeLayout = "2006-01-02T15:04:05.000000"
+)
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// readJSON decodes the body of r into v, writing a fault with badRequest if
+// it isn't valid.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}, badRequest func(http.ResponseWriter, int, string)) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		badRequest(w, http.StatusBadRequest, fmt.Sprintf("Malformed request body: %s", err))
+		return false
+	}
+	return true
+}
+
+// lifecycle is the status of a resource that moves through transitional
+// statuses, such as a server that is built and then becomes ACTIVE.
+type lifecycle struct {
+	status string
+	next   string
+	reads  int
+	settle func()
+}
+
+// transition puts l in a transitional status, which it leaves for next after
+// it's been read TransitionReads times. settle, if not nil, is called once it
+// has.
+func (c *Cloud) transition(l *lifecycle, status, next string, settle func()) {
+	l.status = status
+	l.next = next
+	l.reads = c.TransitionReads
+	l.settle = settle
+}
+
+// busy reports whether l is in a transitional status.
+func (l *lifecycle) busy() bool {
+	return l.next != ""
+}
+
+// read records a read of the resource, which settles it in its next status
+// once it has been read enough times.
+func (l *lifecycle) read() {
+	if l.next == "" {
+		return
+	}
+	if l.reads > 0 {
+		l.reads--
+		return
+	}
+	l.status, l.next = l.next, ""
+	if settle := l.settle; settle != nil {
+		l.settle = nil
+		settle()
+	}
+}
+
+// collection holds resources by ID, in the order in which they were
+// created.
+type collection struct {
+	ids   []string
+	items map[string]interface{}
+}
+
+func newCollection() *collection {
+	return &collection{items: make(map[string]interface{})}
+}
+
+func (c *collection) add(id string, item interface{}) {
+	c.ids = append(c.ids, id)
+	c.items[id] = item
+}
+
+func (c *collection) get(id string) (interface{}, bool) {
+	item, ok := c.items[id]
+	return item, ok
+}
+
+func (c *collection) remove(id string) {
+	if _, ok := c.items[id]; !ok {
+		return
+	}
+	delete(c.items, id)
+	for i, v := range c.ids {
+		if v == id {
+			c.ids = append(c.ids[:i:i], c.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// all returns the resources in the order in which they were created.
+func (c *collection) all() []interface{} {
+	items := make([]interface{}, len(c.ids))
+	for i, id := range c.ids {
+		items[i] = c.items[id]
+	}
+	return items
+}
+
+// listQuery is how a service filters, sorts and pages a list.
+type listQuery struct {
+	// key is the key of the list in the response, such as "servers".
+	key string
+
+	// filters maps the query parameters that filter the list to the
+	// attributes they match.
+	filters map[string]string
+
+	// sortKeys are the keys the list is sorted by.
+	sortKeys []sortKey
+
+	// fields, if set, are the attributes to render.
+	fields []string
+
+	// fault writes the error of an invalid query.
+	fault func(http.ResponseWriter, int, string)
+}
+
+type sortKey struct {
+	key  string
+	desc bool
+}
+
+// pairedSortKeys returns the keys of the sort_key and sort_dir parameters
+// used by the Compute and Networking APIs.
+func pairedSortKeys(q url.Values) []sortKey {
+	dirs := q["sort_dir"]
+	keys := make([]sortKey, len(q["sort_key"]))
+	for i, k := range q["sort_key"] {
+		keys[i].key = k
+		keys[i].desc = i < len(dirs) && dirs[i] == "desc"
+	}
+	return keys
+}
+
+// joinedSortKeys returns the keys of the sort parameter used by the Block
+// Storage API, such as "name:asc,created_at:desc".
+func joinedSortKeys(q url.Values) []sortKey {
+	var keys []sortKey
+	for _, s := range strings.Split(q.Get("sort"), ",") {
+		if s == "" {
+			continue
+		}
+		parts := strings.SplitN(s, ":", 2)
+		keys = append(keys, sortKey{
+			key:  parts[0],
+			desc: len(parts) > 1 && parts[1] == "desc",
+		})
+	}
+	return keys
+}
+
+// writeList writes the page of views that r asks for, with a link to the
+// next page if there is one. Views are filtered, sorted and paged by their
+// JSON attributes; each must have an "id".
+func (c *Cloud) writeList(w http.ResponseWriter, r *http.Request, lq listQuery, views []interface{}) {
+	q := r.URL.Query()
+
+	var items []map[string]interface{}
+	for _, v := range views {
+		item := toMap(v)
+		if matches(item, q, lq.filters) {
+			items = append(items, item)
+		}
+	}
+
+	if len(lq.sortKeys) > 0 {
+		sort.SliceStable(items, func(i, j int) bool {
+			for _, k := range lq.sortKeys {
+				if less, equal := compare(items[i][k.key], items[j][k.key]); !equal {
+					return less != k.desc
+				}
+			}
+			return false
+		})
+	}
+
+	start := 0
+	if marker := q.Get("marker"); marker != "" {
+		start = -1
+		for i, item := range items {
+			if item["id"] == marker {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			lq.fault(w, http.StatusBadRequest, fmt.Sprintf("Marker %s could not be found.", marker))
+			return
+		}
+	}
+
+	end := len(items)
+	if s := q.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 0 {
+			lq.fault(w, http.StatusBadRequest, fmt.Sprintf("Invalid limit %q.", s))
+			return
+		}
+		if start+limit < end {
+			end = start + limit
+		}
+	}
+
+	page := items[start:end]
+	if len(lq.fields) > 0 {
+		for i, item := range page {
+			selected := make(map[string]interface{})
+			for _, f := range lq.fields {
+				if v, ok := item[f]; ok {
+					selected[f] = v
+				}
+			}
+			page[i] = selected
+		}
+	}
+
+	body := map[string]interface{}{lq.key: page}
+	if end < len(items) {
+		q.Set("marker", fmt.Sprint(items[end-1]["id"]))
+		next := c.server.URL + r.URL.Path + "?" + q.Encode()
+		body[lq.key+"_links"] = []map[string]string{{"href": next, "rel": "next"}}
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// compare orders two JSON attributes, numerically if both are numbers.
+func compare(a, b interface{}) (less, equal bool) {
+	if x, ok := a.(float64); ok {
+		if y, ok := b.(float64); ok {
+			return x < y, x == y
+		}
+	}
+	s, t := fmt.Sprint(a), fmt.Sprint(b)
+	return s < t, s == t
+}
+
+// matches reports whether item has the attributes that the query
+// parameters in filters ask for. A parameter that is repeated matches any
+// of its values.
+func matches(item map[string]interface{}, q url.Values, filters map[string]string) bool {
+	for param, attr := range filters {
+		values, ok := q[param]
+		if !ok {
+			continue
+		}
+		found := false
+		for _, v := range values {
+			if fmt.Sprint(item[attr]) == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// toMap renders v as the map of its JSON attributes.
+func toMap(v interface{}) map[string]interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		panic(err)
+	}
+	return m
+}
@@ -0,0 +1,854 @@
+package fakecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// computeFault writes an error in the format of the Compute and Block
+// Storage APIs.
+func computeFault(w http.ResponseWriter, code int, message string) {
+	key := "computeFault"
+	switch code {
+	case http.StatusBadRequest:
+		key = "badRequest"
+	case http.StatusForbidden:
+		key = "forbidden"
+	case http.StatusNotFound:
+		key = "itemNotFound"
+	case http.StatusConflict:
+		key = "conflictingRequest"
+	}
+	writeJSON(w, code, map[string]interface{}{
+		key: map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+type flavor struct {
+	ID    string
+	Name  string
+	RAM   int
+	Disk  int
+	VCPUs int
+}
+
+// defaultFlavors are the flavors of a new Cloud.
+var defaultFlavors = []flavor{
+	{ID: "1", Name: "m1.tiny", RAM: 512, Disk: 1, VCPUs: 1},
+	{ID: "2", Name: "m1.small", RAM: 2048, Disk: 20, VCPUs: 1},
+	{ID: "3", Name: "m1.medium", RAM: 4096, Disk: 40, VCPUs: 2},
+	{ID: "4", Name: "m1.large", RAM: 8192, Disk: 80, VCPUs: 4},
+	{ID: "5", Name: "m1.xlarge", RAM: 16384, Disk: 160, VCPUs: 8},
+}
+
+func (c *Cloud) computeLinks(resource, id string) []map[string]string {
+	return []map[string]string{
+		{"rel": "self", "href": c.server.URL + "/compute/v2.1/" + resource + "/" + id},
+		{"rel": "bookmark", "href": c.server.URL + "/compute/" + resource + "/" + id},
+	}
+}
+
+func (c *Cloud) flavorView(f *flavor) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                         f.ID,
+		"name":                       f.Name,
+		"ram":                        f.RAM,
+		"disk":                       f.Disk,
+		"vcpus":                      f.VCPUs,
+		"swap":                       "",
+		"rxtx_factor":                1.0,
+		"OS-FLV-EXT-DATA:ephemeral":  0,
+		"OS-FLV-DISABLED:disabled":   false,
+		"os-flavor-access:is_public": true,
+		"links":                      c.computeLinks("flavors", f.ID),
+	}
+}
+
+type server struct {
+	lifecycle
+
+	id               string
+	name             string
+	imageID          string
+	flavorID         string
+	keyName          string
+	adminPass        string
+	metadata         map[string]string
+	securityGroups   []string
+	availabilityZone string
+	createdAt        time.Time
+	updatedAt        time.Time
+	launchedAt       time.Time
+
+	// task is the task that the server is busy with, such as
+	// "powering-off".
+	task string
+
+	// ports are the IDs of the ports of the server, in the order in which
+	// they were attached. The ports in ownPorts were created for the
+	// server and are deleted with it.
+	ports    []string
+	ownPorts map[string]bool
+
+	// volumes are the IDs of the volumes attached to the server.
+	volumes []string
+}
+
+// vmStates are the states of the servers in each status.
+var vmStates = map[string]struct {
+	vmState    string
+	powerState int
+}{
+	"BUILD":       {"building", 0},
+	"ACTIVE":      {"active", 1},
+	"REBOOT":      {"active", 1},
+	"HARD_REBOOT": {"active", 1},
+	"SHUTOFF":     {"stopped", 4},
+}
+
+func (c *Cloud) serverView(s *server) map[string]interface{} {
+	addresses := make(map[string][]map[string]interface{})
+	if s.status != "BUILD" {
+		for _, id := range s.ports {
+			item, ok := c.ports.get(id)
+			if !ok {
+				continue
+			}
+			p := item.(*port)
+			n := c.mustNetwork(p.networkID)
+			for _, ip := range p.fixedIPs {
+				version := 6
+				if net.ParseIP(ip.IPAddress).To4() != nil {
+					version = 4
+				}
+				addresses[n.name] = append(addresses[n.name], map[string]interface{}{
+					"addr":                    ip.IPAddress,
+					"version":                 version,
+					"OS-EXT-IPS:type":         "fixed",
+					"OS-EXT-IPS-MAC:mac_addr": p.macAddress,
+				})
+			}
+		}
+	}
+
+	volumes := []map[string]interface{}{}
+	for _, id := range s.volumes {
+		volumes = append(volumes, map[string]interface{}{
+			"id":                    id,
+			"delete_on_termination": false,
+		})
+	}
+
+	securityGroups := []map[string]string{}
+	for _, name := range s.securityGroups {
+		securityGroups = append(securityGroups, map[string]string{"name": name})
+	}
+
+	var keyName, task, launchedAt interface{}
+	if s.keyName != "" {
+		keyName = s.keyName
+	}
+	if s.busy() {
+		task = s.task
+	}
+	if !s.launchedAt.IsZero() {
+		launchedAt = s.launchedAt.Format(microTimeLayout)
+	}
+
+	progress := 100
+	if s.status == "BUILD" {
+		progress = 0
+	}
+
+	state := vmStates[s.status]
+	return map[string]interface{}{
+		"id":         s.id,
+		"name":       s.name,
+		"status":     s.status,
+		"tenant_id":  ProjectID,
+		"user_id":    UserID,
+		"hostId":     "",
+		"created":    s.createdAt.Format(timeLayout),
+		"updated":    s.updatedAt.Format(timeLayout),
+		"progress":   progress,
+		"accessIPv4": "",
+		"accessIPv6": "",
+		"image": map[string]interface{}{
+			"id":    s.imageID,
+			"links": []map[string]string{{"rel": "bookmark", "href": c.server.URL + "/compute/images/" + s.imageID}},
+		},
+		"flavor": map[string]interface{}{
+			"id":    s.flavorID,
+			"links": []map[string]string{{"rel": "bookmark", "href": c.server.URL + "/compute/flavors/" + s.flavorID}},
+		},
+		"addresses":                            addresses,
+		"metadata":                             s.metadata,
+		"links":                                c.computeLinks("servers", s.id),
+		"key_name":                             keyName,
+		"security_groups":                      securityGroups,
+		"config_drive":                         "",
+		"OS-DCF:diskConfig":                    "MANUAL",
+		"OS-EXT-AZ:availability_zone":          s.availabilityZone,
+		"OS-EXT-STS:task_state":                task,
+		"OS-EXT-STS:vm_state":                  state.vmState,
+		"OS-EXT-STS:power_state":               state.powerState,
+		"OS-SRV-USG:launched_at":               launchedAt,
+		"OS-SRV-USG:terminated_at":             nil,
+		"os-extended-volumes:volumes_attached": volumes,
+	}
+}
+
+func (c *Cloud) serveCompute(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] != "v2.1" {
+		computeFault(w, http.StatusNotFound, "The resource could not be found.")
+		return
+	}
+	parts = parts[1:]
+
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"version": map[string]interface{}{
+				"id":          "v2.1",
+				"status":      "CURRENT",
+				"version":     "2.79",
+				"min_version": "2.1",
+				"updated":     "2013-07-23T11:33:21Z",
+				"links":       []map[string]string{{"rel": "self", "href": c.server.URL + "/compute/v2.1/"}},
+			},
+		})
+	case len(parts) >= 1 && parts[0] == "flavors":
+		c.serveFlavors(w, r, parts[1:])
+	case len(parts) >= 1 && parts[0] == "servers":
+		c.serveServers(w, r, parts[1:])
+	default:
+		computeFault(w, http.StatusNotFound, "The resource could not be found.")
+	}
+}
+
+func (c *Cloud) serveFlavors(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != "GET" || len(parts) > 1 {
+		computeFault(w, http.StatusNotFound, "The resource could not be found.")
+		return
+	}
+
+	if len(parts) == 1 && parts[0] != "detail" {
+		item, ok := c.flavors.get(parts[0])
+		if !ok {
+			computeFault(w, http.StatusNotFound, fmt.Sprintf("Flavor %s could not be found.", parts[0]))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"flavor": c.flavorView(item.(*flavor))})
+		return
+	}
+	detail := len(parts) == 1
+
+	q := r.URL.Query()
+	minRAM, _ := strconv.Atoi(q.Get("minRam"))
+	minDisk, _ := strconv.Atoi(q.Get("minDisk"))
+
+	var views []interface{}
+	for _, item := range c.flavors.all() {
+		f := item.(*flavor)
+		if f.RAM < minRAM || f.Disk < minDisk {
+			continue
+		}
+		view := c.flavorView(f)
+		if !detail {
+			view = map[string]interface{}{"id": f.ID, "name": f.Name, "links": view["links"]}
+		}
+		views = append(views, view)
+	}
+
+	c.writeList(w, r, listQuery{
+		key:      "flavors",
+		sortKeys: computeSortKeys(pairedSortKeys(q)),
+		fault:    computeFault,
+	}, views)
+}
+
+// computeSortAttributes maps the sort keys of the Compute API to the
+// attributes they sort by.
+var computeSortAttributes = map[string]string{
+	"flavorid":     "id",
+	"memory_mb":    "ram",
+	"root_gb":      "disk",
+	"uuid":         "id",
+	"display_name": "name",
+	"created_at":   "created",
+	"updated_at":   "updated",
+	"vm_state":     "OS-EXT-STS:vm_state",
+	"task_state":   "OS-EXT-STS:task_state",
+}
+
+func computeSortKeys(keys []sortKey) []sortKey {
+	for i, k := range keys {
+		if attr, ok := computeSortAttributes[k.key]; ok {
+			keys[i].key = attr
+		}
+	}
+	return keys
+}
+
+func (c *Cloud) serveServers(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		c.listServers(w, r, false)
+	case len(parts) == 0 && r.Method == "POST":
+		c.createServer(w, r)
+	case len(parts) == 1 && parts[0] == "detail" && r.Method == "GET":
+		c.listServers(w, r, true)
+	case len(parts) >= 1:
+		s, ok := c.readServer(parts[0], r.Method == "GET")
+		if !ok {
+			computeFault(w, http.StatusNotFound, fmt.Sprintf("Instance %s could not be found.", parts[0]))
+			return
+		}
+		switch {
+		case len(parts) == 1:
+			c.serveServer(w, r, s)
+		case len(parts) == 2 && parts[1] == "action" && r.Method == "POST":
+			c.serverAction(w, r, s)
+		case len(parts) >= 2 && parts[1] == "os-volume_attachments":
+			c.serveVolumeAttachments(w, r, s, parts[2:])
+		default:
+			computeFault(w, http.StatusNotFound, "The resource could not be found.")
+		}
+	default:
+		computeFault(w, http.StatusNotFound, "The resource could not be found.")
+	}
+}
+
+// readServer returns a server, counting a read of it if read is set. A
+// server that is deleted once it's read isn't found.
+func (c *Cloud) readServer(id string, read bool) (*server, bool) {
+	item, ok := c.servers.get(id)
+	if !ok {
+		return nil, false
+	}
+	s := item.(*server)
+	if read {
+		s.read()
+		if _, ok := c.servers.get(id); !ok {
+			return nil, false
+		}
+	}
+	return s, true
+}
+
+func (c *Cloud) listServers(w http.ResponseWriter, r *http.Request, detail bool) {
+	q := r.URL.Query()
+
+	var name *regexp.Regexp
+	if s := q.Get("name"); s != "" {
+		var err error
+		if name, err = regexp.Compile(s); err != nil {
+			computeFault(w, http.StatusBadRequest, fmt.Sprintf("Invalid filter name: %s", err))
+			return
+		}
+	}
+
+	items := c.servers.all()
+	for _, item := range items {
+		item.(*server).read()
+	}
+
+	// Servers are listed from the newest to the oldest.
+	var views []interface{}
+	for i := len(items) - 1; i >= 0; i-- {
+		s := items[i].(*server)
+		if _, ok := c.servers.get(s.id); !ok {
+			continue
+		}
+		if name != nil && !name.MatchString(s.name) {
+			continue
+		}
+		if v := q.Get("image"); v != "" && v != s.imageID {
+			continue
+		}
+		if v := q.Get("flavor"); v != "" && v != s.flavorID {
+			continue
+		}
+
+		view := c.serverView(s)
+		if !detail {
+			view = map[string]interface{}{"id": s.id, "name": s.name, "links": view["links"]}
+		}
+		views = append(views, view)
+	}
+
+	lq := listQuery{
+		key:      "servers",
+		sortKeys: computeSortKeys(pairedSortKeys(q)),
+		fault:    computeFault,
+	}
+	if detail {
+		lq.filters = map[string]string{"status": "status"}
+	}
+	c.writeList(w, r, lq, views)
+}
+
+type serverRequest struct {
+	Server *struct {
+		Name             string            `json:"name"`
+		ImageRef         string            `json:"imageRef"`
+		FlavorRef        string            `json:"flavorRef"`
+		Networks         json.RawMessage   `json:"networks"`
+		Metadata         map[string]string `json:"metadata"`
+		KeyName          string            `json:"key_name"`
+		AdminPass        string            `json:"adminPass"`
+		AvailabilityZone string            `json:"availability_zone"`
+		SecurityGroups   []struct {
+			Name string `json:"name"`
+		} `json:"security_groups"`
+		BlockDevices []interface{} `json:"block_device_mapping_v2"`
+	} `json:"server"`
+}
+
+type serverNetwork struct {
+	UUID    string `json:"uuid"`
+	Port    string `json:"port"`
+	FixedIP string `json:"fixed_ip"`
+}
+
+func (c *Cloud) createServer(w http.ResponseWriter, r *http.Request) {
+	var req serverRequest
+	if !readJSON(w, r, &req, computeFault) {
+		return
+	}
+	opts := req.Server
+	if opts == nil {
+		computeFault(w, http.StatusBadRequest, "Invalid input for field/attribute server.")
+		return
+	}
+	if opts.Name == "" {
+		computeFault(w, http.StatusBadRequest, "Invalid input for field/attribute name.")
+		return
+	}
+	if opts.ImageRef == "" && len(opts.BlockDevices) == 0 {
+		computeFault(w, http.StatusBadRequest, "Block Device Mapping is Invalid: You specified more local devices than the limit allows")
+		return
+	}
+	if _, ok := c.flavors.get(opts.FlavorRef); !ok {
+		computeFault(w, http.StatusBadRequest, fmt.Sprintf("Flavor %s could not be found.", opts.FlavorRef))
+		return
+	}
+
+	networks, f := c.serverNetworks(opts.Networks)
+	if f != nil {
+		computeFault(w, f.code, f.message)
+		return
+	}
+
+	t := now()
+	s := &server{
+		id:               newID(),
+		name:             opts.Name,
+		imageID:          opts.ImageRef,
+		flavorID:         opts.FlavorRef,
+		keyName:          opts.KeyName,
+		adminPass:        opts.AdminPass,
+		metadata:         opts.Metadata,
+		securityGroups:   []string{},
+		availabilityZone: opts.AvailabilityZone,
+		createdAt:        t,
+		updatedAt:        t,
+		ownPorts:         make(map[string]bool),
+	}
+	if s.metadata == nil {
+		s.metadata = map[string]string{}
+	}
+	if s.adminPass == "" {
+		s.adminPass = newID()[:12]
+	}
+	if s.availabilityZone == "" {
+		s.availabilityZone = "nova"
+	}
+	for _, sg := range opts.SecurityGroups {
+		s.securityGroups = append(s.securityGroups, sg.Name)
+	}
+	if len(s.securityGroups) == 0 {
+		s.securityGroups = append(s.securityGroups, "default")
+	}
+
+	if f := c.attachNetworks(s, networks); f != nil {
+		computeFault(w, f.code, f.message)
+		return
+	}
+
+	c.servers.add(s.id, s)
+	s.task = "spawning"
+	c.transition(&s.lifecycle, "BUILD", "ACTIVE", func() {
+		s.launchedAt = now()
+		s.updatedAt = s.launchedAt
+		c.setPortsStatus(s, "ACTIVE")
+	})
+
+	securityGroups := []map[string]string{}
+	for _, name := range s.securityGroups {
+		securityGroups = append(securityGroups, map[string]string{"name": name})
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"server": map[string]interface{}{
+			"id":                s.id,
+			"links":             c.computeLinks("servers", s.id),
+			"adminPass":         s.adminPass,
+			"security_groups":   securityGroups,
+			"OS-DCF:diskConfig": "MANUAL",
+		},
+	})
+}
+
+// serverNetworks returns the networks that a new server is attached to. If
+// the request doesn't name any, or asks for "auto", the server is attached
+// to the only network of the project, if there is one.
+func (c *Cloud) serverNetworks(raw json.RawMessage) ([]serverNetwork, *fault) {
+	var auto string
+	if len(raw) > 0 && json.Unmarshal(raw, &auto) == nil {
+		if auto == "none" {
+			return nil, nil
+		}
+		if auto != "auto" {
+			return nil, &fault{code: http.StatusBadRequest, message: fmt.Sprintf("Invalid input for field/attribute networks. Value: %s.", auto)}
+		}
+		raw = nil
+	}
+
+	if len(raw) == 0 || string(raw) == "null" {
+		ids := c.networks.all()
+		switch len(ids) {
+		case 0:
+			return nil, nil
+		case 1:
+			return []serverNetwork{{UUID: ids[0].(*network).id}}, nil
+		default:
+			return nil, &fault{code: http.StatusConflict, message: "Multiple possible networks found, use a Network ID to be more specific."}
+		}
+	}
+
+	var networks []serverNetwork
+	if err := json.Unmarshal(raw, &networks); err != nil {
+		return nil, &fault{code: http.StatusBadRequest, message: fmt.Sprintf("Invalid input for field/attribute networks: %s", err)}
+	}
+	for _, n := range networks {
+		switch {
+		case n.Port != "":
+			item, ok := c.ports.get(n.Port)
+			if !ok {
+				return nil, &fault{code: http.StatusBadRequest, message: fmt.Sprintf("Port id %s could not be found.", n.Port)}
+			}
+			if item.(*port).deviceID != "" {
+				return nil, &fault{code: http.StatusConflict, message: fmt.Sprintf("Port %s is still in use.", n.Port)}
+			}
+		case n.UUID != "":
+			if _, ok := c.networks.get(n.UUID); !ok {
+				return nil, &fault{code: http.StatusBadRequest, message: fmt.Sprintf("Network %s could not be found.", n.UUID)}
+			}
+		default:
+			return nil, &fault{code: http.StatusBadRequest, message: "Bad networks format: network uuid is not in proper format"}
+		}
+	}
+	return networks, nil
+}
+
+// attachNetworks binds s to the ports of networks, creating those that the
+// request doesn't name. Nothing is created if an address can't be
+// allocated.
+func (c *Cloud) attachNetworks(s *server, networks []serverNetwork) *fault {
+	owner := "compute:" + s.availabilityZone
+	for _, n := range networks {
+		var p *port
+		if n.Port != "" {
+			item, _ := c.ports.get(n.Port)
+			p = item.(*port)
+		} else {
+			opts := &portOpts{NetworkID: n.UUID}
+			if n.FixedIP != "" {
+				opts.FixedIPs = []fixedIP{{IPAddress: n.FixedIP}}
+			}
+			var f *fault
+			if p, f = c.newPort(opts); f != nil {
+				c.detachPorts(s)
+				return f
+			}
+			s.ownPorts[p.id] = true
+		}
+		p.deviceID = s.id
+		p.deviceOwner = owner
+		s.ports = append(s.ports, p.id)
+	}
+	return nil
+}
+
+// detachPorts unbinds the ports of s, deleting those created for it.
+func (c *Cloud) detachPorts(s *server) {
+	for _, id := range s.ports {
+		item, ok := c.ports.get(id)
+		if !ok {
+			continue
+		}
+		p := item.(*port)
+		if s.ownPorts[id] {
+			c.ports.remove(id)
+			continue
+		}
+		p.deviceID = ""
+		p.deviceOwner = ""
+		p.status = "DOWN"
+	}
+	s.ports = nil
+}
+
+func (c *Cloud) setPortsStatus(s *server, status string) {
+	for _, id := range s.ports {
+		if item, ok := c.ports.get(id); ok {
+			item.(*port).status = status
+		}
+	}
+}
+
+func (c *Cloud) serveServer(w http.ResponseWriter, r *http.Request, s *server) {
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"server": c.serverView(s)})
+	case "PUT":
+		var req struct {
+			Server *struct {
+				Name *string `json:"name"`
+			} `json:"server"`
+		}
+		if !readJSON(w, r, &req, computeFault) {
+			return
+		}
+		if req.Server == nil {
+			computeFault(w, http.StatusBadRequest, "Invalid input for field/attribute server.")
+			return
+		}
+		if v := req.Server.Name; v != nil {
+			s.name = *v
+		}
+		s.updatedAt = now()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"server": c.serverView(s)})
+	case "DELETE":
+		if s.task != "deleting" || !s.busy() {
+			s.task = "deleting"
+			c.transition(&s.lifecycle, s.status, "DELETED", func() {
+				c.destroyServer(s)
+			})
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		computeFault(w, http.StatusMethodNotAllowed, "The method is not allowed for the requested URL.")
+	}
+}
+
+// destroyServer removes s, releasing its ports and volumes.
+func (c *Cloud) destroyServer(s *server) {
+	c.detachPorts(s)
+	for _, id := range s.volumes {
+		if item, ok := c.volumes.get(id); ok {
+			v := item.(*volume)
+			v.lifecycle = lifecycle{status: "available"}
+			v.attachments = nil
+		}
+	}
+	s.volumes = nil
+	c.servers.remove(s.id)
+}
+
+func (c *Cloud) serverAction(w http.ResponseWriter, r *http.Request, s *server) {
+	var req map[string]json.RawMessage
+	if !readJSON(w, r, &req, computeFault) {
+		return
+	}
+	if len(req) != 1 {
+		computeFault(w, http.StatusBadRequest, "Malformed request body")
+		return
+	}
+
+	var action string
+	var body json.RawMessage
+	for action, body = range req {
+	}
+
+	// allowed reports whether the server may take the action, writing a
+	// conflict if it may not.
+	allowed := func(statuses ...string) bool {
+		if s.busy() {
+			computeFault(w, http.StatusConflict, fmt.Sprintf("Cannot '%s' instance %s while it is in task_state %s", action, s.id, s.task))
+			return false
+		}
+		for _, status := range statuses {
+			if s.status == status {
+				return true
+			}
+		}
+		computeFault(w, http.StatusConflict, fmt.Sprintf("Cannot '%s' instance %s while it is in vm_state %s", action, s.id, vmStates[s.status].vmState))
+		return false
+	}
+
+	switch action {
+	case "os-stop":
+		if !allowed("ACTIVE") {
+			return
+		}
+		s.task = "powering-off"
+		c.transition(&s.lifecycle, "ACTIVE", "SHUTOFF", func() {
+			c.setPortsStatus(s, "DOWN")
+		})
+	case "os-start":
+		if !allowed("SHUTOFF") {
+			return
+		}
+		s.task = "powering-on"
+		c.transition(&s.lifecycle, "SHUTOFF", "ACTIVE", func() {
+			c.setPortsStatus(s, "ACTIVE")
+		})
+	case "reboot":
+		var opts struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(body, &opts); err != nil || (opts.Type != "SOFT" && opts.Type != "HARD") {
+			computeFault(w, http.StatusBadRequest, "Invalid input for field/attribute type.")
+			return
+		}
+		if opts.Type == "SOFT" {
+			if !allowed("ACTIVE") {
+				return
+			}
+			s.task = "rebooting"
+			c.transition(&s.lifecycle, "REBOOT", "ACTIVE", nil)
+		} else {
+			if !allowed("ACTIVE", "SHUTOFF") {
+				return
+			}
+			s.task = "rebooting_hard"
+			c.transition(&s.lifecycle, "HARD_REBOOT", "ACTIVE", func() {
+				c.setPortsStatus(s, "ACTIVE")
+			})
+		}
+	default:
+		computeFault(w, http.StatusBadRequest, fmt.Sprintf("There is no such action: %s", action))
+		return
+	}
+	s.updatedAt = now()
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (c *Cloud) volumeAttachmentView(s *server, v *volume) map[string]interface{} {
+	view := map[string]interface{}{
+		"id":       v.id,
+		"volumeId": v.id,
+		"serverId": s.id,
+	}
+	for _, a := range v.attachments {
+		if a.serverID == s.id {
+			view["device"] = a.device
+		}
+	}
+	return view
+}
+
+func (c *Cloud) serveVolumeAttachments(w http.ResponseWriter, r *http.Request, s *server, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		attachments := []interface{}{}
+		for _, id := range s.volumes {
+			attachments = append(attachments, c.volumeAttachmentView(s, c.mustVolume(id)))
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"volumeAttachments": attachments})
+	case len(parts) == 0 && r.Method == "POST":
+		c.attachVolume(w, r, s)
+	case len(parts) == 1:
+		var v *volume
+		for _, id := range s.volumes {
+			if id == parts[0] {
+				v = c.mustVolume(id)
+			}
+		}
+		if v == nil {
+			computeFault(w, http.StatusNotFound, fmt.Sprintf("Volume %s is not attached to the instance %s.", parts[0], s.id))
+			return
+		}
+		switch r.Method {
+		case "GET":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"volumeAttachment": c.volumeAttachmentView(s, v)})
+		case "DELETE":
+			if v.busy() || v.status != "in-use" {
+				computeFault(w, http.StatusBadRequest, fmt.Sprintf("Invalid volume: Volume %s status must be in-use to detach. Currently in '%s'", v.id, v.status))
+				return
+			}
+			for i, id := range s.volumes {
+				if id == v.id {
+					s.volumes = append(s.volumes[:i:i], s.volumes[i+1:]...)
+					break
+				}
+			}
+			c.transition(&v.lifecycle, "detaching", "available", func() {
+				v.attachments = nil
+			})
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			computeFault(w, http.StatusMethodNotAllowed, "The method is not allowed for the requested URL.")
+		}
+	default:
+		computeFault(w, http.StatusNotFound, "The resource could not be found.")
+	}
+}
+
+func (c *Cloud) attachVolume(w http.ResponseWriter, r *http.Request, s *server) {
+	var req struct {
+		VolumeAttachment *struct {
+			VolumeID string `json:"volumeId"`
+			Device   string `json:"device"`
+		} `json:"volumeAttachment"`
+	}
+	if !readJSON(w, r, &req, computeFault) {
+		return
+	}
+	if req.VolumeAttachment == nil {
+		computeFault(w, http.StatusBadRequest, "Invalid input for field/attribute volumeAttachment.")
+		return
+	}
+
+	if s.busy() || (s.status != "ACTIVE" && s.status != "SHUTOFF") {
+		computeFault(w, http.StatusConflict, fmt.Sprintf("Cannot 'attach_volume' instance %s while it is in vm_state %s", s.id, vmStates[s.status].vmState))
+		return
+	}
+
+	id := req.VolumeAttachment.VolumeID
+	item, ok := c.volumes.get(id)
+	if !ok {
+		computeFault(w, http.StatusNotFound, fmt.Sprintf("Volume %s could not be found.", id))
+		return
+	}
+	v := item.(*volume)
+	if v.busy() || v.status != "available" {
+		computeFault(w, http.StatusBadRequest, fmt.Sprintf("Invalid volume: volume %s status must be 'available'. Currently in '%s'", v.id, v.status))
+		return
+	}
+
+	device := req.VolumeAttachment.Device
+	if device == "" {
+		device = fmt.Sprintf("/dev/vd%c", 'b'+len(s.volumes))
+	}
+
+	s.volumes = append(s.volumes, v.id)
+	v.attachments = []volumeAttachment{{
+		id:         newID(),
+		serverID:   s.id,
+		device:     device,
+		attachedAt: now(),
+	}}
+	c.transition(&v.lifecycle, "attaching", "in-use", nil)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volumeAttachment": c.volumeAttachmentView(s, v)})
+}
@@ -0,0 +1,81 @@
+/*
+Package fakecloud provides an OpenStack cloud that keeps its resources in
+memory, for unit tests of workflows that span several requests.
+
+Unlike the fixtures of the testhelper package, which answer each URL with
+canned JSON, a Cloud remembers what it's asked to do: a port created on a
+network shows up in a List of ports, a server booted on that port shows its
+address, and deleting the server releases the port.
+
+A Cloud serves the following APIs, with a catalog that points at itself:
+
+	identity       Identity v3 tokens, with the password and token methods
+	compute        Compute v2.1 servers, flavors and volume attachments
+	network        Networking v2.0 networks, subnets and ports
+	volumev3       Block Storage v3 volumes
+	object-store   Object Storage v1 containers and objects
+
+It has one user, which is a member of one project, in one region. Lists are
+paged with limit and marker, and can be filtered and sorted by the common
+query parameters of each service.
+
+Servers and volumes move through transitional statuses, such as BUILD and
+creating, which they leave when they're next read. Set TransitionReads to
+keep them there for longer.
+
+Example to Boot a Server on a New Network
+
+	cloud := fakecloud.New()
+	defer cloud.Close()
+
+	provider, err := openstack.AuthenticatedClient(cloud.AuthOptions())
+	if err != nil {
+		panic(err)
+	}
+
+	networkClient, err := openstack.NewNetworkV2(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+	network, err := networks.Create(networkClient, networks.CreateOpts{Name: "private"}).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	subnetOpts := subnets.CreateOpts{
+		NetworkID: network.ID,
+		CIDR:      "10.0.0.0/24",
+		IPVersion: gophercloud.IPv4,
+	}
+
+	if _, err := subnets.Create(networkClient, subnetOpts).Extract(); err != nil {
+		panic(err)
+	}
+
+	computeClient, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+	createOpts := servers.CreateOpts{
+		Name:      "server_name",
+		ImageRef:  "image-uuid",
+		FlavorRef: "1",
+		Networks:  []servers.Network{{UUID: network.ID}},
+	}
+
+	server, err := servers.Create(computeClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	if err := servers.WaitForStatus(computeClient, server.ID, "ACTIVE", 60); err != nil {
+		panic(err)
+	}
+
+Example to Expire the Tokens of a Client
+
+	cloud.RevokeTokens()
+*/
+package fakecloud
@@ -0,0 +1,267 @@
+package fakecloud
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// token is a token issued by the Identity service.
+type token struct {
+	id        string
+	methods   []string
+	scoped    bool
+	issuedAt  time.Time
+	expiresAt time.Time
+	auditID   string
+}
+
+// RevokeTokens revokes every token that the Cloud has issued, so that the
+// clients holding them have to authenticate again.
+func (c *Cloud) RevokeTokens() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens = make(map[string]*token)
+}
+
+// authorized reports whether r holds a valid token scoped to the project.
+func (c *Cloud) authorized(r *http.Request) bool {
+	t, ok := c.validToken(r.Header.Get("X-Auth-Token"))
+	return ok && t.scoped
+}
+
+func (c *Cloud) validToken(id string) (*token, bool) {
+	t, ok := c.tokens[id]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(t.expiresAt) {
+		delete(c.tokens, id)
+		return nil, false
+	}
+	return t, true
+}
+
+func identityFault(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"title":   http.StatusText(code),
+			"message": message,
+		},
+	})
+}
+
+func (c *Cloud) serveIdentity(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		writeJSON(w, http.StatusMultipleChoices, map[string]interface{}{
+			"versions": map[string]interface{}{
+				"values": []interface{}{c.identityVersion()},
+			},
+		})
+	case len(parts) == 1 && parts[0] == "v3" && r.Method == "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"version": c.identityVersion(),
+		})
+	case len(parts) == 3 && parts[0] == "v3" && parts[1] == "auth" && parts[2] == "tokens":
+		switch r.Method {
+		case "POST":
+			c.createToken(w, r)
+		case "GET", "HEAD":
+			t, ok := c.validToken(r.Header.Get("X-Subject-Token"))
+			if _, authorized := c.validToken(r.Header.Get("X-Auth-Token")); !authorized || !ok {
+				identityFault(w, http.StatusNotFound, "Could not find token.")
+				return
+			}
+			w.Header().Set("X-Subject-Token", t.id)
+			if r.Method == "HEAD" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			writeJSON(w, http.StatusOK, c.tokenBody(t))
+		case "DELETE":
+			t, ok := c.validToken(r.Header.Get("X-Subject-Token"))
+			if _, authorized := c.validToken(r.Header.Get("X-Auth-Token")); !authorized || !ok {
+				identityFault(w, http.StatusNotFound, "Could not find token.")
+				return
+			}
+			delete(c.tokens, t.id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			identityFault(w, http.StatusMethodNotAllowed, "The method is not allowed for the requested URL.")
+		}
+	default:
+		identityFault(w, http.StatusNotFound, "The resource could not be found.")
+	}
+}
+
+func (c *Cloud) identityVersion() map[string]interface{} {
+	return map[string]interface{}{
+		"id":      "v3.14",
+		"status":  "stable",
+		"updated": "2020-04-07T00:00:00Z",
+		"links": []map[string]string{
+			{"rel": "self", "href": c.IdentityEndpoint()},
+		},
+	}
+}
+
+// authRequest is the body of a request for a token, with the methods this
+// Cloud supports.
+type authRequest struct {
+	Auth struct {
+		Identity struct {
+			Methods  []string `json:"methods"`
+			Password struct {
+				User struct {
+					ID       string     `json:"id"`
+					Name     string     `json:"name"`
+					Password string     `json:"password"`
+					Domain   *authScope `json:"domain"`
+				} `json:"user"`
+			} `json:"password"`
+			Token struct {
+				ID string `json:"id"`
+			} `json:"token"`
+		} `json:"identity"`
+		Scope *struct {
+			Project *struct {
+				ID     string     `json:"id"`
+				Name   string     `json:"name"`
+				Domain *authScope `json:"domain"`
+			} `json:"project"`
+		} `json:"scope"`
+	} `json:"auth"`
+}
+
+type authScope struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// isDefaultDomain reports whether s names the domain of the user and the
+// project.
+func (s *authScope) isDefaultDomain() bool {
+	return s != nil && (s.ID == DomainID || (s.ID == "" && s.Name == DomainName))
+}
+
+func (c *Cloud) createToken(w http.ResponseWriter, r *http.Request) {
+	var req authRequest
+	if !readJSON(w, r, &req, identityFault) {
+		return
+	}
+	identity := req.Auth.Identity
+
+	if len(identity.Methods) == 0 {
+		identityFault(w, http.StatusBadRequest, "Expecting to find methods in identity.")
+		return
+	}
+	for _, method := range identity.Methods {
+		switch method {
+		case "password":
+			u := identity.Password.User
+			validUser := u.ID == UserID || (u.ID == "" && u.Name == Username && u.Domain.isDefaultDomain())
+			if !validUser || u.Password != Password {
+				identityFault(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+				return
+			}
+		case "token":
+			if _, ok := c.validToken(identity.Token.ID); !ok {
+				identityFault(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+				return
+			}
+		default:
+			identityFault(w, http.StatusUnauthorized, "Attempted to authenticate with an unsupported method.")
+			return
+		}
+	}
+
+	scoped := false
+	if s := req.Auth.Scope; s != nil {
+		p := s.Project
+		if p == nil || !(p.ID == ProjectID || (p.ID == "" && p.Name == ProjectName && p.Domain.isDefaultDomain())) {
+			identityFault(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+			return
+		}
+		scoped = true
+	}
+
+	issuedAt := now()
+	t := &token{
+		id:        strings.Replace(newID(), "-", "", -1),
+		methods:   identity.Methods,
+		scoped:    scoped,
+		issuedAt:  issuedAt,
+		expiresAt: issuedAt.Add(c.TokenLifetime),
+		auditID:   newID()[:22],
+	}
+	c.tokens[t.id] = t
+
+	w.Header().Set("X-Subject-Token", t.id)
+	writeJSON(w, http.StatusCreated, c.tokenBody(t))
+}
+
+func (c *Cloud) tokenBody(t *token) map[string]interface{} {
+	domain := map[string]string{"id": DomainID, "name": DomainName}
+	body := map[string]interface{}{
+		"methods":    t.methods,
+		"issued_at":  t.issuedAt.Format(microTimeLayout) + "Z",
+		"expires_at": t.expiresAt.Format(microTimeLayout) + "Z",
+		"audit_ids":  []string{t.auditID},
+		"user": map[string]interface{}{
+			"id":                  UserID,
+			"name":                Username,
+			"domain":              domain,
+			"password_expires_at": nil,
+		},
+	}
+	if t.scoped {
+		body["project"] = map[string]interface{}{
+			"id":     ProjectID,
+			"name":   ProjectName,
+			"domain": domain,
+		}
+		body["roles"] = []map[string]string{
+			{"id": "9fe2ff9ee4384b1894a90878d3e92bab", "name": "member"},
+			{"id": "2ab6b6e0b7f94b54a4c8df27c4f1a9f4", "name": "reader"},
+		}
+		body["catalog"] = c.catalog()
+	}
+	return map[string]interface{}{"token": body}
+}
+
+// catalog returns the services of the Cloud, each with a public, internal and
+// admin endpoint.
+func (c *Cloud) catalog() []interface{} {
+	services := []struct {
+		id, name, typ, path string
+	}{
+		{"b3b1d7e0e1f64a6f8dbf0c2ac1a8e4b1", "keystone", "identity", "/identity"},
+		{"4b4d8a3b3d8d4b8a9f4a8d3c1c2e5f61", "nova", "compute", "/compute/v2.1"},
+		{"6c1b9a0a2b2f4c2a8d2e9b6f8a1c3d72", "neutron", "network", "/network"},
+		{"8d2c0b1b3c3a4d3b9e3f0c7a9b2d4e83", "cinderv3", "volumev3", "/volume/v3/" + ProjectID},
+		{"9e3d1c2c4d4b4e4cae4a1d8bac3e5f94", "swift", "object-store", "/object-store/v1/AUTH_" + ProjectID},
+	}
+
+	var catalog []interface{}
+	for _, s := range services {
+		var endpoints []interface{}
+		for _, iface := range []string{"public", "internal", "admin"} {
+			endpoints = append(endpoints, map[string]string{
+				"id":        s.id[:24] + iface[:3] + "00000",
+				"interface": iface,
+				"region":    Region,
+				"region_id": Region,
+				"url":       c.server.URL + s.path,
+			})
+		}
+		catalog = append(catalog, map[string]interface{}{
+			"id":        s.id,
+			"name":      s.name,
+			"type":      s.typ,
+			"endpoints": endpoints,
+		})
+	}
+	return catalog
+}
@@ -0,0 +1,981 @@
+package fakecloud
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// fault is an error that a request fails with. Each service renders it in
+// its own format.
+type fault struct {
+	code int
+
+	// typ is the type of the error in the Networking API, such as
+	// "NetworkNotFound".
+	typ     string
+	message string
+}
+
+func (f *fault) Error() string {
+	return f.message
+}
+
+func networkFault(w http.ResponseWriter, code int, typ, message string) {
+	writeJSON(w, code, map[string]interface{}{
+		"NeutronError": map[string]interface{}{
+			"type":    typ,
+			"message": message,
+			"detail":  "",
+		},
+	})
+}
+
+func badNetworkRequest(w http.ResponseWriter, code int, message string) {
+	networkFault(w, code, "HTTPBadRequest", message)
+}
+
+func writeNetworkFault(w http.ResponseWriter, f *fault) {
+	networkFault(w, f.code, f.typ, f.message)
+}
+
+type network struct {
+	id           string
+	name         string
+	description  string
+	adminStateUp bool
+	shared       bool
+	subnets      []string
+	createdAt    time.Time
+	updatedAt    time.Time
+	revision     int
+}
+
+type networkView struct {
+	ID                    string   `json:"id"`
+	Name                  string   `json:"name"`
+	Description           string   `json:"description"`
+	AdminStateUp          bool     `json:"admin_state_up"`
+	Status                string   `json:"status"`
+	Subnets               []string `json:"subnets"`
+	TenantID              string   `json:"tenant_id"`
+	ProjectID             string   `json:"project_id"`
+	Shared                bool     `json:"shared"`
+	External              bool     `json:"router:external"`
+	MTU                   int      `json:"mtu"`
+	AvailabilityZoneHints []string `json:"availability_zone_hints"`
+	Tags                  []string `json:"tags"`
+	CreatedAt             string   `json:"created_at"`
+	UpdatedAt             string   `json:"updated_at"`
+	RevisionNumber        int      `json:"revision_number"`
+}
+
+func (n *network) view() networkView {
+	return networkView{
+		ID:                    n.id,
+		Name:                  n.name,
+		Description:           n.description,
+		AdminStateUp:          n.adminStateUp,
+		Status:                "ACTIVE",
+		Subnets:               append([]string{}, n.subnets...),
+		TenantID:              ProjectID,
+		ProjectID:             ProjectID,
+		Shared:                n.shared,
+		MTU:                   1450,
+		AvailabilityZoneHints: []string{},
+		Tags:                  []string{},
+		CreatedAt:             n.createdAt.Format(timeLayout),
+		UpdatedAt:             n.updatedAt.Format(timeLayout),
+		RevisionNumber:        n.revision,
+	}
+}
+
+type subnet struct {
+	id              string
+	networkID       string
+	name            string
+	description     string
+	ipVersion       int
+	cidr            *net.IPNet
+	gatewayIP       net.IP
+	enableDHCP      bool
+	dnsNameservers  []string
+	allocationPools []allocationPool
+	hostRoutes      []hostRoute
+	createdAt       time.Time
+	updatedAt       time.Time
+	revision        int
+}
+
+type allocationPool struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type hostRoute struct {
+	Destination string `json:"destination"`
+	NextHop     string `json:"nexthop"`
+}
+
+type subnetView struct {
+	ID              string           `json:"id"`
+	NetworkID       string           `json:"network_id"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	IPVersion       int              `json:"ip_version"`
+	CIDR            string           `json:"cidr"`
+	GatewayIP       *string          `json:"gateway_ip"`
+	EnableDHCP      bool             `json:"enable_dhcp"`
+	DNSNameservers  []string         `json:"dns_nameservers"`
+	AllocationPools []allocationPool `json:"allocation_pools"`
+	HostRoutes      []hostRoute      `json:"host_routes"`
+	IPv6AddressMode *string          `json:"ipv6_address_mode"`
+	IPv6RAMode      *string          `json:"ipv6_ra_mode"`
+	SubnetPoolID    *string          `json:"subnetpool_id"`
+	TenantID        string           `json:"tenant_id"`
+	ProjectID       string           `json:"project_id"`
+	Tags            []string         `json:"tags"`
+	CreatedAt       string           `json:"created_at"`
+	UpdatedAt       string           `json:"updated_at"`
+	RevisionNumber  int              `json:"revision_number"`
+}
+
+func (s *subnet) view() subnetView {
+	v := subnetView{
+		ID:              s.id,
+		NetworkID:       s.networkID,
+		Name:            s.name,
+		Description:     s.description,
+		IPVersion:       s.ipVersion,
+		CIDR:            s.cidr.String(),
+		EnableDHCP:      s.enableDHCP,
+		DNSNameservers:  append([]string{}, s.dnsNameservers...),
+		AllocationPools: append([]allocationPool{}, s.allocationPools...),
+		HostRoutes:      append([]hostRoute{}, s.hostRoutes...),
+		TenantID:        ProjectID,
+		ProjectID:       ProjectID,
+		Tags:            []string{},
+		CreatedAt:       s.createdAt.Format(timeLayout),
+		UpdatedAt:       s.updatedAt.Format(timeLayout),
+		RevisionNumber:  s.revision,
+	}
+	if s.gatewayIP != nil {
+		gateway := s.gatewayIP.String()
+		v.GatewayIP = &gateway
+	}
+	return v
+}
+
+type port struct {
+	id                  string
+	networkID           string
+	name                string
+	description         string
+	adminStateUp        bool
+	status              string
+	macAddress          string
+	fixedIPs            []fixedIP
+	deviceID            string
+	deviceOwner         string
+	securityGroups      []string
+	allowedAddressPairs []addressPair
+	createdAt           time.Time
+	updatedAt           time.Time
+	revision            int
+}
+
+type fixedIP struct {
+	SubnetID  string `json:"subnet_id"`
+	IPAddress string `json:"ip_address"`
+}
+
+type addressPair struct {
+	IPAddress  string `json:"ip_address"`
+	MACAddress string `json:"mac_address,omitempty"`
+}
+
+type portView struct {
+	ID                  string        `json:"id"`
+	NetworkID           string        `json:"network_id"`
+	Name                string        `json:"name"`
+	Description         string        `json:"description"`
+	AdminStateUp        bool          `json:"admin_state_up"`
+	Status              string        `json:"status"`
+	MACAddress          string        `json:"mac_address"`
+	FixedIPs            []fixedIP     `json:"fixed_ips"`
+	DeviceID            string        `json:"device_id"`
+	DeviceOwner         string        `json:"device_owner"`
+	SecurityGroups      []string      `json:"security_groups"`
+	AllowedAddressPairs []addressPair `json:"allowed_address_pairs"`
+	TenantID            string        `json:"tenant_id"`
+	ProjectID           string        `json:"project_id"`
+	Tags                []string      `json:"tags"`
+	CreatedAt           string        `json:"created_at"`
+	UpdatedAt           string        `json:"updated_at"`
+	RevisionNumber      int           `json:"revision_number"`
+}
+
+func (p *port) view() portView {
+	return portView{
+		ID:                  p.id,
+		NetworkID:           p.networkID,
+		Name:                p.name,
+		Description:         p.description,
+		AdminStateUp:        p.adminStateUp,
+		Status:              p.status,
+		MACAddress:          p.macAddress,
+		FixedIPs:            append([]fixedIP{}, p.fixedIPs...),
+		DeviceID:            p.deviceID,
+		DeviceOwner:         p.deviceOwner,
+		SecurityGroups:      append([]string{}, p.securityGroups...),
+		AllowedAddressPairs: append([]addressPair{}, p.allowedAddressPairs...),
+		TenantID:            ProjectID,
+		ProjectID:           ProjectID,
+		Tags:                []string{},
+		CreatedAt:           p.createdAt.Format(timeLayout),
+		UpdatedAt:           p.updatedAt.Format(timeLayout),
+		RevisionNumber:      p.revision,
+	}
+}
+
+// The attributes that lists of each resource can be filtered by.
+var (
+	networkFilters = filterAttributes("id", "name", "description", "status", "admin_state_up", "shared", "tenant_id", "project_id", "router:external", "mtu")
+	subnetFilters  = filterAttributes("id", "name", "description", "network_id", "ip_version", "cidr", "gateway_ip", "enable_dhcp", "tenant_id", "project_id")
+	portFilters    = filterAttributes("id", "name", "description", "network_id", "status", "admin_state_up", "mac_address", "device_id", "device_owner", "tenant_id", "project_id")
+)
+
+// filterAttributes returns the filters of a listQuery whose parameters are
+// named after the attributes they match.
+func filterAttributes(attrs ...string) map[string]string {
+	filters := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		filters[a] = a
+	}
+	return filters
+}
+
+func (c *Cloud) serveNetwork(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) < 2 || parts[0] != "v2.0" || len(parts) > 3 {
+		networkFault(w, http.StatusNotFound, "HTTPNotFound", "The resource could not be found.")
+		return
+	}
+
+	resource := parts[1]
+	if len(parts) == 2 {
+		switch {
+		case r.Method == "GET":
+			c.listNetworkResources(w, r, resource)
+		case r.Method == "POST" && resource == "networks":
+			c.createNetwork(w, r)
+		case r.Method == "POST" && resource == "subnets":
+			c.createSubnet(w, r)
+		case r.Method == "POST" && resource == "ports":
+			c.createPort(w, r)
+		default:
+			networkFault(w, http.StatusNotFound, "HTTPNotFound", "The resource could not be found.")
+		}
+		return
+	}
+
+	id := parts[2]
+	switch resource {
+	case "networks":
+		c.serveNetworkItem(w, r, id)
+	case "subnets":
+		c.serveSubnetItem(w, r, id)
+	case "ports":
+		c.servePortItem(w, r, id)
+	default:
+		networkFault(w, http.StatusNotFound, "HTTPNotFound", "The resource could not be found.")
+	}
+}
+
+func (c *Cloud) listNetworkResources(w http.ResponseWriter, r *http.Request, resource string) {
+	q := r.URL.Query()
+	lq := listQuery{
+		key:      resource,
+		sortKeys: pairedSortKeys(q),
+		fields:   q["fields"],
+		fault:    badNetworkRequest,
+	}
+
+	var views []interface{}
+	switch resource {
+	case "networks":
+		lq.filters = networkFilters
+		for _, item := range c.networks.all() {
+			views = append(views, item.(*network).view())
+		}
+	case "subnets":
+		lq.filters = subnetFilters
+		for _, item := range c.subnets.all() {
+			views = append(views, item.(*subnet).view())
+		}
+	case "ports":
+		lq.filters = portFilters
+		for _, item := range c.ports.all() {
+			views = append(views, item.(*port).view())
+		}
+	default:
+		networkFault(w, http.StatusNotFound, "HTTPNotFound", "The resource could not be found.")
+		return
+	}
+	c.writeList(w, r, lq, views)
+}
+
+func networkNotFound(id string) *fault {
+	return &fault{http.StatusNotFound, "NetworkNotFound", fmt.Sprintf("Network %s could not be found.", id)}
+}
+
+func subnetNotFound(id string) *fault {
+	return &fault{http.StatusNotFound, "SubnetNotFound", fmt.Sprintf("Subnet %s could not be found.", id)}
+}
+
+func portNotFound(id string) *fault {
+	return &fault{http.StatusNotFound, "PortNotFound", fmt.Sprintf("Port %s could not be found.", id)}
+}
+
+type networkRequest struct {
+	Network *struct {
+		Name         *string `json:"name"`
+		Description  *string `json:"description"`
+		AdminStateUp *bool   `json:"admin_state_up"`
+		Shared       *bool   `json:"shared"`
+	} `json:"network"`
+}
+
+func (c *Cloud) createNetwork(w http.ResponseWriter, r *http.Request) {
+	var req networkRequest
+	if !readJSON(w, r, &req, badNetworkRequest) {
+		return
+	}
+	if req.Network == nil {
+		badNetworkRequest(w, http.StatusBadRequest, "Resource body required")
+		return
+	}
+
+	t := now()
+	n := &network{
+		id:           newID(),
+		adminStateUp: true,
+		createdAt:    t,
+		updatedAt:    t,
+		revision:     1,
+	}
+	c.updateNetwork(n, &req)
+	c.networks.add(n.id, n)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"network": n.view()})
+}
+
+func (c *Cloud) updateNetwork(n *network, req *networkRequest) {
+	if v := req.Network.Name; v != nil {
+		n.name = *v
+	}
+	if v := req.Network.Description; v != nil {
+		n.description = *v
+	}
+	if v := req.Network.AdminStateUp; v != nil {
+		n.adminStateUp = *v
+	}
+	if v := req.Network.Shared; v != nil {
+		n.shared = *v
+	}
+}
+
+func (c *Cloud) serveNetworkItem(w http.ResponseWriter, r *http.Request, id string) {
+	item, ok := c.networks.get(id)
+	if !ok {
+		writeNetworkFault(w, networkNotFound(id))
+		return
+	}
+	n := item.(*network)
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"network": n.view()})
+	case "PUT":
+		var req networkRequest
+		if !readJSON(w, r, &req, badNetworkRequest) {
+			return
+		}
+		if req.Network == nil {
+			badNetworkRequest(w, http.StatusBadRequest, "Resource body required")
+			return
+		}
+		c.updateNetwork(n, &req)
+		n.updatedAt = now()
+		n.revision++
+		writeJSON(w, http.StatusOK, map[string]interface{}{"network": n.view()})
+	case "DELETE":
+		for _, item := range c.ports.all() {
+			if item.(*port).networkID == id {
+				networkFault(w, http.StatusConflict, "NetworkInUse",
+					fmt.Sprintf("Unable to complete operation on network %s. There are one or more ports still in use on the network.", id))
+				return
+			}
+		}
+		for _, s := range n.subnets {
+			c.subnets.remove(s)
+		}
+		c.networks.remove(id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		networkFault(w, http.StatusMethodNotAllowed, "HTTPMethodNotAllowed", "The method is not allowed for the requested URL.")
+	}
+}
+
+type subnetRequest struct {
+	Subnet *struct {
+		NetworkID       string           `json:"network_id"`
+		Name            *string          `json:"name"`
+		Description     *string          `json:"description"`
+		IPVersion       int              `json:"ip_version"`
+		CIDR            string           `json:"cidr"`
+		GatewayIP       *nullableString  `json:"gateway_ip"`
+		EnableDHCP      *bool            `json:"enable_dhcp"`
+		DNSNameservers  []string         `json:"dns_nameservers"`
+		AllocationPools []allocationPool `json:"allocation_pools"`
+		HostRoutes      []hostRoute      `json:"host_routes"`
+	} `json:"subnet"`
+}
+
+// nullableString is a string attribute that may be set to null, as opposed
+// to left out.
+type nullableString struct {
+	value string
+	null  bool
+}
+
+func (s *nullableString) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		s.null = true
+		return nil
+	}
+	return json.Unmarshal(b, &s.value)
+}
+
+func (c *Cloud) createSubnet(w http.ResponseWriter, r *http.Request) {
+	var req subnetRequest
+	if !readJSON(w, r, &req, badNetworkRequest) {
+		return
+	}
+	opts := req.Subnet
+	if opts == nil {
+		badNetworkRequest(w, http.StatusBadRequest, "Resource body required")
+		return
+	}
+
+	item, ok := c.networks.get(opts.NetworkID)
+	if !ok {
+		writeNetworkFault(w, networkNotFound(opts.NetworkID))
+		return
+	}
+	n := item.(*network)
+
+	ip, cidr, err := net.ParseCIDR(opts.CIDR)
+	if err != nil || !ip.Equal(cidr.IP) {
+		badNetworkRequest(w, http.StatusBadRequest, fmt.Sprintf("Invalid input for cidr. Reason: '%s' is not a valid IP subnet.", opts.CIDR))
+		return
+	}
+	version := 6
+	if cidr.IP.To4() != nil {
+		version = 4
+		cidr.IP = cidr.IP.To4()
+	}
+	if opts.IPVersion != version {
+		badNetworkRequest(w, http.StatusBadRequest, fmt.Sprintf("Invalid input for operation: cidr '%s' does not match the ip_version '%d'.", opts.CIDR, opts.IPVersion))
+		return
+	}
+	for _, id := range n.subnets {
+		other := c.mustSubnet(id)
+		if other.cidr.Contains(cidr.IP) || cidr.Contains(other.cidr.IP) {
+			badNetworkRequest(w, http.StatusBadRequest, fmt.Sprintf("Invalid input for operation: Requested subnet with cidr: %s for network: %s overlaps with another subnet.", cidr, n.id))
+			return
+		}
+	}
+
+	first, last := hostRange(cidr)
+	if first == nil {
+		badNetworkRequest(w, http.StatusBadRequest, fmt.Sprintf("Invalid input for operation: cidr '%s' has no usable addresses.", cidr))
+		return
+	}
+
+	t := now()
+	s := &subnet{
+		id:             newID(),
+		networkID:      n.id,
+		ipVersion:      version,
+		cidr:           cidr,
+		gatewayIP:      first,
+		enableDHCP:     true,
+		dnsNameservers: opts.DNSNameservers,
+		hostRoutes:     opts.HostRoutes,
+		createdAt:      t,
+		updatedAt:      t,
+		revision:       1,
+	}
+	if v := opts.Name; v != nil {
+		s.name = *v
+	}
+	if v := opts.Description; v != nil {
+		s.description = *v
+	}
+	if v := opts.EnableDHCP; v != nil {
+		s.enableDHCP = *v
+	}
+	if g := opts.GatewayIP; g != nil {
+		if g.null {
+			s.gatewayIP = nil
+		} else if s.gatewayIP = net.ParseIP(g.value); s.gatewayIP == nil || !cidr.Contains(s.gatewayIP) {
+			badNetworkRequest(w, http.StatusBadRequest, fmt.Sprintf("Invalid input for operation: Gateway IP %s is not in the subnet %s.", g.value, cidr))
+			return
+		}
+	}
+
+	if len(opts.AllocationPools) > 0 {
+		for _, pool := range opts.AllocationPools {
+			start, end := net.ParseIP(pool.Start), net.ParseIP(pool.End)
+			if start == nil || end == nil || !cidr.Contains(start) || !cidr.Contains(end) || bytes.Compare(normalizeIP(start), normalizeIP(end)) > 0 {
+				badNetworkRequest(w, http.StatusBadRequest, fmt.Sprintf("Invalid input for operation: The allocation pool %s-%s is not valid in the subnet %s.", pool.Start, pool.End, cidr))
+				return
+			}
+		}
+		s.allocationPools = opts.AllocationPools
+	} else {
+		if s.gatewayIP != nil && s.gatewayIP.Equal(first) {
+			first = nextIP(first)
+		}
+		if bytes.Compare(first, last) <= 0 {
+			s.allocationPools = []allocationPool{{Start: first.String(), End: last.String()}}
+		}
+	}
+
+	c.subnets.add(s.id, s)
+	n.subnets = append(n.subnets, s.id)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"subnet": s.view()})
+}
+
+func (c *Cloud) mustSubnet(id string) *subnet {
+	item, _ := c.subnets.get(id)
+	return item.(*subnet)
+}
+
+func (c *Cloud) serveSubnetItem(w http.ResponseWriter, r *http.Request, id string) {
+	item, ok := c.subnets.get(id)
+	if !ok {
+		writeNetworkFault(w, subnetNotFound(id))
+		return
+	}
+	s := item.(*subnet)
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"subnet": s.view()})
+	case "PUT":
+		var req subnetRequest
+		if !readJSON(w, r, &req, badNetworkRequest) {
+			return
+		}
+		opts := req.Subnet
+		if opts == nil {
+			badNetworkRequest(w, http.StatusBadRequest, "Resource body required")
+			return
+		}
+		if v := opts.Name; v != nil {
+			s.name = *v
+		}
+		if v := opts.Description; v != nil {
+			s.description = *v
+		}
+		if v := opts.EnableDHCP; v != nil {
+			s.enableDHCP = *v
+		}
+		if opts.DNSNameservers != nil {
+			s.dnsNameservers = opts.DNSNameservers
+		}
+		if opts.HostRoutes != nil {
+			s.hostRoutes = opts.HostRoutes
+		}
+		if g := opts.GatewayIP; g != nil {
+			if g.null {
+				s.gatewayIP = nil
+			} else if ip := net.ParseIP(g.value); ip != nil && s.cidr.Contains(ip) {
+				s.gatewayIP = ip
+			} else {
+				badNetworkRequest(w, http.StatusBadRequest, fmt.Sprintf("Invalid input for operation: Gateway IP %s is not in the subnet %s.", g.value, s.cidr))
+				return
+			}
+		}
+		s.updatedAt = now()
+		s.revision++
+		writeJSON(w, http.StatusOK, map[string]interface{}{"subnet": s.view()})
+	case "DELETE":
+		for _, item := range c.ports.all() {
+			for _, ip := range item.(*port).fixedIPs {
+				if ip.SubnetID == id {
+					networkFault(w, http.StatusConflict, "SubnetInUse",
+						fmt.Sprintf("Unable to complete operation on subnet %s: One or more ports have an IP allocation from this subnet.", id))
+					return
+				}
+			}
+		}
+		if item, ok := c.networks.get(s.networkID); ok {
+			n := item.(*network)
+			for i, v := range n.subnets {
+				if v == id {
+					n.subnets = append(n.subnets[:i:i], n.subnets[i+1:]...)
+					break
+				}
+			}
+		}
+		c.subnets.remove(id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		networkFault(w, http.StatusMethodNotAllowed, "HTTPMethodNotAllowed", "The method is not allowed for the requested URL.")
+	}
+}
+
+type portRequest struct {
+	Port *portOpts `json:"port"`
+}
+
+// portOpts are the attributes of a port that may be set when it's created
+// or updated.
+type portOpts struct {
+	NetworkID           string         `json:"network_id"`
+	Name                *string        `json:"name"`
+	Description         *string        `json:"description"`
+	AdminStateUp        *bool          `json:"admin_state_up"`
+	MACAddress          string         `json:"mac_address"`
+	FixedIPs            []fixedIP      `json:"fixed_ips"`
+	DeviceID            *string        `json:"device_id"`
+	DeviceOwner         *string        `json:"device_owner"`
+	SecurityGroups      *[]string      `json:"security_groups"`
+	AllowedAddressPairs *[]addressPair `json:"allowed_address_pairs"`
+}
+
+func (c *Cloud) createPort(w http.ResponseWriter, r *http.Request) {
+	var req portRequest
+	if !readJSON(w, r, &req, badNetworkRequest) {
+		return
+	}
+	if req.Port == nil {
+		badNetworkRequest(w, http.StatusBadRequest, "Resource body required")
+		return
+	}
+
+	p, f := c.newPort(req.Port)
+	if f != nil {
+		writeNetworkFault(w, f)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"port": p.view()})
+}
+
+// newPort creates a port on a network, allocating its addresses.
+func (c *Cloud) newPort(opts *portOpts) (*port, *fault) {
+	item, ok := c.networks.get(opts.NetworkID)
+	if !ok {
+		return nil, networkNotFound(opts.NetworkID)
+	}
+	n := item.(*network)
+
+	t := now()
+	p := &port{
+		id:                  newID(),
+		networkID:           n.id,
+		adminStateUp:        true,
+		status:              "DOWN",
+		macAddress:          opts.MACAddress,
+		securityGroups:      []string{},
+		allowedAddressPairs: []addressPair{},
+		createdAt:           t,
+		updatedAt:           t,
+		revision:            1,
+	}
+
+	if p.macAddress == "" {
+		p.macAddress = c.newMACAddress()
+	} else if hw, err := net.ParseMAC(p.macAddress); err != nil || len(hw) != 6 {
+		return nil, &fault{http.StatusBadRequest, "HTTPBadRequest", fmt.Sprintf("Invalid input for mac_address. Reason: '%s' is not a valid MAC address.", p.macAddress)}
+	} else {
+		for _, item := range c.ports.all() {
+			if item.(*port).macAddress == p.macAddress {
+				return nil, &fault{http.StatusConflict, "MacAddressInUse", fmt.Sprintf("Unable to complete operation for network %s. The mac address %s is in use.", n.id, p.macAddress)}
+			}
+		}
+	}
+
+	ips, f := c.allocateIPs(n, opts.FixedIPs)
+	if f != nil {
+		return nil, f
+	}
+	p.fixedIPs = ips
+
+	updatePort(p, opts)
+	c.ports.add(p.id, p)
+	return p, nil
+}
+
+func updatePort(p *port, opts *portOpts) {
+	if v := opts.Name; v != nil {
+		p.name = *v
+	}
+	if v := opts.Description; v != nil {
+		p.description = *v
+	}
+	if v := opts.AdminStateUp; v != nil {
+		p.adminStateUp = *v
+	}
+	if v := opts.DeviceID; v != nil {
+		p.deviceID = *v
+	}
+	if v := opts.DeviceOwner; v != nil {
+		p.deviceOwner = *v
+	}
+	if v := opts.SecurityGroups; v != nil {
+		p.securityGroups = append([]string{}, *v...)
+	}
+	if v := opts.AllowedAddressPairs; v != nil {
+		p.allowedAddressPairs = append([]addressPair{}, *v...)
+	}
+}
+
+// newMACAddress returns an unused MAC address with the prefix Neutron uses.
+func (c *Cloud) newMACAddress() string {
+	for {
+		b := make([]byte, 3)
+		if _, err := rand.Read(b); err != nil {
+			panic(err)
+		}
+		mac := fmt.Sprintf("fa:16:3e:%02x:%02x:%02x", b[0], b[1], b[2])
+		inUse := false
+		for _, item := range c.ports.all() {
+			if item.(*port).macAddress == mac {
+				inUse = true
+				break
+			}
+		}
+		if !inUse {
+			return mac
+		}
+	}
+}
+
+// allocateIPs allocates the addresses that a new port on n asks for. If it
+// doesn't ask for any, it gets one from the first IPv4 subnet and one from
+// the first IPv6 subnet of n.
+func (c *Cloud) allocateIPs(n *network, requested []fixedIP) ([]fixedIP, *fault) {
+	if requested == nil {
+		versions := make(map[int]bool)
+		for _, id := range n.subnets {
+			if s := c.mustSubnet(id); !versions[s.ipVersion] {
+				versions[s.ipVersion] = true
+				requested = append(requested, fixedIP{SubnetID: id})
+			}
+		}
+	}
+
+	ips := []fixedIP{}
+	for _, req := range requested {
+		var s *subnet
+		if req.SubnetID != "" {
+			item, ok := c.subnets.get(req.SubnetID)
+			if !ok {
+				return nil, subnetNotFound(req.SubnetID)
+			}
+			s = item.(*subnet)
+			if s.networkID != n.id {
+				return nil, &fault{http.StatusBadRequest, "InvalidInput", fmt.Sprintf("Invalid input for operation: Failed to create port on network %s, because fixed_ips included invalid subnet %s.", n.id, s.id)}
+			}
+		}
+
+		if req.IPAddress == "" {
+			ip := c.freeIP(s, ips)
+			if ip == nil {
+				return nil, &fault{http.StatusConflict, "IpAddressGenerationFailure", fmt.Sprintf("No more IP addresses available on network %s.", n.id)}
+			}
+			ips = append(ips, fixedIP{SubnetID: s.id, IPAddress: ip.String()})
+			continue
+		}
+
+		ip := net.ParseIP(req.IPAddress)
+		if ip == nil {
+			return nil, &fault{http.StatusBadRequest, "HTTPBadRequest", fmt.Sprintf("Invalid input for fixed_ips. Reason: '%s' is not a valid IP address.", req.IPAddress)}
+		}
+		if s == nil {
+			for _, id := range n.subnets {
+				if candidate := c.mustSubnet(id); candidate.cidr.Contains(ip) {
+					s = candidate
+					break
+				}
+			}
+		}
+		if s == nil || !s.cidr.Contains(ip) {
+			return nil, &fault{http.StatusBadRequest, "InvalidIpForNetwork", fmt.Sprintf("IP address %s is not a valid IP for any of the subnets on the specified network.", req.IPAddress)}
+		}
+		if c.ipInUse(s, ip, ips) {
+			return nil, &fault{http.StatusConflict, "IpAddressAlreadyAllocated", fmt.Sprintf("IP address %s already allocated in subnet %s", req.IPAddress, s.id)}
+		}
+		ips = append(ips, fixedIP{SubnetID: s.id, IPAddress: ip.String()})
+	}
+	return ips, nil
+}
+
+// freeIP returns the first address of the allocation pools of s that is
+// neither in use nor in pending, or nil if there are none left.
+func (c *Cloud) freeIP(s *subnet, pending []fixedIP) net.IP {
+	for _, pool := range s.allocationPools {
+		end := normalizeIP(net.ParseIP(pool.End))
+		for ip := normalizeIP(net.ParseIP(pool.Start)); bytes.Compare(ip, end) <= 0; ip = nextIP(ip) {
+			if !c.ipInUse(s, ip, pending) {
+				return ip
+			}
+			if ip.Equal(end) {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// ipInUse reports whether ip is the gateway of s, or is allocated to a port
+// or in pending.
+func (c *Cloud) ipInUse(s *subnet, ip net.IP, pending []fixedIP) bool {
+	if s.gatewayIP != nil && s.gatewayIP.Equal(ip) {
+		return true
+	}
+	for _, p := range pending {
+		if p.SubnetID == s.id && net.ParseIP(p.IPAddress).Equal(ip) {
+			return true
+		}
+	}
+	for _, item := range c.ports.all() {
+		for _, p := range item.(*port).fixedIPs {
+			if p.SubnetID == s.id && net.ParseIP(p.IPAddress).Equal(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hostRange returns the first and last addresses of cidr that can be given
+// to hosts, or nil if there are none.
+func hostRange(cidr *net.IPNet) (net.IP, net.IP) {
+	ip := normalizeIP(cidr.IP)
+	last := make(net.IP, len(ip))
+	for i := range ip {
+		last[i] = ip[i] | ^cidr.Mask[i]
+	}
+
+	first := nextIP(ip)
+	if len(ip) == net.IPv4len {
+		// The broadcast address can't be given to hosts.
+		last = prevIP(last)
+	}
+	if bytes.Compare(first, last) > 0 {
+		return nil, nil
+	}
+	return first, last
+}
+
+// normalizeIP returns ip in its 4-byte form if it's an IPv4 address.
+func normalizeIP(ip net.IP) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		return v4
+	}
+	return ip
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := append(net.IP{}, ip...)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+func prevIP(ip net.IP) net.IP {
+	prev := append(net.IP{}, ip...)
+	for i := len(prev) - 1; i >= 0; i-- {
+		prev[i]--
+		if prev[i] != 0xff {
+			break
+		}
+	}
+	return prev
+}
+
+func (c *Cloud) servePortItem(w http.ResponseWriter, r *http.Request, id string) {
+	item, ok := c.ports.get(id)
+	if !ok {
+		writeNetworkFault(w, portNotFound(id))
+		return
+	}
+	p := item.(*port)
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"port": p.view()})
+	case "PUT":
+		var req portRequest
+		if !readJSON(w, r, &req, badNetworkRequest) {
+			return
+		}
+		if req.Port == nil {
+			badNetworkRequest(w, http.StatusBadRequest, "Resource body required")
+			return
+		}
+		if req.Port.FixedIPs != nil {
+			// The addresses of the port are released before the new ones
+			// are allocated, so that it may keep some of them.
+			ips := p.fixedIPs
+			p.fixedIPs = nil
+			allocated, f := c.allocateIPs(c.mustNetwork(p.networkID), req.Port.FixedIPs)
+			if f != nil {
+				p.fixedIPs = ips
+				writeNetworkFault(w, f)
+				return
+			}
+			p.fixedIPs = allocated
+		}
+		updatePort(p, req.Port)
+		p.updatedAt = now()
+		p.revision++
+		writeJSON(w, http.StatusOK, map[string]interface{}{"port": p.view()})
+	case "DELETE":
+		c.removePort(p)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		networkFault(w, http.StatusMethodNotAllowed, "HTTPMethodNotAllowed", "The method is not allowed for the requested URL.")
+	}
+}
+
+func (c *Cloud) mustNetwork(id string) *network {
+	item, _ := c.networks.get(id)
+	return item.(*network)
+}
+
+// removePort deletes p and detaches it from the server it's bound to.
+func (c *Cloud) removePort(p *port) {
+	if item, ok := c.servers.get(p.deviceID); ok {
+		s := item.(*server)
+		for i, id := range s.ports {
+			if id == p.id {
+				s.ports = append(s.ports[:i:i], s.ports[i+1:]...)
+				break
+			}
+		}
+		delete(s.ownPorts, p.id)
+	}
+	c.ports.remove(p.id)
+}
@@ -0,0 +1,422 @@
+package fakecloud
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type container struct {
+	name      string
+	metadata  map[string]string
+	objects   map[string]*object
+	createdAt time.Time
+}
+
+type object struct {
+	name         string
+	data         []byte
+	etag         string
+	contentType  string
+	metadata     map[string]string
+	lastModified time.Time
+}
+
+func (ct *container) bytesUsed() int {
+	n := 0
+	for _, o := range ct.objects {
+		n += len(o.data)
+	}
+	return n
+}
+
+// swiftError writes an error in the format of the Object Storage API.
+func swiftError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	w.WriteHeader(code)
+	if code != http.StatusNoContent && code != http.StatusNotModified {
+		fmt.Fprintf(w, "<html><h1>%s</h1><p>%s</p></html>", http.StatusText(code), message)
+	}
+}
+
+func swiftNotFound(w http.ResponseWriter) {
+	swiftError(w, http.StatusNotFound, "The resource could not be found.")
+}
+
+// timestamp renders t in the format of the X-Timestamp header.
+func timestamp(t time.Time) string {
+	return fmt.Sprintf("%.5f", float64(t.UnixNano())/1e9)
+}
+
+// updateMetadata applies the metadata headers of r with the given prefix,
+// such as "X-Container-Meta-", to metadata. A header with an empty value,
+// or a matching X-Remove- header, removes an item.
+func updateMetadata(metadata map[string]string, h http.Header, prefix string) {
+	remove := "X-Remove-" + strings.TrimPrefix(prefix, "X-")
+	for k := range h {
+		switch {
+		case strings.HasPrefix(k, prefix):
+			if v := h.Get(k); v != "" {
+				metadata[strings.TrimPrefix(k, prefix)] = v
+			} else {
+				delete(metadata, strings.TrimPrefix(k, prefix))
+			}
+		case strings.HasPrefix(k, remove):
+			delete(metadata, strings.TrimPrefix(k, remove))
+		}
+	}
+}
+
+func setMetadataHeaders(h http.Header, metadata map[string]string, prefix string) {
+	for k, v := range metadata {
+		h.Set(prefix+k, v)
+	}
+}
+
+func (c *Cloud) serveObjectStorage(w http.ResponseWriter, r *http.Request, path string) {
+	parts := strings.SplitN(strings.TrimPrefix(path, "v1/"), "/", 3)
+	if !strings.HasPrefix(path, "v1/") || parts[0] != "AUTH_"+ProjectID {
+		swiftNotFound(w)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 || parts[1] == "":
+		c.serveAccount(w, r)
+	case len(parts) == 2 || parts[2] == "":
+		c.serveContainer(w, r, parts[1])
+	default:
+		c.serveObject(w, r, parts[1], parts[2])
+	}
+}
+
+func (c *Cloud) accountHeaders(h http.Header) {
+	objects, bytes := 0, 0
+	for _, ct := range c.containers {
+		objects += len(ct.objects)
+		bytes += ct.bytesUsed()
+	}
+	h.Set("X-Account-Container-Count", strconv.Itoa(len(c.containers)))
+	h.Set("X-Account-Object-Count", strconv.Itoa(objects))
+	h.Set("X-Account-Bytes-Used", strconv.Itoa(bytes))
+	h.Set("X-Timestamp", timestamp(time.Unix(0, 0)))
+}
+
+func (c *Cloud) serveAccount(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		c.accountHeaders(w.Header())
+
+		var names []string
+		for name := range c.containers {
+			names = append(names, name)
+		}
+		entries, ok := listing(w, r, names)
+		if !ok {
+			return
+		}
+
+		var items []interface{}
+		for _, e := range entries {
+			ct := c.containers[e.name]
+			items = append(items, map[string]interface{}{
+				"name":          ct.name,
+				"count":         len(ct.objects),
+				"bytes":         ct.bytesUsed(),
+				"last_modified": ct.createdAt.Format(microTimeLayout),
+			})
+		}
+		writeListing(w, r, entries, items)
+	case "HEAD":
+		c.accountHeaders(w.Header())
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		swiftError(w, http.StatusMethodNotAllowed, "The method is not allowed for this resource.")
+	}
+}
+
+func containerHeaders(h http.Header, ct *container) {
+	h.Set("X-Container-Object-Count", strconv.Itoa(len(ct.objects)))
+	h.Set("X-Container-Bytes-Used", strconv.Itoa(ct.bytesUsed()))
+	h.Set("X-Timestamp", timestamp(ct.createdAt))
+	setMetadataHeaders(h, ct.metadata, "X-Container-Meta-")
+}
+
+func (c *Cloud) serveContainer(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method == "PUT" {
+		if ct, ok := c.containers[name]; ok {
+			updateMetadata(ct.metadata, r.Header, "X-Container-Meta-")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		ct := &container{
+			name:      name,
+			metadata:  make(map[string]string),
+			objects:   make(map[string]*object),
+			createdAt: now(),
+		}
+		updateMetadata(ct.metadata, r.Header, "X-Container-Meta-")
+		c.containers[name] = ct
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	ct, ok := c.containers[name]
+	if !ok {
+		swiftNotFound(w)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		containerHeaders(w.Header(), ct)
+
+		var names []string
+		for name := range ct.objects {
+			names = append(names, name)
+		}
+		entries, ok := listing(w, r, names)
+		if !ok {
+			return
+		}
+
+		var items []interface{}
+		for _, e := range entries {
+			if e.subdir {
+				items = append(items, map[string]interface{}{"subdir": e.name})
+				continue
+			}
+			o := ct.objects[e.name]
+			items = append(items, map[string]interface{}{
+				"name":          o.name,
+				"hash":          o.etag,
+				"bytes":         len(o.data),
+				"content_type":  o.contentType,
+				"last_modified": o.lastModified.Format(microTimeLayout),
+			})
+		}
+		writeListing(w, r, entries, items)
+	case "HEAD":
+		containerHeaders(w.Header(), ct)
+		w.WriteHeader(http.StatusNoContent)
+	case "POST":
+		updateMetadata(ct.metadata, r.Header, "X-Container-Meta-")
+		w.WriteHeader(http.StatusNoContent)
+	case "DELETE":
+		if len(ct.objects) > 0 {
+			swiftError(w, http.StatusConflict, "There was a conflict when trying to complete your request.")
+			return
+		}
+		delete(c.containers, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		swiftError(w, http.StatusMethodNotAllowed, "The method is not allowed for this resource.")
+	}
+}
+
+type listingEntry struct {
+	name   string
+	subdir bool
+}
+
+// listing returns the entries of a container or account listing that r
+// asks for, from the names of the objects or containers it holds.
+func listing(w http.ResponseWriter, r *http.Request, names []string) ([]listingEntry, bool) {
+	q := r.URL.Query()
+	prefix, delimiter := q.Get("prefix"), q.Get("delimiter")
+	marker, endMarker := q.Get("marker"), q.Get("end_marker")
+
+	limit := 10000
+	if s := q.Get("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil || limit < 0 {
+			swiftError(w, http.StatusPreconditionFailed, fmt.Sprintf("Value of limit must be a positive integer: %q", s))
+			return nil, false
+		}
+	}
+
+	sort.Strings(names)
+	var entries []listingEntry
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		e := listingEntry{name: name}
+		if delimiter != "" {
+			if i := strings.Index(name[len(prefix):], delimiter); i >= 0 {
+				e = listingEntry{name: name[:len(prefix)+i+len(delimiter)], subdir: true}
+			}
+		}
+		if e.name <= marker || (endMarker != "" && e.name >= endMarker) {
+			continue
+		}
+		if n := len(entries); n > 0 && entries[n-1] == e {
+			continue
+		}
+		if len(entries) == limit {
+			break
+		}
+		entries = append(entries, e)
+	}
+	return entries, true
+}
+
+// writeListing writes a listing as plain text, one name per line, or as
+// JSON items if r asks for them.
+func writeListing(w http.ResponseWriter, r *http.Request, entries []listingEntry, items []interface{}) {
+	format := r.URL.Query().Get("format")
+	if format == "json" || (format == "" && strings.Contains(r.Header.Get("Accept"), "application/json")) {
+		if items == nil {
+			items = []interface{}{}
+		}
+		writeJSON(w, http.StatusOK, items)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if len(entries) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	for _, e := range entries {
+		fmt.Fprintln(w, e.name)
+	}
+}
+
+func objectHeaders(h http.Header, o *object) {
+	h.Set("Content-Type", o.contentType)
+	h.Set("Content-Length", strconv.Itoa(len(o.data)))
+	h.Set("ETag", o.etag)
+	h.Set("Last-Modified", o.lastModified.Format(http.TimeFormat))
+	h.Set("X-Timestamp", timestamp(o.lastModified))
+	setMetadataHeaders(h, o.metadata, "X-Object-Meta-")
+}
+
+func (c *Cloud) serveObject(w http.ResponseWriter, r *http.Request, containerName, name string) {
+	ct, ok := c.containers[containerName]
+	if !ok {
+		swiftNotFound(w)
+		return
+	}
+
+	if r.Method == "PUT" {
+		if source := r.Header.Get("X-Copy-From"); source != "" {
+			c.copyObject(w, r, source, containerName+"/"+name)
+			return
+		}
+		c.putObject(w, r, ct, name)
+		return
+	}
+
+	o, ok := ct.objects[name]
+	if !ok {
+		swiftNotFound(w)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		objectHeaders(w.Header(), o)
+		w.WriteHeader(http.StatusOK)
+		w.Write(o.data)
+	case "HEAD":
+		objectHeaders(w.Header(), o)
+		w.WriteHeader(http.StatusOK)
+	case "POST":
+		o.metadata = make(map[string]string)
+		updateMetadata(o.metadata, r.Header, "X-Object-Meta-")
+		if v := r.Header.Get("Content-Type"); v != "" {
+			o.contentType = v
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case "COPY":
+		c.copyObject(w, r, containerName+"/"+name, r.Header.Get("Destination"))
+	case "DELETE":
+		delete(ct.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		swiftError(w, http.StatusMethodNotAllowed, "The method is not allowed for this resource.")
+	}
+}
+
+func (c *Cloud) putObject(w http.ResponseWriter, r *http.Request, ct *container, name string) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		swiftError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sum := md5.Sum(data)
+	etag := hex.EncodeToString(sum[:])
+	if v := strings.Trim(r.Header.Get("ETag"), `"`); v != "" && !strings.EqualFold(v, etag) {
+		swiftError(w, http.StatusUnprocessableEntity, "The MD5 checksum of the body didn't match the ETag header.")
+		return
+	}
+
+	o := &object{
+		name:         name,
+		data:         data,
+		etag:         etag,
+		contentType:  r.Header.Get("Content-Type"),
+		metadata:     make(map[string]string),
+		lastModified: now(),
+	}
+	if o.contentType == "" {
+		o.contentType = "application/octet-stream"
+	}
+	updateMetadata(o.metadata, r.Header, "X-Object-Meta-")
+	ct.objects[name] = o
+
+	w.Header().Set("ETag", o.etag)
+	w.Header().Set("Last-Modified", o.lastModified.Format(http.TimeFormat))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// copyObject copies the object at source to destination, both in the form
+// "container/object", applying the metadata headers of r to the copy.
+func (c *Cloud) copyObject(w http.ResponseWriter, r *http.Request, source, destination string) {
+	split := func(path string) (string, string, bool) {
+		parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return "", "", false
+		}
+		return parts[0], parts[1], true
+	}
+
+	fromContainer, fromName, fromOK := split(source)
+	toContainer, toName, toOK := split(destination)
+	if !fromOK || !toOK {
+		swiftError(w, http.StatusPreconditionFailed, "Copy paths must be of the form <container name>/<object name>")
+		return
+	}
+	from, to := c.containers[fromContainer], c.containers[toContainer]
+	if from == nil || to == nil || from.objects[fromName] == nil {
+		swiftNotFound(w)
+		return
+	}
+
+	o := *from.objects[fromName]
+	o.name = toName
+	o.lastModified = now()
+	o.metadata = make(map[string]string)
+	for k, v := range from.objects[fromName].metadata {
+		o.metadata[k] = v
+	}
+	updateMetadata(o.metadata, r.Header, "X-Object-Meta-")
+	if v := r.Header.Get("Content-Type"); v != "" {
+		o.contentType = v
+	}
+	to.objects[toName] = &o
+
+	w.Header().Set("ETag", o.etag)
+	w.Header().Set("Last-Modified", o.lastModified.Format(http.TimeFormat))
+	w.Header().Set("X-Copied-From", strings.TrimPrefix(source, "/"))
+	w.WriteHeader(http.StatusCreated)
+}
@@ -0,0 +1,336 @@
+package testing
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/startstop"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/containers"
+	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/objects"
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/fakecloud"
+)
+
+type clients struct {
+	provider *gophercloud.ProviderClient
+	compute  *gophercloud.ServiceClient
+	network  *gophercloud.ServiceClient
+	volume   *gophercloud.ServiceClient
+	object   *gophercloud.ServiceClient
+}
+
+func newClients(t *testing.T, cloud *fakecloud.Cloud) clients {
+	provider, err := openstack.AuthenticatedClient(cloud.AuthOptions())
+	th.AssertNoErr(t, err)
+
+	var c clients
+	c.provider = provider
+	c.compute, err = openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	c.network, err = openstack.NewNetworkV2(provider, gophercloud.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	c.volume, err = openstack.NewBlockStorageV3(provider, gophercloud.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	c.object, err = openstack.NewObjectStorageV1(provider, gophercloud.EndpointOpts{})
+	th.AssertNoErr(t, err)
+	return c
+}
+
+func createNetwork(t *testing.T, c clients, name, cidr string) *networks.Network {
+	n, err := networks.Create(c.network, networks.CreateOpts{Name: name}).Extract()
+	th.AssertNoErr(t, err)
+	_, err = subnets.Create(c.network, subnets.CreateOpts{
+		NetworkID: n.ID,
+		CIDR:      cidr,
+		IPVersion: gophercloud.IPv4,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	return n
+}
+
+// assertCode asserts that err is the error of a response with the given
+// status code.
+func assertCode(t *testing.T, err error, code int) {
+	t.Helper()
+	var actual int
+	switch e := err.(type) {
+	case gophercloud.ErrDefault400:
+		actual = e.Actual
+	case gophercloud.ErrDefault404:
+		actual = e.Actual
+	case gophercloud.ErrUnexpectedResponseCode:
+		actual = e.Actual
+	default:
+		t.Fatalf("Expected a %d error, got %v", code, err)
+	}
+	th.AssertEquals(t, code, actual)
+}
+
+func assertVolumeStatus(t *testing.T, c clients, id, status string) {
+	t.Helper()
+	v, err := volumes.Get(c.volume, id).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, status, v.Status)
+}
+
+func TestServerWorkflow(t *testing.T) {
+	cloud := fakecloud.New()
+	defer cloud.Close()
+	c := newClients(t, cloud)
+
+	n := createNetwork(t, c, "private", "10.0.0.0/24")
+	port, err := ports.Create(c.network, ports.CreateOpts{NetworkID: n.ID, Name: "port"}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "DOWN", port.Status)
+	th.AssertEquals(t, 1, len(port.FixedIPs))
+	th.AssertEquals(t, "10.0.0.2", port.FixedIPs[0].IPAddress)
+
+	s, err := servers.Create(c.compute, servers.CreateOpts{
+		Name:      "server",
+		ImageRef:  "cirros",
+		FlavorRef: "1",
+		Networks:  []servers.Network{{Port: port.ID}},
+	}).Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertNoErr(t, servers.WaitForStatus(c.compute, s.ID, "ACTIVE", 10))
+	s, err = servers.Get(c.compute, s.ID).Extract()
+	th.AssertNoErr(t, err)
+	addresses := s.Addresses["private"].([]interface{})
+	th.AssertEquals(t, "10.0.0.2", addresses[0].(map[string]interface{})["addr"])
+
+	port, err = ports.Get(c.network, port.ID).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "ACTIVE", port.Status)
+	th.AssertEquals(t, s.ID, port.DeviceID)
+
+	assertCode(t, networks.Delete(c.network, n.ID).ExtractErr(), 409)
+
+	v, err := volumes.Create(c.volume, volumes.CreateOpts{Name: "data", Size: 1}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "creating", v.Status)
+	assertVolumeStatus(t, c, v.ID, "available")
+
+	attachment, err := volumeattach.Create(c.compute, s.ID, volumeattach.CreateOpts{VolumeID: v.ID}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "/dev/vdb", attachment.Device)
+	assertVolumeStatus(t, c, v.ID, "in-use")
+
+	assertCode(t, volumes.Delete(c.volume, v.ID).ExtractErr(), 400)
+
+	th.AssertNoErr(t, volumeattach.Delete(c.compute, s.ID, v.ID).ExtractErr())
+	assertVolumeStatus(t, c, v.ID, "available")
+	th.AssertNoErr(t, volumes.Delete(c.volume, v.ID).ExtractErr())
+	_, err = volumes.Get(c.volume, v.ID).Extract()
+	assertCode(t, err, 404)
+
+	th.AssertNoErr(t, servers.Delete(c.compute, s.ID).ExtractErr())
+	_, err = servers.Get(c.compute, s.ID).Extract()
+	assertCode(t, err, 404)
+
+	// The port was created before the server, so it outlives it.
+	port, err = ports.Get(c.network, port.ID).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "", port.DeviceID)
+	th.AssertEquals(t, "DOWN", port.Status)
+
+	th.AssertNoErr(t, ports.Delete(c.network, port.ID).ExtractErr())
+	th.AssertNoErr(t, networks.Delete(c.network, n.ID).ExtractErr())
+	_, err = networks.Get(c.network, n.ID).Extract()
+	assertCode(t, err, 404)
+	allSubnets, err := subnets.List(c.network, nil).AllPages()
+	th.AssertNoErr(t, err)
+	subnetList, err := subnets.ExtractSubnets(allSubnets)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 0, len(subnetList))
+}
+
+func TestServerPorts(t *testing.T) {
+	cloud := fakecloud.New()
+	defer cloud.Close()
+	c := newClients(t, cloud)
+
+	_, err := servers.Create(c.compute, servers.CreateOpts{
+		Name:      "server",
+		ImageRef:  "cirros",
+		FlavorRef: "42",
+	}).Extract()
+	assertCode(t, err, 400)
+
+	a := createNetwork(t, c, "a", "10.0.0.0/24")
+	createNetwork(t, c, "b", "10.0.1.0/24")
+
+	// With several networks, one must be chosen.
+	_, err = servers.Create(c.compute, servers.CreateOpts{
+		Name:      "server",
+		ImageRef:  "cirros",
+		FlavorRef: "1",
+	}).Extract()
+	assertCode(t, err, 409)
+
+	s, err := servers.Create(c.compute, servers.CreateOpts{
+		Name:      "server",
+		ImageRef:  "cirros",
+		FlavorRef: "1",
+		Networks:  []servers.Network{{UUID: a.ID, FixedIP: "10.0.0.10"}},
+	}).Extract()
+	th.AssertNoErr(t, err)
+
+	allPages, err := ports.List(c.network, ports.ListOpts{DeviceID: s.ID}).AllPages()
+	th.AssertNoErr(t, err)
+	serverPorts, err := ports.ExtractPorts(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(serverPorts))
+	th.AssertEquals(t, "10.0.0.10", serverPorts[0].FixedIPs[0].IPAddress)
+
+	// The port was created for the server, so it's deleted with it.
+	th.AssertNoErr(t, servers.Delete(c.compute, s.ID).ExtractErr())
+	_, err = servers.Get(c.compute, s.ID).Extract()
+	assertCode(t, err, 404)
+	_, err = ports.Get(c.network, serverPorts[0].ID).Extract()
+	assertCode(t, err, 404)
+}
+
+func TestServerActions(t *testing.T) {
+	cloud := fakecloud.New()
+	cloud.TransitionReads = 1
+	defer cloud.Close()
+	c := newClients(t, cloud)
+
+	s, err := servers.Create(c.compute, servers.CreateOpts{
+		Name:      "server",
+		ImageRef:  "cirros",
+		FlavorRef: "1",
+	}).Extract()
+	th.AssertNoErr(t, err)
+
+	for _, status := range []string{"BUILD", "ACTIVE"} {
+		s, err = servers.Get(c.compute, s.ID).Extract()
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, status, s.Status)
+	}
+
+	assertCode(t, startstop.Start(c.compute, s.ID).ExtractErr(), 409)
+	th.AssertNoErr(t, startstop.Stop(c.compute, s.ID).ExtractErr())
+
+	// A server that is busy stopping takes no other action.
+	assertCode(t, servers.Reboot(c.compute, s.ID, &servers.RebootOpts{Type: servers.HardReboot}).ExtractErr(), 409)
+
+	for _, status := range []string{"ACTIVE", "SHUTOFF"} {
+		s, err = servers.Get(c.compute, s.ID).Extract()
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, status, s.Status)
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	cloud := fakecloud.New()
+	defer cloud.Close()
+	c := newClients(t, cloud)
+
+	for _, name := range []string{"c", "a", "e", "b", "d"} {
+		_, err := networks.Create(c.network, networks.CreateOpts{Name: name}).Extract()
+		th.AssertNoErr(t, err)
+	}
+
+	var names []string
+	pages := 0
+	err := networks.List(c.network, networks.ListOpts{Limit: 2, SortKey: "name", SortDir: "desc"}).EachPage(func(page pagination.Page) (bool, error) {
+		pages++
+		actual, err := networks.ExtractNetworks(page)
+		th.AssertNoErr(t, err)
+		for _, n := range actual {
+			names = append(names, n.Name)
+		}
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, pages)
+	th.AssertDeepEquals(t, []string{"e", "d", "c", "b", "a"}, names)
+
+	allPages, err := flavors.ListDetail(c.compute, flavors.ListOpts{MinRAM: 4096, Limit: 1}).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := flavors.ExtractFlavors(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, len(actual))
+	th.AssertEquals(t, "m1.medium", actual[0].Name)
+}
+
+func TestObjectStorage(t *testing.T) {
+	cloud := fakecloud.New()
+	defer cloud.Close()
+	c := newClients(t, cloud)
+
+	_, err := containers.Create(c.object, "docs", containers.CreateOpts{Metadata: map[string]string{"Owner": "demo"}}).Extract()
+	th.AssertNoErr(t, err)
+
+	for _, name := range []string{"a/1.txt", "a/2.txt", "b.txt"} {
+		_, err = objects.Create(c.object, "docs", name, objects.CreateOpts{
+			Content:     bytes.NewBufferString("content of " + name),
+			ContentType: "text/plain",
+		}).Extract()
+		th.AssertNoErr(t, err)
+	}
+
+	allPages, err := objects.List(c.object, "docs", objects.ListOpts{Prefix: "a/", Limit: 1}).AllPages()
+	th.AssertNoErr(t, err)
+	names, err := objects.ExtractNames(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, []string{"a/1.txt", "a/2.txt"}, names)
+
+	allPages, err = objects.List(c.object, "docs", objects.ListOpts{Full: true, Delimiter: "/"}).AllPages()
+	th.AssertNoErr(t, err)
+	info, err := objects.ExtractInfo(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(info))
+	th.AssertEquals(t, "a/", info[0].Subdir)
+	th.AssertEquals(t, "b.txt", info[1].Name)
+	th.AssertEquals(t, int64(len("content of b.txt")), info[1].Bytes)
+
+	_, err = objects.Copy(c.object, "docs", "b.txt", objects.CopyOpts{Destination: "docs/c.txt"}).Extract()
+	th.AssertNoErr(t, err)
+	download := objects.Download(c.object, "docs", "c.txt", nil)
+	content, err := download.ExtractContent()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "content of b.txt", string(content))
+
+	metadata, err := containers.Get(c.object, "docs").ExtractMetadata()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "demo", metadata["Owner"])
+
+	_, err = containers.Delete(c.object, "docs").Extract()
+	assertCode(t, err, 409)
+	for _, name := range []string{"a/1.txt", "a/2.txt", "b.txt", "c.txt"} {
+		_, err = objects.Delete(c.object, "docs", name, nil).Extract()
+		th.AssertNoErr(t, err)
+	}
+	_, err = containers.Delete(c.object, "docs").Extract()
+	th.AssertNoErr(t, err)
+	_, err = containers.Get(c.object, "docs").Extract()
+	assertCode(t, err, 404)
+}
+
+func TestReauthentication(t *testing.T) {
+	cloud := fakecloud.New()
+	defer cloud.Close()
+	c := newClients(t, cloud)
+
+	token := c.provider.Token()
+	cloud.RevokeTokens()
+
+	_, err := networks.List(c.network, nil).AllPages()
+	th.AssertNoErr(t, err)
+	if c.provider.Token() == token {
+		t.Fatalf("Expected the client to reauthenticate")
+	}
+}
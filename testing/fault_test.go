@@ -0,0 +1,111 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestParseFault(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		expected gophercloud.Fault
+	}{
+		{
+			name: "nova",
+			body: `{"badRequest": {"message": "Invalid flavorRef provided.", "code": 400}}`,
+			expected: gophercloud.Fault{
+				Type:    "badRequest",
+				Message: "Invalid flavorRef provided.",
+			},
+		},
+		{
+			name: "neutron",
+			body: `{"NeutronError": {"type": "PortNotFound", "message": "Port 1234 could not be found.", "detail": ""}}`,
+			expected: gophercloud.Fault{
+				Type:    "PortNotFound",
+				Message: "Port 1234 could not be found.",
+			},
+		},
+		{
+			name: "heat",
+			body: `{"explanation": "The server could not comply with the request since it is either malformed or otherwise incorrect.", "code": 400, "error": {"message": "Property error: resources.port.properties.network", "traceback": null, "type": "StackValidationFailed"}, "title": "Bad Request"}`,
+			expected: gophercloud.Fault{
+				Type:    "StackValidationFailed",
+				Message: "Property error: resources.port.properties.network",
+				Detail:  "The server could not comply with the request since it is either malformed or otherwise incorrect.",
+			},
+		},
+		{
+			name: "keystone",
+			body: `{"error": {"code": 401, "message": "The request you have made requires authentication.", "title": "Unauthorized"}}`,
+			expected: gophercloud.Fault{
+				Type:    "Unauthorized",
+				Message: "The request you have made requires authentication.",
+			},
+		},
+		{
+			name: "octavia",
+			body: `{"faultcode": "Client", "faultstring": "Load Balancer 1234 not found.", "debuginfo": null}`,
+			expected: gophercloud.Fault{
+				Type:    "Client",
+				Message: "Load Balancer 1234 not found.",
+			},
+		},
+		{
+			name: "errors list",
+			body: `{"errors": [{"status": 409, "title": "Conflict", "detail": "There was a conflict when trying to complete your request."}]}`,
+			expected: gophercloud.Fault{
+				Message: "Conflict",
+				Detail:  "There was a conflict when trying to complete your request.",
+			},
+		},
+		{
+			name: "swift",
+			body: "<html><h1>Not Found</h1><p>The resource could not be found.</p></html>",
+			expected: gophercloud.Fault{
+				Message: "Not Found The resource could not be found.",
+			},
+		},
+		{
+			name:     "empty",
+			body:     "",
+			expected: gophercloud.Fault{},
+		},
+	}
+
+	for _, c := range cases {
+		actual := gophercloud.ParseFault([]byte(c.body))
+		if actual != c.expected {
+			t.Errorf("%s: expected %#v, got %#v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestUnexpectedResponseCodeFault(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Compute-Request-Id", "req-1234")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"itemNotFound": {"message": "Instance 1234 could not be found.", "code": 404}}`)
+	})
+
+	p := &gophercloud.ProviderClient{}
+	_, err := p.Request("GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+
+	e, ok := err.(gophercloud.ErrDefault404)
+	if !ok {
+		t.Fatalf("expected ErrDefault404, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, "req-1234", e.RequestID)
+	th.AssertEquals(t, "req-1234", e.ResponseHeader.Get("X-Compute-Request-Id"))
+	th.AssertEquals(t, "itemNotFound", e.Fault.Type)
+	th.AssertEquals(t, "Instance 1234 could not be found.", e.Fault.Message)
+}
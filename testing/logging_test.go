@@ -0,0 +1,121 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/credentials"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestLoggerRedactsSecrets(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", "subject-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"access": {"token": {"id": "token-id", "expires": "never"}}, "adminPass": "hunter2"}`)
+	})
+
+	var entries []*gophercloud.RequestLogEntry
+	p := &gophercloud.ProviderClient{
+		TokenID:   "auth-token",
+		LogBodies: true,
+		Logger: gophercloud.LoggerFunc(func(e *gophercloud.RequestLogEntry) {
+			entries = append(entries, e)
+		}),
+	}
+
+	var actual map[string]interface{}
+	_, err := p.Request("POST", th.Endpoint()+"route", &gophercloud.RequestOpts{
+		JSONBody: map[string]interface{}{
+			"auth": map[string]interface{}{
+				"passwordCredentials": map[string]string{
+					"username": "user",
+					"password": "swordfish",
+				},
+			},
+		},
+		JSONResponse: &actual,
+	})
+	th.AssertNoErr(t, err)
+
+	// The caller still sees the unmasked response.
+	th.AssertEquals(t, "hunter2", actual["adminPass"])
+
+	th.AssertEquals(t, 1, len(entries))
+	e := entries[0]
+	th.AssertEquals(t, "POST", e.Method)
+	th.AssertEquals(t, th.Endpoint()+"route", e.URL)
+	th.AssertEquals(t, http.StatusCreated, e.StatusCode)
+	th.AssertEquals(t, "***", e.RequestHeader.Get("X-Auth-Token"))
+	th.AssertEquals(t, "***", e.ResponseHeader.Get("X-Subject-Token"))
+	th.AssertJSONEquals(t, `{"auth": {"passwordCredentials": {"username": "user", "password": "***"}}}`, json.RawMessage(e.RequestBody))
+	th.AssertJSONEquals(t, `{"access": {"token": {"id": "***", "expires": "never"}}, "adminPass": "***"}`, json.RawMessage(e.ResponseBody))
+}
+
+func TestLoggerRedactsCredentialBlobs(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	blob := `{"access":"181920","secret":"secretKey"}`
+	th.Mux.HandleFunc("/credentials", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"credential": {"id": "c1", "type": "ec2", "user_id": "u1", "blob": %q}}`, blob)
+	})
+
+	var entries []*gophercloud.RequestLogEntry
+	sc := client.ServiceClient()
+	sc.LogBodies = true
+	sc.Logger = gophercloud.LoggerFunc(func(e *gophercloud.RequestLogEntry) {
+		entries = append(entries, e)
+	})
+
+	credential, err := credentials.Create(sc, credentials.CreateOpts{
+		Blob:   blob,
+		Type:   "ec2",
+		UserID: "u1",
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, blob, credential.Blob)
+
+	th.AssertEquals(t, 1, len(entries))
+	th.AssertJSONEquals(t, `{"credential": {"blob": "***", "type": "ec2", "user_id": "u1"}}`, json.RawMessage(entries[0].RequestBody))
+	th.AssertJSONEquals(t, `{"credential": {"id": "c1", "type": "ec2", "user_id": "u1", "blob": "***"}}`, json.RawMessage(entries[0].ResponseBody))
+}
+
+func TestLoggerWithoutBodies(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"itemNotFound": {"message": "not found", "code": 404}}`)
+	})
+
+	var entries []*gophercloud.RequestLogEntry
+	p := &gophercloud.ProviderClient{
+		Logger: gophercloud.LoggerFunc(func(e *gophercloud.RequestLogEntry) {
+			entries = append(entries, e)
+		}),
+	}
+
+	_, err := p.Request("GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("expected ErrDefault404, got %T: %v", err, err)
+	}
+
+	th.AssertEquals(t, 1, len(entries))
+	th.AssertEquals(t, http.StatusNotFound, entries[0].StatusCode)
+	if entries[0].ResponseBody != nil || entries[0].RequestBody != nil {
+		t.Errorf("expected no bodies to be logged")
+	}
+}
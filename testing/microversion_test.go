@@ -0,0 +1,67 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestCompareMicroversions(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"2.1", "2.1", 0},
+		{"2.9", "2.10", -1},
+		{"2.53", "2.10", 1},
+		{"3.0", "2.99", 1},
+		{"v2.1", "2.1", 0},
+	}
+	for _, c := range cases {
+		actual, err := gophercloud.CompareMicroversions(c.a, c.b)
+		th.AssertNoErr(t, err)
+		if actual != c.expected {
+			t.Errorf("CompareMicroversions(%q, %q): expected %d, got %d", c.a, c.b, c.expected, actual)
+		}
+	}
+
+	_, err := gophercloud.CompareMicroversions("latest", "2.1")
+	if err == nil {
+		t.Errorf("expected an error for an invalid microversion")
+	}
+}
+
+func TestRequireMicroversion(t *testing.T) {
+	c := &gophercloud.ServiceClient{Microversion: "2.14"}
+	th.AssertNoErr(t, c.RequireMicroversion("Operation", "2.7"))
+	th.AssertNoErr(t, c.RequireMicroversion("Operation", "2.14"))
+
+	err := c.RequireMicroversion("Operation", "2.40")
+	th.AssertEquals(t, "Operation requires microversion 2.40 or later, but the client uses microversion 2.14", err.Error())
+
+	c.Microversion = ""
+	err = c.RequireMicroversion("Operation", "2.7")
+	th.AssertEquals(t, "Operation requires microversion 2.7 or later, but the client uses microversion none", err.Error())
+}
+
+func TestRequireMicroversionFields(t *testing.T) {
+	type opts struct {
+		Name string
+		Tags []string `microversion:"2.52"`
+	}
+
+	c := &gophercloud.ServiceClient{Microversion: "2.1"}
+	th.AssertNoErr(t, c.RequireMicroversionFields(opts{Name: "foo"}))
+
+	err := c.RequireMicroversionFields(&opts{Tags: []string{"foo"}})
+	e, ok := err.(gophercloud.ErrMicroversionRequired)
+	if !ok {
+		t.Fatalf("expected ErrMicroversionRequired, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, "opts.Tags", e.Feature)
+	th.AssertEquals(t, "2.52", e.Required)
+
+	c.Microversion = "2.60"
+	th.AssertNoErr(t, c.RequireMicroversionFields(&opts{Tags: []string{"foo"}}))
+}
@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"fmt"
 	"net/url"
 	"reflect"
 	"testing"
@@ -88,6 +89,186 @@ func TestBuildQueryString(t *testing.T) {
 	}
 }
 
+func TestBuildQueryStringSortKeys(t *testing.T) {
+	keys := []gophercloud.SortKey{
+		{Key: "name", Direction: gophercloud.SortAsc},
+		{Key: "id"},
+		{Key: "created_at", Direction: gophercloud.SortDesc},
+	}
+	opts := struct {
+		Sort  []gophercloud.SortKey `q:"sort"`
+		Pairs []gophercloud.SortKey `q:"sort_key,sort_dir"`
+	}{
+		Sort:  keys,
+		Pairs: keys,
+	}
+
+	actual, err := gophercloud.BuildQueryString(opts)
+	th.AssertNoErr(t, err)
+
+	// Directions are paired with keys by position, so the key without one
+	// gets the default direction.
+	th.CheckDeepEquals(t, url.Values{
+		"sort":     []string{"name:asc,id,created_at:desc"},
+		"sort_key": []string{"name", "id", "created_at"},
+		"sort_dir": []string{"asc", "asc", "desc"},
+	}, actual.Query())
+	th.CheckEquals(t, "sort=name%3Aasc%2Cid%2Ccreated_at%3Adesc&sort_dir=asc&sort_dir=asc&sort_dir=desc&sort_key=name&sort_key=id&sort_key=created_at", actual.RawQuery)
+
+	// Keys without any direction leave it to the service.
+	opts.Sort = nil
+	opts.Pairs = []gophercloud.SortKey{{Key: "name"}, {Key: "id"}}
+	actual, err = gophercloud.BuildQueryString(opts)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, url.Values{
+		"sort_key": []string{"name", "id"},
+	}, actual.Query())
+}
+
+func TestBuildQueryStringTimes(t *testing.T) {
+	created := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	opts := struct {
+		ChangesSince  time.Time               `q:"changes-since"`
+		ChangesBefore *time.Time              `q:"changes-before" format:"2006-01-02T15:04:05.000000"`
+		CreatedAt     *gophercloud.TimeFilter `q:"created_at"`
+		UpdatedAt     gophercloud.TimeFilter  `q:"updated_at"`
+		Unset         *gophercloud.TimeFilter `q:"unset"`
+		Zero          time.Time               `q:"zero"`
+	}{
+		ChangesSince:  created,
+		ChangesBefore: &created,
+		CreatedAt:     &gophercloud.TimeFilter{Operator: gophercloud.FilterGTE, Time: created},
+		UpdatedAt:     gophercloud.TimeFilter{Time: created},
+	}
+
+	actual, err := gophercloud.BuildQueryString(opts)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, url.Values{
+		"changes-since":  []string{"2020-01-02T03:04:05Z"},
+		"changes-before": []string{"2020-01-02T03:04:05.000000"},
+		"created_at":     []string{"gte:2020-01-02T03:04:05Z"},
+		"updated_at":     []string{"2020-01-02T03:04:05Z"},
+	}, actual.Query())
+
+	text, err := gophercloud.TimeFilter{Operator: gophercloud.FilterLT, Time: created}.MarshalText()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "lt:2020-01-02T03:04:05Z", string(text))
+}
+
+type testLevel int
+
+func (l testLevel) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("level-%d", l)), nil
+}
+
+func TestBuildQueryStringTypes(t *testing.T) {
+	type Paging struct {
+		Limit  int    `q:"limit"`
+		Marker string `q:"marker"`
+	}
+	type Filters struct {
+		Owner string `q:"owner"`
+		Since int64  `q:"since"`
+	}
+
+	iFalse := false
+	level := testLevel(3)
+	opts := struct {
+		Paging
+		Filters   *Filters          `q:"filters"`
+		Level     testLevel         `q:"level"`
+		MinLevel  *testLevel        `q:"min_level"`
+		Levels    []testLevel       `q:"levels"`
+		Flags     []bool            `q:"flag"`
+		Ratio     float64           `q:"ratio"`
+		Size      uint              `q:"size"`
+		Shared    *bool             `q:"shared,omitempty"`
+		Public    *bool             `q:"public"`
+		Hidden    bool              `q:"hidden,omitempty"`
+		Extra     map[string]string `q:",inline"`
+		Counts    map[string]int    `q:"counts"`
+		Metadata  map[string]string `q:"metadata"`
+		Untagged  string
+		Forbidden string `q:"-"`
+	}{
+		Paging:    Paging{Limit: 10, Marker: "abc"},
+		Filters:   &Filters{Owner: "me", Since: 1},
+		Level:     2,
+		MinLevel:  &level,
+		Levels:    []testLevel{1, 2},
+		Flags:     []bool{true, false},
+		Ratio:     0.5,
+		Size:      7,
+		Shared:    &iFalse,
+		Public:    &iFalse,
+		Extra:     map[string]string{"b": "2", "a": "1"},
+		Counts:    map[string]int{"y": 2, "x": 1},
+		Metadata:  map[string]string{"k1": "v1"},
+		Untagged:  "untagged",
+		Forbidden: "forbidden",
+	}
+
+	actual, err := gophercloud.BuildQueryString(opts)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, url.Values{
+		"limit":     []string{"10"},
+		"marker":    []string{"abc"},
+		"owner":     []string{"me"},
+		"since":     []string{"1"},
+		"level":     []string{"level-2"},
+		"min_level": []string{"level-3"},
+		"levels":    []string{"level-1", "level-2"},
+		"flag":      []string{"true", "false"},
+		"ratio":     []string{"0.5"},
+		"size":      []string{"7"},
+		"public":    []string{"false"},
+		"a":         []string{"1"},
+		"b":         []string{"2"},
+		"counts":    []string{"{'x':'1', 'y':'2'}"},
+		"metadata":  []string{"{'k1':'v1'}"},
+	}, actual.Query())
+}
+
+func TestBuildQueryStringValidation(t *testing.T) {
+	type Range struct {
+		Min int `q:"min" or:"Max"`
+		Max int `q:"max" or:"Min"`
+	}
+	type opts struct {
+		Name  string `q:"name" required:"true"`
+		Range *Range `q:"range"`
+		Other string `required:"true"`
+	}
+
+	_, err := gophercloud.BuildQueryString(opts{Range: &Range{Min: 1}})
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Fatalf("expected ErrMissingInput, got %T: %v", err, err)
+	}
+	th.CheckEquals(t, "Required query parameter [Name] not set.", err.Error())
+
+	// A pointer to an empty struct is validated too.
+	_, err = gophercloud.BuildQueryString(opts{Name: "n", Range: &Range{}})
+	th.CheckEquals(t, "Range.Min/Range.Max", err.(gophercloud.ErrMissingInput).Argument)
+
+	type nested struct {
+		Inner struct {
+			ID   string `q:"id" required:"true"`
+			Name string `q:"name" xor:"ID"`
+		} `q:"inner"`
+		Limit int `q:"limit" required:"true"`
+	}
+	o := nested{}
+	o.Inner.ID = "a"
+	o.Inner.Name = "b"
+	_, err = gophercloud.BuildQueryString(o)
+	verr, ok := err.(gophercloud.ErrInvalidOptions)
+	if !ok {
+		t.Fatalf("expected ErrInvalidOptions, got %T: %v", err, err)
+	}
+	th.CheckEquals(t, 2, len(verr.Errors))
+	th.CheckEquals(t, "Invalid options: Exactly one of Inner.Name and Inner.ID must be provided; Required query parameter [Limit] not set.", err.Error())
+}
+
 func TestBuildHeaders(t *testing.T) {
 	testStruct := struct {
 		Accept string `h:"Accept"`
@@ -274,3 +455,87 @@ func TestBuildRequestBody(t *testing.T) {
 	th.AssertDeepEquals(t, expectedComplexFields, actual)
 
 }
+
+func TestBuildRequestBodyValidationErrors(t *testing.T) {
+	type Rule struct {
+		Local  string `json:"local" required:"true"`
+		Remote string `json:"remote,omitempty" xor:"Regex"`
+		Regex  string `json:"regex,omitempty" xor:"Remote"`
+	}
+	type Mapping struct {
+		ID    string  `json:"id" required:"true"`
+		Rules []Rule  `json:"rules" required:"true"`
+		Ptrs  []*Rule `json:"ptrs,omitempty"`
+	}
+	type Opts struct {
+		Name    string   `json:"name" required:"true"`
+		Mapping *Mapping `json:"mapping,omitempty"`
+	}
+
+	opts := Opts{
+		Mapping: &Mapping{
+			ID: "m",
+			Rules: []Rule{
+				{Local: "a", Remote: "b"},
+				{Remote: "c", Regex: "d"},
+			},
+			Ptrs: []*Rule{nil, {Remote: "e"}},
+		},
+	}
+
+	_, err := gophercloud.BuildRequestBody(opts, "")
+	verr, ok := err.(gophercloud.ErrInvalidOptions)
+	if !ok {
+		t.Fatalf("expected ErrInvalidOptions, got %T: %v", err, err)
+	}
+
+	var arguments []string
+	for _, e := range verr.Errors {
+		arguments = append(arguments, e.(gophercloud.ErrMissingInput).Argument)
+	}
+	th.CheckDeepEquals(t, []string{
+		"Name",
+		"Mapping.Rules[1].Local",
+		"Mapping.Rules[1].Remote/Mapping.Rules[1].Regex",
+		"Mapping.Ptrs[1].Local",
+	}, arguments)
+
+	opts.Name = "n"
+	opts.Mapping.Rules[1] = Rule{Local: "c", Regex: "d"}
+	opts.Mapping.Ptrs = nil
+	actual, err := gophercloud.BuildRequestBody(opts, "")
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, map[string]interface{}{
+		"name": "n",
+		"mapping": map[string]interface{}{
+			"id": "m",
+			"rules": []interface{}{
+				map[string]interface{}{"local": "a", "remote": "b"},
+				map[string]interface{}{"local": "c", "regex": "d"},
+			},
+		},
+	}, actual)
+}
+
+func TestBuildRequestBodyValidatesEmptyNested(t *testing.T) {
+	type Inner struct {
+		Name string `json:"name" required:"true"`
+	}
+	type Outer struct {
+		In    *Inner  `json:"in,omitempty"`
+		Items []Inner `json:"items,omitempty"`
+		Value Inner   `json:"-"`
+	}
+
+	// A pointer to an empty struct is sent, so it's validated.
+	_, err := gophercloud.BuildRequestBody(Outer{In: &Inner{}}, "")
+	th.CheckEquals(t, "In.Name", err.(gophercloud.ErrMissingInput).Argument)
+
+	// So is an empty element of a slice of structs.
+	_, err = gophercloud.BuildRequestBody(Outer{Items: []Inner{{Name: "a"}, {}}}, "")
+	th.CheckEquals(t, "Items[1].Name", err.(gophercloud.ErrMissingInput).Argument)
+
+	// A struct field that isn't set isn't.
+	_, err = gophercloud.BuildRequestBody(Outer{}, "")
+	th.AssertNoErr(t, err)
+}
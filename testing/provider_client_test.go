@@ -1,9 +1,13 @@
 package testing
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -122,3 +126,248 @@ func TestConcurrentReauth(t *testing.T) {
 
 	th.AssertEquals(t, 1, info.numreauths)
 }
+
+func TestRequestWithContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "OK")
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &gophercloud.ProviderClient{Context: ctx}
+
+	res, err := p.Request("GET", ts.URL, &gophercloud.RequestOpts{})
+	th.AssertNoErr(t, err)
+	_, err = ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	th.AssertNoErr(t, err)
+
+	cancel()
+	res, err = p.Request("GET", ts.URL, &gophercloud.RequestOpts{})
+	if err == nil {
+		t.Fatal("expecting error, got nil")
+	}
+	if !strings.Contains(err.Error(), ctx.Err().Error()) {
+		t.Fatalf("expecting error to contain: %q, got %q", ctx.Err().Error(), err.Error())
+	}
+}
+
+func TestRequestOptsContextOverridesProvider(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "OK")
+	}))
+	defer ts.Close()
+
+	p := &gophercloud.ProviderClient{Context: context.Background()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := p.Request("GET", ts.URL, &gophercloud.RequestOpts{Context: ctx})
+	if err == nil {
+		t.Fatal("expecting error, got nil")
+	}
+	if !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Fatalf("expecting error to contain: %q, got %q", context.Canceled.Error(), err.Error())
+	}
+}
+
+func TestRequestRetry(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var calls int
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, err := ioutil.ReadAll(r.Body)
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, "payload", string(body))
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	p := &gophercloud.ProviderClient{
+		RetryPolicy: &gophercloud.RetryPolicy{},
+	}
+
+	_, err := p.Request("PUT", th.Endpoint()+"route", &gophercloud.RequestOpts{
+		RawBody: strings.NewReader("payload"),
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, calls)
+}
+
+func TestRequestRetryExhausted(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var calls int
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(429)
+	})
+
+	p := &gophercloud.ProviderClient{
+		RetryPolicy: &gophercloud.RetryPolicy{
+			MaxAttempts: 2,
+			BaseDelay:   time.Millisecond,
+		},
+	}
+
+	_, err := p.Request("GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	if _, ok := err.(gophercloud.ErrDefault429); !ok {
+		t.Fatalf("expected ErrDefault429, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, 2, calls)
+}
+
+func TestRequestRetryNonIdempotent(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var calls int
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	p := &gophercloud.ProviderClient{
+		RetryPolicy: &gophercloud.RetryPolicy{},
+	}
+
+	_, err := p.Request("POST", th.Endpoint()+"route", &gophercloud.RequestOpts{
+		JSONBody: map[string]string{"foo": "bar"},
+	})
+	if _, ok := err.(gophercloud.ErrDefault503); !ok {
+		t.Fatalf("expected ErrDefault503, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, 1, calls)
+
+	calls = 0
+	_, err = p.Request("POST", th.Endpoint()+"route", &gophercloud.RequestOpts{
+		JSONBody:           map[string]string{"foo": "bar"},
+		RetryNonIdempotent: true,
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, calls)
+}
+
+func TestRequestRetryContextCancelled(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	p := &gophercloud.ProviderClient{
+		Context:     ctx,
+		RetryPolicy: &gophercloud.RetryPolicy{MaxDelay: time.Minute},
+	}
+
+	_, err := p.Request("GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertEquals(t, context.DeadlineExceeded, err)
+}
+
+func TestRequestReusesConnections(t *testing.T) {
+	var mut sync.Mutex
+	var conns int
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"itemNotFound": {"message": "Not found", "code": 404}}`)
+			return
+		}
+		// Trailing data after the JSON document must be drained as well.
+		fmt.Fprintf(w, `{"foo": "bar"}`+"\n\n")
+	}))
+	ts.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mut.Lock()
+			conns++
+			mut.Unlock()
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	p := &gophercloud.ProviderClient{}
+	for i := 0; i < 3; i++ {
+		var actual map[string]string
+		_, err := p.Request("GET", ts.URL+"/json", &gophercloud.RequestOpts{
+			JSONResponse: &actual,
+		})
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, "bar", actual["foo"])
+
+		_, err = p.Request("GET", ts.URL+"/error", &gophercloud.RequestOpts{})
+		if _, ok := err.(gophercloud.ErrDefault404); !ok {
+			t.Fatalf("expected ErrDefault404, got %T: %v", err, err)
+		}
+	}
+
+	mut.Lock()
+	defer mut.Unlock()
+	th.AssertEquals(t, 1, conns)
+}
+
+func TestProactiveReauth(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	prereauthTok := client.TokenID
+	postreauthTok := "12345678"
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		// A non-seekable body could not be replayed after a 401.
+		th.TestHeader(t, r, "X-Auth-Token", postreauthTok)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	var mut sync.Mutex
+	var numreauths int
+
+	p := new(gophercloud.ProviderClient)
+	p.UseTokenLock()
+	p.SetToken(prereauthTok)
+	p.SetTokenExpiry(time.Now().Add(10 * time.Second))
+	p.TokenRenewalWindow = time.Minute
+	p.ReauthFunc = func() error {
+		mut.Lock()
+		numreauths++
+		mut.Unlock()
+		p.TokenID = postreauthTok
+		p.TokenExpiresAt = time.Now().Add(time.Hour)
+		return nil
+	}
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body := ioutil.NopCloser(strings.NewReader("data"))
+			_, err := p.Request("PUT", th.Endpoint()+"route", &gophercloud.RequestOpts{
+				RawBody: body,
+			})
+			th.CheckNoErr(t, err)
+		}()
+	}
+	wg.Wait()
+
+	th.AssertEquals(t, 1, numreauths)
+	th.AssertEquals(t, postreauthTok, p.Token())
+}
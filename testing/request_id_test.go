@@ -0,0 +1,42 @@
+package testing
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestNewGlobalRequestID(t *testing.T) {
+	id, err := gophercloud.NewGlobalRequestID()
+	th.AssertNoErr(t, err)
+
+	re := regexp.MustCompile(`^req-[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if !re.MatchString(id) {
+		t.Errorf("unexpected global request ID format: %s", id)
+	}
+}
+
+func TestGlobalRequestID(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	id := "req-3f7d8e7c-5c1a-4b7e-9f5a-0d1e2c3b4a59"
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Openstack-Request-Id", id)
+		w.Header().Set("X-Openstack-Request-Id", "req-local")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ctx := gophercloud.WithGlobalRequestID(context.Background(), id)
+	th.AssertEquals(t, id, gophercloud.GlobalRequestIDFromContext(ctx))
+
+	c := &gophercloud.ServiceClient{ProviderClient: new(gophercloud.ProviderClient)}
+	resp, err := c.WithContext(ctx).Get(th.Endpoint()+"route", nil, nil)
+	_, header, err := gophercloud.ParseResponse(resp, err)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "req-local", gophercloud.RequestIDFromHeader(header))
+}
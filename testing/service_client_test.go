@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -32,3 +33,31 @@ func TestMoreHeaders(t *testing.T) {
 	th.AssertNoErr(t, err)
 	th.AssertEquals(t, resp.Request.Header.Get("custom"), "header")
 }
+
+func TestWithContext(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c := new(gophercloud.ServiceClient)
+	c.ProviderClient = new(gophercloud.ProviderClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cc := c.WithContext(ctx)
+	th.AssertEquals(t, ctx, cc.RequestContext())
+	th.AssertEquals(t, context.Background(), c.RequestContext())
+
+	_, err := cc.Get(fmt.Sprintf("%s/route", th.Endpoint()), nil, nil)
+	th.AssertNoErr(t, err)
+
+	cancel()
+	_, err = cc.Get(fmt.Sprintf("%s/route", th.Endpoint()), nil, nil)
+	if err == nil {
+		t.Fatal("expecting error, got nil")
+	}
+
+	_, err = c.Get(fmt.Sprintf("%s/route", th.Endpoint()), nil, nil)
+	th.AssertNoErr(t, err)
+}
@@ -0,0 +1,106 @@
+package testing
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestFileTokenCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gophercloud-tokens")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	cache, err := gophercloud.NewFileTokenCache(filepath.Join(dir, "tokens"))
+	th.AssertNoErr(t, err)
+
+	info, err := os.Stat(filepath.Join(dir, "tokens"))
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, os.FileMode(0700), info.Mode().Perm())
+
+	token, err := cache.Get("key")
+	th.AssertNoErr(t, err)
+	if token != nil {
+		t.Fatalf("expected no token, got %#v", token)
+	}
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	th.AssertNoErr(t, cache.Set("key", &gophercloud.CachedToken{
+		TokenID:         "token",
+		ExpiresAt:       expiresAt,
+		IdentityVersion: "v3",
+		Catalog:         []byte(`{"catalog":[]}`),
+	}))
+
+	token, err = cache.Get("key")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token", token.TokenID)
+	th.AssertEquals(t, true, token.ExpiresAt.Equal(expiresAt))
+	th.AssertEquals(t, `{"catalog":[]}`, string(token.Catalog))
+
+	th.AssertNoErr(t, cache.Delete("key"))
+	th.AssertNoErr(t, cache.Delete("key"))
+	token, err = cache.Get("key")
+	th.AssertNoErr(t, err)
+	if token != nil {
+		t.Fatalf("expected no token after Delete, got %#v", token)
+	}
+}
+
+func TestFileTokenCacheRejectsSharedDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gophercloud-tokens")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+	th.AssertNoErr(t, os.Chmod(dir, 0755))
+
+	_, err = gophercloud.NewFileTokenCache(dir)
+	if err == nil {
+		t.Fatal("expected an error for a directory readable by other users")
+	}
+}
+
+type memoryTokenCache map[string]*gophercloud.CachedToken
+
+func (c memoryTokenCache) Get(key string) (*gophercloud.CachedToken, error) {
+	return c[key], nil
+}
+
+func (c memoryTokenCache) Set(key string, token *gophercloud.CachedToken) error {
+	c[key] = token
+	return nil
+}
+
+func (c memoryTokenCache) Delete(key string) error {
+	delete(c, key)
+	return nil
+}
+
+func TestCachedTokenEvictedOn401(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	cache := memoryTokenCache{
+		"key": {TokenID: "revoked", ExpiresAt: time.Now().Add(time.Hour)},
+	}
+	p := &gophercloud.ProviderClient{
+		TokenID:       "revoked",
+		TokenCache:    cache,
+		TokenCacheKey: "key",
+	}
+
+	_, err := p.Request("GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	if _, ok := err.(gophercloud.ErrDefault401); !ok {
+		t.Fatalf("expected ErrDefault401, got %T: %v", err, err)
+	}
+	th.AssertEquals(t, 0, len(cache))
+}
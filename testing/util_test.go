@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -53,6 +54,16 @@ func TestWaitForPredicateExceed(t *testing.T) {
 	th.AssertEquals(t, "A timeout occurred", err.Error())
 }
 
+func TestWaitForContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := gophercloud.WaitForContext(ctx, 60, func() (bool, error) {
+		return false, nil
+	})
+	th.AssertEquals(t, context.Canceled, err)
+}
+
 func TestNormalizeURL(t *testing.T) {
 	urls := []string{
 		"NoSlashAtEnd",
@@ -0,0 +1,180 @@
+package gophercloud
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// CachedToken is a token stored in a TokenCache together with everything
+// needed to use it without authenticating again.
+type CachedToken struct {
+	// TokenID is the token itself.
+	TokenID string `json:"token_id"`
+
+	// ExpiresAt is the time at which the token expires.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// IdentityVersion is the version of the identity API that issued the
+	// token, such as "v2.0" or "v3".
+	IdentityVersion string `json:"identity_version"`
+
+	// IdentityEndpoint is the versioned identity endpoint that issued the
+	// token. It is used to reauthenticate once the token expires.
+	IdentityEndpoint string `json:"identity_endpoint"`
+
+	// Catalog is the service catalog returned with the token, in the format
+	// of the identity API that issued it.
+	Catalog json.RawMessage `json:"catalog"`
+}
+
+// TokenCache stores tokens across ProviderClient instances, typically so
+// that short-lived processes can reuse a token instead of authenticating on
+// every run. Keys are opaque strings that identify the auth URL, user and
+// scope a token was issued for; they never contain credentials.
+//
+// Get returns a nil token and a nil error if nothing is stored under key.
+// Implementations must be safe for concurrent use.
+type TokenCache interface {
+	Get(key string) (*CachedToken, error)
+	Set(key string, token *CachedToken) error
+	Delete(key string) error
+}
+
+// FileTokenCache is a TokenCache that stores each token in its own file
+// within a directory that only the current user can access.
+type FileTokenCache struct {
+	dir string
+}
+
+// NewFileTokenCache returns a FileTokenCache that stores tokens in dir,
+// creating the directory with mode 0700 if needed. An existing directory
+// that can be accessed by other users is rejected. If dir is empty, a
+// "gophercloud/tokens" directory within os.UserCacheDir is used.
+func NewFileTokenCache(dir string) (*FileTokenCache, error) {
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(base, "gophercloud", "tokens")
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("token cache path %s is not a directory", dir)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("token cache directory %s must not be accessible by other users (mode %#o)", dir, info.Mode().Perm())
+	}
+
+	return &FileTokenCache{dir: dir}, nil
+}
+
+// path returns the file that holds the token stored under key. Keys are
+// hashed so that they are safe to use as file names.
+func (c *FileTokenCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements TokenCache. Files that aren't regular files or that can be
+// read by other users are removed and treated as missing.
+func (c *FileTokenCache) Get(key string) (*CachedToken, error) {
+	path := c.path(key)
+
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() || (runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0) {
+		return nil, os.Remove(path)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var token CachedToken
+	if err := json.Unmarshal(b, &token); err != nil {
+		// A corrupt entry is no better than a missing one.
+		return nil, os.Remove(path)
+	}
+	return &token, nil
+}
+
+// Set implements TokenCache. The token is written to a temporary file with
+// mode 0600 which then replaces any previous entry, so that concurrent
+// readers never see a partial file.
+func (c *FileTokenCache) Set(key string, token *CachedToken) error {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(c.dir, ".token-")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+
+	if err := f.Chmod(0600); err != nil && runtime.GOOS != "windows" {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, c.path(key)); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Delete implements TokenCache.
+func (c *FileTokenCache) Delete(key string) error {
+	err := os.Remove(c.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// evictCachedToken removes the token stored under client.TokenCacheKey if it
+// is still the given token. It is called when a request using that token is
+// rejected with a 401, so that other processes don't keep trying it. Tokens
+// that have since been replaced by a reauthentication are left alone.
+func (client *ProviderClient) evictCachedToken(token string) {
+	if client.TokenCache == nil || client.TokenCacheKey == "" || token == "" {
+		return
+	}
+	cached, err := client.TokenCache.Get(client.TokenCacheKey)
+	if err != nil || cached == nil || cached.TokenID != token {
+		return
+	}
+	client.TokenCache.Delete(client.TokenCacheKey)
+}